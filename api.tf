@@ -2,6 +2,9 @@ resource "aws_api_gateway_rest_api" "api" {
   name        = "${var.prefix}-volleygoals"
   description = "API for VolleyGoals application"
 
+  # Progress report exports are returned as base64 encoded PDFs
+  binary_media_types = ["application/pdf"]
+
   endpoint_configuration {
     types = ["REGIONAL"]
   }
@@ -127,6 +130,13 @@ resource "aws_api_gateway_deployment" "this" {
     module.update_team_member_ms,
     module.delete_team_member_ms,
     module.leave_team_ms,
+    module.get_member_development_ms,
+    module.transfer_team_member_ms,
+    # Handovers
+    module.create_handover_ms,
+    module.list_handovers_ms,
+    module.respond_to_handover_ms,
+    module.cancel_handover_ms,
     # Invites
     module.create_invite_ms,
     module.complete_invite_ms,
@@ -138,6 +148,14 @@ resource "aws_api_gateway_deployment" "this" {
     module.get_user_ms,
     module.delete_user_ms,
     module.update_user_ms,
+    module.list_user_memberships_ms,
+    # Guardians
+    module.create_guardianship_ms,
+    module.list_guardians_ms,
+    module.delete_guardianship_ms,
+    module.list_wards_ms,
+    module.get_ward_overview_ms,
+    module.give_guardian_consent_ms,
     # Seasons
     module.create_season_ms,
     module.list_seasons_ms,
@@ -145,6 +163,89 @@ resource "aws_api_gateway_deployment" "this" {
     module.update_season_ms,
     module.delete_season_ms,
     module.get_season_stats_ms,
+    module.clone_season_ms,
+    # Questionnaires
+    module.get_season_questionnaire_ms,
+    module.update_season_questionnaire_ms,
+    module.delete_season_questionnaire_ms,
+    # Calendar events
+    module.create_event_ms,
+    module.list_events_ms,
+    module.get_event_ms,
+    module.update_event_ms,
+    module.delete_event_ms,
+    module.get_event_attendance_ms,
+    module.update_event_attendance_ms,
+    module.list_season_attendance_ms,
+    # Match statistics
+    module.get_match_stats_ms,
+    module.update_match_stats_ms,
+    module.delete_match_stats_ms,
+    module.get_season_match_stats_ms,
+    module.get_player_match_stats_ms,
+    # Lineups
+    module.get_lineup_ms,
+    module.update_lineup_ms,
+    module.delete_lineup_ms,
+    module.get_season_lineup_history_ms,
+    module.get_player_lineup_history_ms,
+    # Skills
+    module.get_skill_catalogue_ms,
+    module.update_skill_catalogue_ms,
+    module.get_skill_matrix_ms,
+    module.create_skill_assessment_ms,
+    module.list_skill_assessments_ms,
+    module.delete_skill_assessment_ms,
+    module.get_member_skill_radar_ms,
+    # Fitness tests
+    module.create_fitness_test_ms,
+    module.list_fitness_tests_ms,
+    module.update_fitness_test_ms,
+    module.delete_fitness_test_ms,
+    module.create_fitness_result_ms,
+    module.list_fitness_results_ms,
+    module.delete_fitness_result_ms,
+    module.get_fitness_leaderboard_ms,
+    module.get_member_personal_bests_ms,
+    # Media references
+    module.create_goal_media_ms,
+    module.update_goal_media_ms,
+    module.delete_goal_media_ms,
+    module.create_progress_entry_media_ms,
+    module.update_progress_entry_media_ms,
+    module.delete_progress_entry_media_ms,
+    module.create_comment_media_ms,
+    module.update_comment_media_ms,
+    module.delete_comment_media_ms,
+    # Organizations
+    module.create_organization_ms,
+    module.list_organizations_ms,
+    module.get_organization_ms,
+    module.update_organization_ms,
+    module.delete_organization_ms,
+    module.list_organization_members_ms,
+    module.add_organization_member_ms,
+    module.update_organization_member_ms,
+    module.remove_organization_member_ms,
+    # Team roles
+    module.list_team_roles_ms,
+    module.create_team_role_ms,
+    module.update_team_role_ms,
+    module.delete_team_role_ms,
+    # Drills
+    module.create_drill_ms,
+    module.list_drills_ms,
+    module.get_drill_ms,
+    module.update_drill_ms,
+    module.delete_drill_ms,
+    module.upload_drill_media_ms,
+    module.suggest_drills_for_goal_ms,
+    # Subgroups
+    module.create_subgroup_ms,
+    module.list_subgroups_ms,
+    module.get_subgroup_ms,
+    module.update_subgroup_ms,
+    module.delete_subgroup_ms,
     # Goals
     module.create_goal_ms,
     module.list_goals_ms,
@@ -158,6 +259,8 @@ resource "aws_api_gateway_deployment" "this" {
     module.get_progress_report_ms,
     module.update_progress_report_ms,
     module.delete_progress_report_ms,
+    module.export_member_progress_reports_ms,
+    module.export_progress_report_ms,
     # Comments
     module.create_comment_ms,
     module.list_comments_ms,
@@ -211,4 +211,460 @@ resource "aws_dynamodb_table" "activities" {
   tags = local.tags
 }
 
+resource "aws_dynamodb_table" "questionnaires" {
+  name         = "${var.prefix}-questionnaires"
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "id"
+
+  attribute {
+    name = "id"
+    type = "S"
+  }
+  attribute {
+    name = "seasonId"
+    type = "S"
+  }
+
+  global_secondary_index {
+    name            = "seasonIdIndex"
+    hash_key        = "seasonId"
+    projection_type = "ALL"
+  }
+
+  tags = local.tags
+}
+
+# Precomputed season analytics, keyed by season ID. Rollups expire through TTL.
+resource "aws_dynamodb_table" "season_stats" {
+  name         = "${var.prefix}-season-stats"
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "id"
+
+  attribute {
+    name = "id"
+    type = "S"
+  }
+
+  ttl {
+    attribute_name = "expiresAt"
+    enabled        = true
+  }
+
+  tags = local.tags
+}
+
+# Calendar events (trainings, matches, ...) of a season.
+resource "aws_dynamodb_table" "events" {
+  name         = "${var.prefix}-events"
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "id"
+
+  attribute {
+    name = "id"
+    type = "S"
+  }
+  attribute {
+    name = "seasonId"
+    type = "S"
+  }
+
+  global_secondary_index {
+    name            = "seasonIdIndex"
+    hash_key        = "seasonId"
+    projection_type = "ALL"
+  }
+
+  tags = local.tags
+}
+
+# Attendance of team members at calendar events, one record per event and member.
+resource "aws_dynamodb_table" "attendance" {
+  name         = "${var.prefix}-attendance"
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "id"
+
+  attribute {
+    name = "id"
+    type = "S"
+  }
+  attribute {
+    name = "eventId"
+    type = "S"
+  }
+  attribute {
+    name = "seasonId"
+    type = "S"
+  }
+
+  global_secondary_index {
+    name            = "eventIdIndex"
+    hash_key        = "eventId"
+    projection_type = "ALL"
+  }
+
+  global_secondary_index {
+    name            = "seasonIdIndex"
+    hash_key        = "seasonId"
+    projection_type = "ALL"
+  }
+
+  tags = local.tags
+}
+
+# Box scores of match events, keyed by event ID.
+resource "aws_dynamodb_table" "match_stats" {
+  name         = "${var.prefix}-match-stats"
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "id"
+
+  attribute {
+    name = "id"
+    type = "S"
+  }
+  attribute {
+    name = "seasonId"
+    type = "S"
+  }
+
+  global_secondary_index {
+    name            = "seasonIdIndex"
+    hash_key        = "seasonId"
+    projection_type = "ALL"
+  }
+
+  tags = local.tags
+}
+
+# Skill catalogue of a team, keyed by team ID.
+resource "aws_dynamodb_table" "skill_catalogues" {
+  name         = "${var.prefix}-skill-catalogues"
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "id"
+
+  attribute {
+    name = "id"
+    type = "S"
+  }
+
+  tags = local.tags
+}
+
+# Dated skill ratings of players.
+resource "aws_dynamodb_table" "skill_assessments" {
+  name         = "${var.prefix}-skill-assessments"
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "id"
+
+  attribute {
+    name = "id"
+    type = "S"
+  }
+  attribute {
+    name = "teamId"
+    type = "S"
+  }
+
+  global_secondary_index {
+    name            = "teamIdIndex"
+    hash_key        = "teamId"
+    projection_type = "ALL"
+  }
+
+  tags = local.tags
+}
+
+# Drill library of a team, with links to goals and training events.
+resource "aws_dynamodb_table" "drills" {
+  name         = "${var.prefix}-drills"
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "id"
+
+  attribute {
+    name = "id"
+    type = "S"
+  }
+  attribute {
+    name = "teamId"
+    type = "S"
+  }
+
+  global_secondary_index {
+    name            = "teamIdIndex"
+    hash_key        = "teamId"
+    projection_type = "ALL"
+  }
+
+  tags = local.tags
+}
+
+# Subgroups (squads) of a team with their members and assistant trainers.
+resource "aws_dynamodb_table" "subgroups" {
+  name         = "${var.prefix}-subgroups"
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "id"
+
+  attribute {
+    name = "id"
+    type = "S"
+  }
+  attribute {
+    name = "teamId"
+    type = "S"
+  }
+
+  global_secondary_index {
+    name            = "teamIdIndex"
+    hash_key        = "teamId"
+    projection_type = "ALL"
+  }
+
+  tags = local.tags
+}
+
+# Nominations of a successor for a team's admin or trainer role.
+resource "aws_dynamodb_table" "handovers" {
+  name         = "${var.prefix}-handovers"
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "id"
+
+  attribute {
+    name = "id"
+    type = "S"
+  }
+  attribute {
+    name = "teamId"
+    type = "S"
+  }
+
+  global_secondary_index {
+    name            = "teamIdIndex"
+    hash_key        = "teamId"
+    projection_type = "ALL"
+  }
+
+  tags = local.tags
+}
+
+# Guardians linked to minor players, looked up from both sides.
+resource "aws_dynamodb_table" "guardians" {
+  name         = "${var.prefix}-guardians"
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "id"
+
+  attribute {
+    name = "id"
+    type = "S"
+  }
+  attribute {
+    name = "guardianId"
+    type = "S"
+  }
+  attribute {
+    name = "playerId"
+    type = "S"
+  }
+
+  global_secondary_index {
+    name            = "guardianIdIndex"
+    hash_key        = "guardianId"
+    projection_type = "ALL"
+  }
+
+  global_secondary_index {
+    name            = "playerIdIndex"
+    hash_key        = "playerId"
+    projection_type = "ALL"
+  }
+
+  tags = local.tags
+}
+
+# Starting lineup of a match, keyed by event ID.
+resource "aws_dynamodb_table" "lineups" {
+  name         = "${var.prefix}-lineups"
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "id"
+
+  attribute {
+    name = "id"
+    type = "S"
+  }
+  attribute {
+    name = "seasonId"
+    type = "S"
+  }
+
+  global_secondary_index {
+    name            = "seasonIdIndex"
+    hash_key        = "seasonId"
+    projection_type = "ALL"
+  }
+
+  tags = local.tags
+}
+
+# Fitness test definitions of a team.
+resource "aws_dynamodb_table" "fitness_tests" {
+  name         = "${var.prefix}-fitness-tests"
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "id"
+
+  attribute {
+    name = "id"
+    type = "S"
+  }
+  attribute {
+    name = "teamId"
+    type = "S"
+  }
+
+  global_secondary_index {
+    name            = "teamIdIndex"
+    hash_key        = "teamId"
+    projection_type = "ALL"
+  }
+
+  tags = local.tags
+}
+
+# Dated results of members in a team's fitness tests.
+resource "aws_dynamodb_table" "fitness_results" {
+  name         = "${var.prefix}-fitness-results"
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "id"
+
+  attribute {
+    name = "id"
+    type = "S"
+  }
+  attribute {
+    name = "teamId"
+    type = "S"
+  }
+  attribute {
+    name = "testId"
+    type = "S"
+  }
+
+  global_secondary_index {
+    name            = "teamIdIndex"
+    hash_key        = "teamId"
+    projection_type = "ALL"
+  }
+
+  global_secondary_index {
+    name            = "testIdIndex"
+    hash_key        = "testId"
+    projection_type = "ALL"
+  }
+
+  tags = local.tags
+}
+
+# Video clips attached to goals, progress entries and comments.
+resource "aws_dynamodb_table" "media_references" {
+  name         = "${var.prefix}-media-references"
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "id"
+
+  attribute {
+    name = "id"
+    type = "S"
+  }
+  attribute {
+    name = "targetId"
+    type = "S"
+  }
+  attribute {
+    name = "teamId"
+    type = "S"
+  }
+
+  global_secondary_index {
+    name            = "targetIdIndex"
+    hash_key        = "targetId"
+    projection_type = "ALL"
+  }
+
+  global_secondary_index {
+    name            = "teamIdIndex"
+    hash_key        = "teamId"
+    projection_type = "ALL"
+  }
+
+  tags = local.tags
+}
+
+# Clubs and associations owning teams.
+resource "aws_dynamodb_table" "organizations" {
+  name         = "${var.prefix}-organizations"
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "id"
+
+  attribute {
+    name = "id"
+    type = "S"
+  }
+
+  tags = local.tags
+}
+
+# Organization-level roles of users (owner, admin, viewer).
+resource "aws_dynamodb_table" "organization_members" {
+  name         = "${var.prefix}-organization-members"
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "id"
+
+  attribute {
+    name = "id"
+    type = "S"
+  }
+  attribute {
+    name = "organizationId"
+    type = "S"
+  }
+  attribute {
+    name = "userId"
+    type = "S"
+  }
+
+  global_secondary_index {
+    name            = "organizationIdIndex"
+    hash_key        = "organizationId"
+    projection_type = "ALL"
+  }
+
+  global_secondary_index {
+    name            = "userIdIndex"
+    hash_key        = "userId"
+    projection_type = "ALL"
+  }
+
+  tags = local.tags
+}
+
+# Custom roles teams define on top of the built-in admin, trainer and member roles.
+resource "aws_dynamodb_table" "team_roles" {
+  name         = "${var.prefix}-team-roles"
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "id"
+
+  attribute {
+    name = "id"
+    type = "S"
+  }
+  attribute {
+    name = "teamId"
+    type = "S"
+  }
+
+  global_secondary_index {
+    name            = "teamIdIndex"
+    hash_key        = "teamId"
+    projection_type = "ALL"
+  }
+
+  tags = local.tags
+}
+
 # Parameter Store
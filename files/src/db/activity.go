@@ -39,7 +39,6 @@ func ListTeamActivities(ctx context.Context, filter ActivityFilter) ([]*models.A
 
 	in := &dynamodb.ScanInput{
 		TableName: aws.String(activitiesTableName),
-		Limit:     aws.Int32(int32(limit)),
 	}
 
 	expr, vals, names := filter.BuildExpression()
@@ -57,18 +56,26 @@ func ListTeamActivities(ctx context.Context, filter ActivityFilter) ([]*models.A
 		}
 	}
 
-	result, err := client.Scan(ctx, in)
-	if err != nil {
-		return nil, 0, nil, false, err
-	}
-
-	activities := make([]*models.Activity, 0, len(result.Items))
-	for _, item := range result.Items {
-		var a models.Activity
-		if err := attributevalue.UnmarshalMap(item, &a); err != nil {
+	// The filter is applied after Limit, so keep scanning until the page is full. Each scan reads at most the
+	// remaining number of items, so the last evaluated key is always right after the last returned activity.
+	activities := make([]*models.Activity, 0, limit)
+	for {
+		in.Limit = aws.Int32(int32(limit - len(activities)))
+		result, err := client.Scan(ctx, in)
+		if err != nil {
 			return nil, 0, nil, false, err
 		}
-		activities = append(activities, &a)
+		for _, item := range result.Items {
+			var a models.Activity
+			if err := attributevalue.UnmarshalMap(item, &a); err != nil {
+				return nil, 0, nil, false, err
+			}
+			activities = append(activities, &a)
+		}
+		in.ExclusiveStartKey = result.LastEvaluatedKey
+		if len(result.LastEvaluatedKey) == 0 || len(activities) >= limit {
+			break
+		}
 	}
 
 	// Sort by timestamp descending
@@ -76,6 +83,6 @@ func ListTeamActivities(ctx context.Context, filter ActivityFilter) ([]*models.A
 		return activities[i].Timestamp.After(activities[j].Timestamp)
 	})
 
-	nextCursor, hasMore := nextCursorFromLEK(result.LastEvaluatedKey)
+	nextCursor, hasMore := nextCursorFromLEK(in.ExclusiveStartKey)
 	return activities, len(activities), nextCursor, hasMore, nil
 }
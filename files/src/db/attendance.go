@@ -0,0 +1,143 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/fpgschiba/volleygoals/models"
+)
+
+// attendanceId derives the record ID from event and member, so recording attendance twice replaces the
+// earlier record instead of adding a second one.
+func attendanceId(eventId, userId string) string {
+	return eventId + "#" + userId
+}
+
+// PutAttendance creates or replaces the attendance records of an event. CreatedAt is kept for records that
+// already exist.
+func PutAttendance(ctx context.Context, records []*models.Attendance) error {
+	client = GetClient()
+	if len(records) == 0 {
+		return nil
+	}
+	existing, err := ListAttendanceByEventId(ctx, records[0].EventId)
+	if err != nil {
+		return err
+	}
+	createdAt := make(map[string]time.Time, len(existing))
+	for _, a := range existing {
+		createdAt[a.Id] = a.CreatedAt
+	}
+
+	now := time.Now()
+	for _, a := range records {
+		a.Id = attendanceId(a.EventId, a.UserId)
+		a.CreatedAt = now
+		if t, ok := createdAt[a.Id]; ok {
+			a.CreatedAt = t
+		}
+		a.UpdatedAt = now
+		_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+			TableName: aws.String(attendanceTableName),
+			Item:      a.ToAttributeValues(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListAttendanceByEventId returns all attendance records of an event.
+func ListAttendanceByEventId(ctx context.Context, eventId string) ([]*models.Attendance, error) {
+	return queryAttendance(ctx, "eventIdIndex", "eventId", eventId)
+}
+
+// ListAttendanceBySeasonId returns all attendance records of a season.
+func ListAttendanceBySeasonId(ctx context.Context, seasonId string) ([]*models.Attendance, error) {
+	return queryAttendance(ctx, "seasonIdIndex", "seasonId", seasonId)
+}
+
+// DeleteAttendanceByEventId removes all attendance records of an event.
+func DeleteAttendanceByEventId(ctx context.Context, eventId string) error {
+	client = GetClient()
+	records, err := ListAttendanceByEventId(ctx, eventId)
+	if err != nil {
+		return err
+	}
+	for _, a := range records {
+		_, err := client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+			TableName: aws.String(attendanceTableName),
+			Key: map[string]types.AttributeValue{
+				"id": &types.AttributeValueMemberS{Value: a.Id},
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func queryAttendance(ctx context.Context, indexName, keyName, keyValue string) ([]*models.Attendance, error) {
+	client = GetClient()
+	records := make([]*models.Attendance, 0)
+	var lastKey map[string]types.AttributeValue
+	for {
+		in := &dynamodb.QueryInput{
+			TableName:              aws.String(attendanceTableName),
+			IndexName:              aws.String(indexName),
+			KeyConditionExpression: aws.String("#key = :value"),
+			ExpressionAttributeNames: map[string]string{
+				"#key": keyName,
+			},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":value": &types.AttributeValueMemberS{Value: keyValue},
+			},
+		}
+		if lastKey != nil {
+			in.ExclusiveStartKey = lastKey
+		}
+		result, err := client.Query(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result.Items {
+			var a models.Attendance
+			if err := attributevalue.UnmarshalMap(item, &a); err != nil {
+				return nil, err
+			}
+			records = append(records, &a)
+		}
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = result.LastEvaluatedKey
+	}
+	return records, nil
+}
+
+// DeleteAttendanceBySeasonId removes all attendance records of a season.
+func DeleteAttendanceBySeasonId(ctx context.Context, seasonId string) error {
+	client = GetClient()
+	records, err := ListAttendanceBySeasonId(ctx, seasonId)
+	if err != nil {
+		return err
+	}
+	for _, a := range records {
+		_, err := client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+			TableName: aws.String(attendanceTableName),
+			Key: map[string]types.AttributeValue{
+				"id": &types.AttributeValueMemberS{Value: a.Id},
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -226,8 +226,9 @@ func sortComments(comments []*models.Comment, sortBy, sortOrder string) {
 	}
 }
 
-// listCommentsByTargetId returns all comments for a given targetId (used for cascade deletes).
-func listCommentsByTargetId(ctx context.Context, targetId string) ([]*models.Comment, error) {
+// ListCommentsByTargetId returns all comments for a given targetId (used for cascade deletes and exports).
+func ListCommentsByTargetId(ctx context.Context, targetId string) ([]*models.Comment, error) {
+	client = GetClient()
 	result, err := client.Scan(ctx, &dynamodb.ScanInput{
 		TableName:        aws.String(commentsTableName),
 		FilterExpression: aws.String("#tid = :targetId"),
@@ -279,7 +280,7 @@ func deleteCommentFilesByCommentId(ctx context.Context, commentId string) error
 
 // DeleteCommentsForTarget deletes all comments (and their files) for a given targetId.
 func DeleteCommentsForTarget(ctx context.Context, targetId string) error {
-	comments, err := listCommentsByTargetId(ctx, targetId)
+	comments, err := ListCommentsByTargetId(ctx, targetId)
 	if err != nil {
 		return err
 	}
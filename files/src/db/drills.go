@@ -0,0 +1,172 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/fpgschiba/volleygoals/models"
+)
+
+// CreateDrill stores a new drill. ID and timestamps are assigned here.
+func CreateDrill(ctx context.Context, drill *models.Drill) (*models.Drill, error) {
+	client = GetClient()
+	now := time.Now()
+	drill.Id = models.GenerateID()
+	drill.CreatedAt = now
+	drill.UpdatedAt = now
+	_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(drillsTableName),
+		Item:      drill.ToAttributeValues(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return drill, nil
+}
+
+func GetDrillById(ctx context.Context, drillId string) (*models.Drill, error) {
+	client = GetClient()
+	result, err := client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(drillsTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: drillId},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Item == nil {
+		return nil, nil
+	}
+	var drill models.Drill
+	if err := attributevalue.UnmarshalMap(result.Item, &drill); err != nil {
+		return nil, err
+	}
+	return &drill, nil
+}
+
+// UpdateDrill replaces a stored drill with the given one.
+func UpdateDrill(ctx context.Context, drill *models.Drill) error {
+	client = GetClient()
+	drill.UpdatedAt = time.Now()
+	_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(drillsTableName),
+		Item:      drill.ToAttributeValues(),
+	})
+	return err
+}
+
+func UpdateDrillMedia(ctx context.Context, drillId string, mediaUrl string) error {
+	client = GetClient()
+	_, err := client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(drillsTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: drillId},
+		},
+		UpdateExpression: aws.String("SET mediaUrl = :mediaUrl, updatedAt = :updatedAt"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":mediaUrl":  &types.AttributeValueMemberS{Value: mediaUrl},
+			":updatedAt": &types.AttributeValueMemberS{Value: time.Now().Format(time.RFC3339)},
+		},
+	})
+	return err
+}
+
+func DeleteDrill(ctx context.Context, drillId string) error {
+	client = GetClient()
+	_, err := client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(drillsTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: drillId},
+		},
+	})
+	return err
+}
+
+// ListDrillsByTeamId returns every drill of a team in no particular order.
+func ListDrillsByTeamId(ctx context.Context, teamId string) ([]*models.Drill, error) {
+	client = GetClient()
+	drills := make([]*models.Drill, 0)
+	var lastKey map[string]types.AttributeValue
+	for {
+		in := &dynamodb.QueryInput{
+			TableName:              aws.String(drillsTableName),
+			IndexName:              aws.String("teamIdIndex"),
+			KeyConditionExpression: aws.String("teamId = :teamId"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":teamId": &types.AttributeValueMemberS{Value: teamId},
+			},
+		}
+		if lastKey != nil {
+			in.ExclusiveStartKey = lastKey
+		}
+		result, err := client.Query(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result.Items {
+			var drill models.Drill
+			if err := attributevalue.UnmarshalMap(item, &drill); err != nil {
+				return nil, err
+			}
+			drills = append(drills, &drill)
+		}
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = result.LastEvaluatedKey
+	}
+	return drills, nil
+}
+
+// UnlinkGoalFromDrills removes the goal from the links of every drill of the team.
+func UnlinkGoalFromDrills(ctx context.Context, teamId, goalId string) error {
+	return unlinkFromDrills(ctx, teamId, goalId, func(d *models.Drill) *[]string { return &d.GoalIds })
+}
+
+// UnlinkEventFromDrills removes the event from the links of every drill of the team.
+func UnlinkEventFromDrills(ctx context.Context, teamId, eventId string) error {
+	return unlinkFromDrills(ctx, teamId, eventId, func(d *models.Drill) *[]string { return &d.EventIds })
+}
+
+func unlinkFromDrills(ctx context.Context, teamId, id string, links func(d *models.Drill) *[]string) error {
+	drills, err := ListDrillsByTeamId(ctx, teamId)
+	if err != nil {
+		return err
+	}
+	for _, d := range drills {
+		ids := links(d)
+		kept := make([]string, 0, len(*ids))
+		for _, linked := range *ids {
+			if linked != id {
+				kept = append(kept, linked)
+			}
+		}
+		if len(kept) == len(*ids) {
+			continue
+		}
+		*ids = kept
+		if err := UpdateDrill(ctx, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteDrillsByTeamId removes every drill of a team.
+func DeleteDrillsByTeamId(ctx context.Context, teamId string) error {
+	drills, err := ListDrillsByTeamId(ctx, teamId)
+	if err != nil {
+		return err
+	}
+	for _, d := range drills {
+		if err := DeleteDrill(ctx, d.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/fpgschiba/volleygoals/models"
+)
+
+// CreateEvent stores a new calendar event. ID and timestamps are assigned here.
+func CreateEvent(ctx context.Context, event *models.Event) (*models.Event, error) {
+	client = GetClient()
+	now := time.Now()
+	event.Id = models.GenerateID()
+	event.CreatedAt = now
+	event.UpdatedAt = now
+	_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(eventsTableName),
+		Item:      event.ToAttributeValues(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func GetEventById(ctx context.Context, eventId string) (*models.Event, error) {
+	client = GetClient()
+	result, err := client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(eventsTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: eventId},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Item == nil {
+		return nil, nil
+	}
+	var event models.Event
+	err = attributevalue.UnmarshalMap(result.Item, &event)
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// UpdateEvent replaces a stored event with the given one.
+func UpdateEvent(ctx context.Context, event *models.Event) error {
+	client = GetClient()
+	event.UpdatedAt = time.Now()
+	_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(eventsTableName),
+		Item:      event.ToAttributeValues(),
+	})
+	return err
+}
+
+func DeleteEvent(ctx context.Context, eventId string) error {
+	client = GetClient()
+	_, err := client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(eventsTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: eventId},
+		},
+	})
+	return err
+}
+
+// ListAllEventsBySeasonId returns every event of a season in no particular order.
+func ListAllEventsBySeasonId(ctx context.Context, seasonId string) ([]*models.Event, error) {
+	client = GetClient()
+	events := make([]*models.Event, 0)
+	var lastKey map[string]types.AttributeValue
+	for {
+		in := &dynamodb.QueryInput{
+			TableName:              aws.String(eventsTableName),
+			IndexName:              aws.String("seasonIdIndex"),
+			KeyConditionExpression: aws.String("seasonId = :seasonId"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":seasonId": &types.AttributeValueMemberS{Value: seasonId},
+			},
+		}
+		if lastKey != nil {
+			in.ExclusiveStartKey = lastKey
+		}
+		result, err := client.Query(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result.Items {
+			var event models.Event
+			if err := attributevalue.UnmarshalMap(item, &event); err != nil {
+				return nil, err
+			}
+			events = append(events, &event)
+		}
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = result.LastEvaluatedKey
+	}
+	return events, nil
+}
+
+// UnlinkEvent removes the event reference from every goal and progress report that points to the event.
+func UnlinkEvent(ctx context.Context, eventId string) error {
+	for _, tableName := range []string{goalsTableName, progressReportsTableName} {
+		if err := removeEventIdFromTable(ctx, tableName, eventId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func removeEventIdFromTable(ctx context.Context, tableName, eventId string) error {
+	client = GetClient()
+	var lastKey map[string]types.AttributeValue
+	for {
+		in := &dynamodb.ScanInput{
+			TableName:            aws.String(tableName),
+			FilterExpression:     aws.String("#eventId = :eventId"),
+			ProjectionExpression: aws.String("id"),
+			ExpressionAttributeNames: map[string]string{
+				"#eventId": "eventId",
+			},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":eventId": &types.AttributeValueMemberS{Value: eventId},
+			},
+		}
+		if lastKey != nil {
+			in.ExclusiveStartKey = lastKey
+		}
+		result, err := client.Scan(ctx, in)
+		if err != nil {
+			return err
+		}
+		for _, item := range result.Items {
+			_, err := client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+				TableName:        aws.String(tableName),
+				Key:              map[string]types.AttributeValue{"id": item["id"]},
+				UpdateExpression: aws.String("REMOVE #eventId"),
+				ExpressionAttributeNames: map[string]string{
+					"#eventId": "eventId",
+				},
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = result.LastEvaluatedKey
+	}
+	return nil
+}
+
+// DeleteEventsBySeasonId removes every calendar event of a season.
+func DeleteEventsBySeasonId(ctx context.Context, seasonId string) error {
+	events, err := ListAllEventsBySeasonId(ctx, seasonId)
+	if err != nil {
+		return err
+	}
+	for _, e := range events {
+		if err := DeleteEvent(ctx, e.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package db
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -95,8 +96,9 @@ func FilterOptionsFromQuery(q map[string]string, defaultLimit, maxLimit int) (Fi
 // TeamFilter combines resource-specific filters for teams with generic sort & pagination options.
 type TeamFilter struct {
 	FilterOptions
-	NameContains string // partial match against teamName
-	Status       string // "active" | "inactive" | ""
+	NameContains    string   // partial match against teamName
+	Status          string   // "active" | "inactive" | ""
+	OrganizationIds []string // teams owned by one of these organizations; nil for all teams
 }
 
 // BuildExpression builds a DynamoDB filter expression for teams.
@@ -114,6 +116,15 @@ func (f *TeamFilter) BuildExpression() (string, map[string]types.AttributeValue,
 		names["#s"] = "status"
 		values[":status"] = &types.AttributeValueMemberS{Value: f.Status}
 	}
+	if len(f.OrganizationIds) > 0 {
+		keys := make([]string, 0, len(f.OrganizationIds))
+		for i, id := range f.OrganizationIds {
+			key := fmt.Sprintf(":org%d", i)
+			keys = append(keys, key)
+			values[key] = &types.AttributeValueMemberS{Value: id}
+		}
+		parts = append(parts, "organizationId IN ("+strings.Join(keys, ", ")+")")
+	}
 
 	if len(parts) == 0 {
 		return "", nil, nil
@@ -225,6 +236,9 @@ type TeamMemberFilter struct {
 	Role         string // "member" | "admin" | "trainer" | ""
 	UserId       string // userId of the team member
 	Status       string // "active" | "invited" | "removed" | "left" | ""
+	Position     string // one of the member's playing positions
+	JerseyNumber *int   // exact match on the jersey number
+	DominantHand string // "left" | "right" | "both" | ""
 	NameContains  string // partial match on user name (applied in-memory after enrichment)
 	EmailContains string // partial match on user email (applied in-memory after enrichment)
 }
@@ -253,6 +267,24 @@ func (f *TeamMemberFilter) BuildExpression() (string, map[string]types.Attribute
 		values[":status"] = &types.AttributeValueMemberS{Value: f.Status}
 	}
 
+	if strings.TrimSpace(f.Position) != "" {
+		parts = append(parts, "contains(#p, :position)")
+		names["#p"] = "positions"
+		values[":position"] = &types.AttributeValueMemberS{Value: f.Position}
+	}
+
+	if f.JerseyNumber != nil {
+		parts = append(parts, "#j = :jerseyNumber")
+		names["#j"] = "jerseyNumber"
+		values[":jerseyNumber"] = &types.AttributeValueMemberN{Value: strconv.Itoa(*f.JerseyNumber)}
+	}
+
+	if strings.TrimSpace(f.DominantHand) != "" {
+		parts = append(parts, "#h = :dominantHand")
+		names["#h"] = "dominantHand"
+		values[":dominantHand"] = &types.AttributeValueMemberS{Value: f.DominantHand}
+	}
+
 	if len(parts) == 0 {
 		return "", nil, nil
 	}
@@ -285,6 +317,25 @@ func TeamMemberFilterFromQuery(q map[string]string) (TeamMemberFilter, error) {
 		t.Status = strings.TrimSpace(v)
 	}
 
+	// position
+	if v, ok := q["position"]; ok {
+		t.Position = strings.TrimSpace(v)
+	}
+
+	// jerseyNumber
+	if v, ok := getFirst([]string{"jerseyNumber", "jersey_number"}, q); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return t, fmt.Errorf("invalid jerseyNumber: must be a number")
+		}
+		t.JerseyNumber = &n
+	}
+
+	// dominantHand
+	if v, ok := getFirst([]string{"dominantHand", "dominant_hand"}, q); ok {
+		t.DominantHand = v
+	}
+
 	// name / name_contains — in-memory filter after user enrichment
 	for _, key := range []string{"name", "name_contains"} {
 		if v, ok := q[key]; ok && strings.TrimSpace(v) != "" {
@@ -372,6 +423,8 @@ type GoalFilter struct {
 	GoalType      string // goal type (individual|team)
 	Status        string // goal status
 	TitleContains string // partial match against title
+	EventId       string // exact match on eventId
+	SubgroupId    string // exact match on subgroupId
 }
 
 // BuildExpression builds a DynamoDB filter expression for goals.
@@ -404,6 +457,18 @@ func (f *GoalFilter) BuildExpression() (string, map[string]types.AttributeValue,
 		values[":title"] = &types.AttributeValueMemberS{Value: f.TitleContains}
 	}
 
+	if strings.TrimSpace(f.EventId) != "" {
+		parts = append(parts, "#eventId = :eventId")
+		names["#eventId"] = "eventId"
+		values[":eventId"] = &types.AttributeValueMemberS{Value: f.EventId}
+	}
+
+	if strings.TrimSpace(f.SubgroupId) != "" {
+		parts = append(parts, "#subgroupId = :subgroupId")
+		names["#subgroupId"] = "subgroupId"
+		values[":subgroupId"] = &types.AttributeValueMemberS{Value: f.SubgroupId}
+	}
+
 	if len(parts) == 0 {
 		return "", nil, nil
 	}
@@ -433,6 +498,12 @@ func GoalFilterFromQuery(q map[string]string) (GoalFilter, error) {
 	if v, ok := q["title"]; ok && strings.TrimSpace(v) != "" {
 		g.TitleContains = strings.TrimSpace(v)
 	}
+	if v, ok := q["eventId"]; ok {
+		g.EventId = strings.TrimSpace(v)
+	}
+	if v, ok := q["subgroupId"]; ok {
+		g.SubgroupId = strings.TrimSpace(v)
+	}
 
 	return g, nil
 }
@@ -443,6 +514,7 @@ type ProgressReportFilter struct {
 	SeasonId        string     // exact match on seasonId
 	AuthorId        string     // exact match on authorId
 	SummaryContains string     // contains() match on summary
+	EventId         string     // exact match on eventId
 	CreatedAfter    *time.Time // createdAt >= CreatedAfter (inclusive)
 	CreatedBefore   *time.Time // createdAt <= CreatedBefore (inclusive)
 }
@@ -471,6 +543,12 @@ func (f *ProgressReportFilter) BuildExpression() (string, map[string]types.Attri
 		values[":summary"] = &types.AttributeValueMemberS{Value: f.SummaryContains}
 	}
 
+	if strings.TrimSpace(f.EventId) != "" {
+		parts = append(parts, "#eventId = :eventId")
+		names["#eventId"] = "eventId"
+		values[":eventId"] = &types.AttributeValueMemberS{Value: f.EventId}
+	}
+
 	if len(parts) == 0 {
 		return "", nil, nil
 	}
@@ -496,6 +574,9 @@ func ProgressReportFilterFromQuery(q map[string]string) (ProgressReportFilter, e
 	if v, ok := q["summary"]; ok && strings.TrimSpace(v) != "" {
 		p.SummaryContains = strings.TrimSpace(v)
 	}
+	if v, ok := q["eventId"]; ok {
+		p.EventId = strings.TrimSpace(v)
+	}
 
 	if v, ok := q["createdAfter"]; ok && strings.TrimSpace(v) != "" {
 		t, err := time.Parse(time.RFC3339, strings.TrimSpace(v))
@@ -516,10 +597,54 @@ func ProgressReportFilterFromQuery(q map[string]string) (ProgressReportFilter, e
 	return p, nil
 }
 
+// EventFilter combines calendar-event-specific filters with generic pagination options.
+// Events are filtered and sorted in memory, so there is no expression builder.
+type EventFilter struct {
+	FilterOptions
+	Type string     // event type (training|match|tournament|meeting)
+	From *time.Time // startsAt >= From (inclusive)
+	To   *time.Time // startsAt <= To (inclusive)
+}
+
+// EventFilterFromQuery parses event-specific and generic filter params from QueryStringParameters.
+func EventFilterFromQuery(q map[string]string) (EventFilter, error) {
+	var e EventFilter
+
+	fo, err := FilterOptionsFromQuery(q, defaultPageSize, maxPageSize)
+	if err != nil {
+		return e, err
+	}
+	e.FilterOptions = fo
+
+	if v, ok := q["type"]; ok {
+		e.Type = strings.TrimSpace(v)
+	}
+
+	if v, ok := q["from"]; ok && strings.TrimSpace(v) != "" {
+		t, err := time.Parse(time.RFC3339, strings.TrimSpace(v))
+		if err != nil {
+			return e, fmt.Errorf("invalid from: must be RFC3339 / ISO 8601")
+		}
+		e.From = &t
+	}
+
+	if v, ok := q["to"]; ok && strings.TrimSpace(v) != "" {
+		t, err := time.Parse(time.RFC3339, strings.TrimSpace(v))
+		if err != nil {
+			return e, fmt.Errorf("invalid to: must be RFC3339 / ISO 8601")
+		}
+		e.To = &t
+	}
+
+	return e, nil
+}
+
 // ActivityFilter combines activity-specific filters with generic sort & pagination options.
 type ActivityFilter struct {
 	FilterOptions
-	TeamId string // exact match on teamId
+	TeamId     string   // exact match on teamId
+	Visibility string   // exact match on visibility
+	ActorIds   []string // actorId is one of these; nil means any actor
 }
 
 // BuildExpression builds a DynamoDB filter expression for activities.
@@ -533,6 +658,34 @@ func (f *ActivityFilter) BuildExpression() (string, map[string]types.AttributeVa
 		names["#teamId"] = "teamId"
 		values[":teamId"] = &types.AttributeValueMemberS{Value: f.TeamId}
 	}
+	if strings.TrimSpace(f.Visibility) != "" {
+		parts = append(parts, "#visibility = :visibility")
+		names["#visibility"] = "visibility"
+		values[":visibility"] = &types.AttributeValueMemberS{Value: f.Visibility}
+	}
+	if f.ActorIds != nil {
+		// IN takes at most 100 operands, so larger lists are split and joined with OR
+		groups := make([]string, 0)
+		for start := 0; start < len(f.ActorIds); start += 100 {
+			end := start + 100
+			if end > len(f.ActorIds) {
+				end = len(f.ActorIds)
+			}
+			keys := make([]string, 0, end-start)
+			for i := start; i < end; i++ {
+				key := fmt.Sprintf(":actor%d", i)
+				keys = append(keys, key)
+				values[key] = &types.AttributeValueMemberS{Value: f.ActorIds[i]}
+			}
+			groups = append(groups, "#actorId IN ("+strings.Join(keys, ", ")+")")
+		}
+		if len(groups) == 0 {
+			// No actor can match an empty list
+			groups = append(groups, "attribute_not_exists(#actorId) AND attribute_exists(#actorId)")
+		}
+		parts = append(parts, "("+strings.Join(groups, " OR ")+")")
+		names["#actorId"] = "actorId"
+	}
 
 	if len(parts) == 0 {
 		return "", nil, nil
@@ -0,0 +1,235 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/fpgschiba/volleygoals/models"
+)
+
+// CreateFitnessTest stores a new fitness test. ID and timestamps are assigned here.
+func CreateFitnessTest(ctx context.Context, test *models.FitnessTest) (*models.FitnessTest, error) {
+	client = GetClient()
+	now := time.Now()
+	test.Id = models.GenerateID()
+	test.CreatedAt = now
+	test.UpdatedAt = now
+	_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(fitnessTestsTableName),
+		Item:      test.ToAttributeValues(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return test, nil
+}
+
+func GetFitnessTestById(ctx context.Context, testId string) (*models.FitnessTest, error) {
+	client = GetClient()
+	result, err := client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(fitnessTestsTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: testId},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Item == nil {
+		return nil, nil
+	}
+	var test models.FitnessTest
+	if err := attributevalue.UnmarshalMap(result.Item, &test); err != nil {
+		return nil, err
+	}
+	return &test, nil
+}
+
+// UpdateFitnessTest replaces a stored fitness test with the given one.
+func UpdateFitnessTest(ctx context.Context, test *models.FitnessTest) error {
+	client = GetClient()
+	test.UpdatedAt = time.Now()
+	_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(fitnessTestsTableName),
+		Item:      test.ToAttributeValues(),
+	})
+	return err
+}
+
+// DeleteFitnessTest removes a fitness test together with all of its results.
+func DeleteFitnessTest(ctx context.Context, testId string) error {
+	client = GetClient()
+	results, err := ListFitnessResultsByTestId(ctx, testId)
+	if err != nil {
+		return err
+	}
+	for _, r := range results {
+		if err := DeleteFitnessResult(ctx, r.Id); err != nil {
+			return err
+		}
+	}
+	_, err = client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(fitnessTestsTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: testId},
+		},
+	})
+	return err
+}
+
+// ListFitnessTestsByTeamId returns every fitness test of a team in no particular order.
+func ListFitnessTestsByTeamId(ctx context.Context, teamId string) ([]*models.FitnessTest, error) {
+	client = GetClient()
+	tests := make([]*models.FitnessTest, 0)
+	var lastKey map[string]types.AttributeValue
+	for {
+		in := &dynamodb.QueryInput{
+			TableName:              aws.String(fitnessTestsTableName),
+			IndexName:              aws.String("teamIdIndex"),
+			KeyConditionExpression: aws.String("teamId = :teamId"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":teamId": &types.AttributeValueMemberS{Value: teamId},
+			},
+		}
+		if lastKey != nil {
+			in.ExclusiveStartKey = lastKey
+		}
+		result, err := client.Query(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result.Items {
+			var test models.FitnessTest
+			if err := attributevalue.UnmarshalMap(item, &test); err != nil {
+				return nil, err
+			}
+			tests = append(tests, &test)
+		}
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = result.LastEvaluatedKey
+	}
+	return tests, nil
+}
+
+// CreateFitnessResult stores a new fitness test result. ID and timestamps are assigned here.
+func CreateFitnessResult(ctx context.Context, fitnessResult *models.FitnessResult) (*models.FitnessResult, error) {
+	client = GetClient()
+	now := time.Now()
+	fitnessResult.Id = models.GenerateID()
+	fitnessResult.CreatedAt = now
+	fitnessResult.UpdatedAt = now
+	_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(fitnessResultsTableName),
+		Item:      fitnessResult.ToAttributeValues(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fitnessResult, nil
+}
+
+func GetFitnessResultById(ctx context.Context, resultId string) (*models.FitnessResult, error) {
+	client = GetClient()
+	result, err := client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(fitnessResultsTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: resultId},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Item == nil {
+		return nil, nil
+	}
+	var fitnessResult models.FitnessResult
+	if err := attributevalue.UnmarshalMap(result.Item, &fitnessResult); err != nil {
+		return nil, err
+	}
+	return &fitnessResult, nil
+}
+
+func DeleteFitnessResult(ctx context.Context, resultId string) error {
+	client = GetClient()
+	_, err := client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(fitnessResultsTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: resultId},
+		},
+	})
+	return err
+}
+
+// ListFitnessResultsByTestId returns every result of a fitness test in no particular order.
+func ListFitnessResultsByTestId(ctx context.Context, testId string) ([]*models.FitnessResult, error) {
+	return queryFitnessResults(ctx, "testIdIndex", "testId", testId)
+}
+
+// ListFitnessResultsByTeamId returns every fitness test result of a team in no particular order.
+func ListFitnessResultsByTeamId(ctx context.Context, teamId string) ([]*models.FitnessResult, error) {
+	return queryFitnessResults(ctx, "teamIdIndex", "teamId", teamId)
+}
+
+func queryFitnessResults(ctx context.Context, indexName, key, value string) ([]*models.FitnessResult, error) {
+	client = GetClient()
+	results := make([]*models.FitnessResult, 0)
+	var lastKey map[string]types.AttributeValue
+	for {
+		in := &dynamodb.QueryInput{
+			TableName:              aws.String(fitnessResultsTableName),
+			IndexName:              aws.String(indexName),
+			KeyConditionExpression: aws.String(key + " = :value"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":value": &types.AttributeValueMemberS{Value: value},
+			},
+		}
+		if lastKey != nil {
+			in.ExclusiveStartKey = lastKey
+		}
+		result, err := client.Query(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result.Items {
+			var fitnessResult models.FitnessResult
+			if err := attributevalue.UnmarshalMap(item, &fitnessResult); err != nil {
+				return nil, err
+			}
+			results = append(results, &fitnessResult)
+		}
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = result.LastEvaluatedKey
+	}
+	return results, nil
+}
+
+// DeleteFitnessTestsByTeamId removes every fitness test of a team together with all results recorded in the team.
+func DeleteFitnessTestsByTeamId(ctx context.Context, teamId string) error {
+	results, err := ListFitnessResultsByTeamId(ctx, teamId)
+	if err != nil {
+		return err
+	}
+	for _, r := range results {
+		if err := DeleteFitnessResult(ctx, r.Id); err != nil {
+			return err
+		}
+	}
+	tests, err := ListFitnessTestsByTeamId(ctx, teamId)
+	if err != nil {
+		return err
+	}
+	for _, t := range tests {
+		if err := DeleteFitnessTest(ctx, t.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
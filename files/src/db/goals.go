@@ -13,7 +13,7 @@ import (
 	"github.com/fpgschiba/volleygoals/models"
 )
 
-func CreateGoal(ctx context.Context, seasonId string, ownerId string, goalType models.GoalType, title string, description string) (*models.Goal, error) {
+func CreateGoal(ctx context.Context, seasonId string, ownerId string, goalType models.GoalType, title string, description string, eventId *string, metric *models.GoalMetric, tags []string, subgroupId string) (*models.Goal, error) {
 	client = GetClient()
 	now := time.Now()
 	goal := &models.Goal{
@@ -24,6 +24,10 @@ func CreateGoal(ctx context.Context, seasonId string, ownerId string, goalType m
 		Title:       title,
 		Description: description,
 		Status:      models.GoalStatusOpen,
+		EventId:     eventId,
+		Metric:      metric,
+		Tags:        tags,
+		SubgroupId:  subgroupId,
 		CreatedBy:   ownerId,
 		CreatedAt:   now,
 		UpdatedAt:   now,
@@ -62,13 +66,17 @@ func GetGoalById(ctx context.Context, goalId string) (*models.Goal, error) {
 	return &goal, nil
 }
 
-func UpdateGoal(ctx context.Context, goalId string, ownerId *string, title *string, description *string, status *models.GoalStatus) (*models.Goal, error) {
+// UpdateGoal updates the given fields of a goal. An empty eventId removes the goal's event link, a metric
+// without stat removes the goal's metric, empty tags remove the goal's tags and an empty subgroupId unassigns the
+// goal from its subgroup.
+func UpdateGoal(ctx context.Context, goalId string, ownerId *string, title *string, description *string, status *models.GoalStatus, eventId *string, metric *models.GoalMetric, tags *[]string, subgroupId *string) (*models.Goal, error) {
 	client = GetClient()
 	updateExpr := "SET updatedAt = :updatedAt"
 	exprAttrValues := map[string]types.AttributeValue{
 		":updatedAt": &types.AttributeValueMemberS{Value: time.Now().Format(time.RFC3339)},
 	}
 	exprAttrNames := map[string]string{}
+	removes := make([]string, 0)
 
 	if ownerId != nil {
 		updateExpr += ", ownerId = :ownerId"
@@ -91,6 +99,46 @@ func UpdateGoal(ctx context.Context, goalId string, ownerId *string, title *stri
 		exprAttrNames["#st"] = "status"
 	}
 
+	if eventId != nil && *eventId != "" {
+		updateExpr += ", eventId = :eventId"
+		exprAttrValues[":eventId"] = &types.AttributeValueMemberS{Value: *eventId}
+	} else if eventId != nil {
+		removes = append(removes, "eventId")
+	}
+
+	if metric != nil && metric.Stat != "" {
+		av, err := attributevalue.Marshal(metric)
+		if err != nil {
+			return nil, err
+		}
+		updateExpr += ", metric = :metric"
+		exprAttrValues[":metric"] = av
+	} else if metric != nil {
+		removes = append(removes, "metric")
+	}
+
+	if tags != nil && len(*tags) > 0 {
+		av, err := attributevalue.Marshal(*tags)
+		if err != nil {
+			return nil, err
+		}
+		updateExpr += ", tags = :tags"
+		exprAttrValues[":tags"] = av
+	} else if tags != nil {
+		removes = append(removes, "tags")
+	}
+
+	if subgroupId != nil && *subgroupId != "" {
+		updateExpr += ", subgroupId = :subgroupId"
+		exprAttrValues[":subgroupId"] = &types.AttributeValueMemberS{Value: *subgroupId}
+	} else if subgroupId != nil {
+		removes = append(removes, "subgroupId")
+	}
+
+	if len(removes) > 0 {
+		updateExpr += " REMOVE " + strings.Join(removes, ", ")
+	}
+
 	input := &dynamodb.UpdateItemInput{
 		TableName:                 &goalsTableName,
 		Key:                       map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: goalId}},
@@ -117,6 +165,26 @@ func UpdateGoal(ctx context.Context, goalId string, ownerId *string, title *stri
 	return &updatedGoal, nil
 }
 
+// MoveGoalToSeason moves a goal to a season of another team. Its event link and subgroup belong to the old team
+// and are removed, as is a metric measured by one of the old team's fitness tests.
+func MoveGoalToSeason(ctx context.Context, goal *models.Goal, seasonId string) error {
+	client = GetClient()
+	removes := []string{"eventId", "subgroupId"}
+	if goal.Metric != nil && goal.Metric.Source == models.GoalMetricSourceFitnessTests {
+		removes = append(removes, "metric")
+	}
+	_, err := client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:        &goalsTableName,
+		Key:              map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: goal.Id}},
+		UpdateExpression: aws.String("SET seasonId = :seasonId, updatedAt = :updatedAt REMOVE " + strings.Join(removes, ", ")),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":seasonId":  &types.AttributeValueMemberS{Value: seasonId},
+			":updatedAt": &types.AttributeValueMemberS{Value: time.Now().Format(time.RFC3339)},
+		},
+	})
+	return err
+}
+
 func DeleteGoal(ctx context.Context, goalId string) error {
 	client = GetClient()
 	_, err := client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
@@ -245,6 +313,42 @@ func CountGoalsBySeasonId(ctx context.Context, seasonId string) (total int, comp
 	return total, completed, open, inProgress, nil
 }
 
+// ListAllGoalsBySeasonId returns every goal of a season, including archived ones.
+func ListAllGoalsBySeasonId(ctx context.Context, seasonId string) ([]*models.Goal, error) {
+	client = GetClient()
+	goals := make([]*models.Goal, 0)
+	var lastKey map[string]types.AttributeValue
+	for {
+		in := &dynamodb.ScanInput{
+			TableName:        aws.String(goalsTableName),
+			FilterExpression: aws.String("#sid = :seasonId"),
+			ExpressionAttributeNames: map[string]string{
+				"#sid": "seasonId",
+			},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":seasonId": &types.AttributeValueMemberS{Value: seasonId},
+			},
+		}
+		if lastKey != nil {
+			in.ExclusiveStartKey = lastKey
+		}
+		result, err := client.Scan(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		page, err := unmarshalGoals(result.Items)
+		if err != nil {
+			return nil, err
+		}
+		goals = append(goals, page...)
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = result.LastEvaluatedKey
+	}
+	return goals, nil
+}
+
 // SearchGoalsForTeam scans all goals whose title contains query (case-insensitive)
 // and whose seasonId belongs to the given team. Archived goals are excluded.
 // Returns at most limit results.
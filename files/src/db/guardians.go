@@ -0,0 +1,125 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/fpgschiba/volleygoals/models"
+)
+
+// CreateGuardianship links a guardian to a player. ID and timestamps are assigned here.
+func CreateGuardianship(ctx context.Context, guardianship *models.Guardianship) (*models.Guardianship, error) {
+	client = GetClient()
+	now := time.Now()
+	guardianship.Id = models.GenerateID()
+	guardianship.CreatedAt = now
+	guardianship.UpdatedAt = now
+	_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(guardiansTableName),
+		Item:      guardianship.ToAttributeValues(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return guardianship, nil
+}
+
+func GetGuardianshipById(ctx context.Context, guardianshipId string) (*models.Guardianship, error) {
+	client = GetClient()
+	result, err := client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(guardiansTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: guardianshipId},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Item == nil {
+		return nil, nil
+	}
+	var guardianship models.Guardianship
+	if err := attributevalue.UnmarshalMap(result.Item, &guardianship); err != nil {
+		return nil, err
+	}
+	return &guardianship, nil
+}
+
+func DeleteGuardianshipById(ctx context.Context, guardianshipId string) error {
+	client = GetClient()
+	_, err := client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(guardiansTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: guardianshipId},
+		},
+	})
+	return err
+}
+
+// ListGuardiansByPlayerId returns the guardianships of a player in no particular order.
+func ListGuardiansByPlayerId(ctx context.Context, playerId string) ([]*models.Guardianship, error) {
+	return queryGuardianships(ctx, "playerIdIndex", "playerId", playerId)
+}
+
+// ListWardsByGuardianId returns the guardianships in which the user is the guardian, in no particular order.
+func ListWardsByGuardianId(ctx context.Context, guardianId string) ([]*models.Guardianship, error) {
+	return queryGuardianships(ctx, "guardianIdIndex", "guardianId", guardianId)
+}
+
+// IsGuardianOf reports whether guardianId is a guardian of playerId. The answer is cached for the request.
+func IsGuardianOf(ctx context.Context, guardianId, playerId string) (bool, error) {
+	return Remember(ctx, "guardian:"+guardianId+":"+playerId, func() (bool, error) {
+		wards, err := ListWardsByGuardianId(ctx, guardianId)
+		if err != nil {
+			return false, err
+		}
+		for _, w := range wards {
+			if w.PlayerId == playerId {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+}
+
+func queryGuardianships(ctx context.Context, indexName, attribute, value string) ([]*models.Guardianship, error) {
+	client = GetClient()
+	guardianships := make([]*models.Guardianship, 0)
+	var lastKey map[string]types.AttributeValue
+	for {
+		in := &dynamodb.QueryInput{
+			TableName:              aws.String(guardiansTableName),
+			IndexName:              aws.String(indexName),
+			KeyConditionExpression: aws.String("#key = :value"),
+			ExpressionAttributeNames: map[string]string{
+				"#key": attribute,
+			},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":value": &types.AttributeValueMemberS{Value: value},
+			},
+		}
+		if lastKey != nil {
+			in.ExclusiveStartKey = lastKey
+		}
+		result, err := client.Query(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result.Items {
+			var guardianship models.Guardianship
+			if err := attributevalue.UnmarshalMap(item, &guardianship); err != nil {
+				return nil, err
+			}
+			guardianships = append(guardianships, &guardianship)
+		}
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = result.LastEvaluatedKey
+	}
+	return guardianships, nil
+}
@@ -0,0 +1,304 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/fpgschiba/volleygoals/models"
+)
+
+// ErrHandoverChanged is returned when a handover is no longer pending, or one of the two memberships no longer has
+// the role or status it had when the handover was answered.
+var ErrHandoverChanged = errors.New("handover or membership changed")
+
+// ErrHandoverPending is returned when the nominator already has a pending handover on the team.
+var ErrHandoverPending = errors.New("nominator already has a pending handover")
+
+// pendingHandoverKey is the key of the item that marks the pending handover of a nominating membership. The item
+// exists only while the handover is pending, so a membership never has two pending handovers, not even when both
+// are created at the same time. It has no teamId and so is not listed with the team's handovers.
+func pendingHandoverKey(fromMemberId string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"id": &types.AttributeValueMemberS{Value: "pending#" + fromMemberId},
+	}
+}
+
+// deletePendingHandoverMarker removes the marker of a pending handover in a transaction.
+func deletePendingHandoverMarker(fromMemberId string) types.TransactWriteItem {
+	return types.TransactWriteItem{
+		Delete: &types.Delete{
+			TableName: aws.String(handoversTableName),
+			Key:       pendingHandoverKey(fromMemberId),
+		},
+	}
+}
+
+// CreateHandover stores a new pending handover. ID and timestamps are assigned here. Nothing is written if the
+// nominator already has a pending handover (ErrHandoverPending) or is no longer an active member with the
+// handover's role (ErrMembershipNotActive).
+func CreateHandover(ctx context.Context, handover *models.Handover) (*models.Handover, error) {
+	client = GetClient()
+	now := time.Now()
+	handover.Id = models.GenerateID()
+	handover.Status = models.HandoverStatusPending
+	handover.CreatedAt = now
+	handover.UpdatedAt = now
+	marker := pendingHandoverKey(handover.FromMemberId)
+	marker["handoverId"] = &types.AttributeValueMemberS{Value: handover.Id}
+	_, err := client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{
+				Put: &types.Put{
+					TableName: aws.String(handoversTableName),
+					Item:      handover.ToAttributeValues(),
+				},
+			},
+			{
+				Put: &types.Put{
+					TableName:           aws.String(handoversTableName),
+					Item:                marker,
+					ConditionExpression: aws.String("attribute_not_exists(id)"),
+				},
+			},
+			{
+				ConditionCheck: &types.ConditionCheck{
+					TableName: &teamMembersTableName,
+					Key: map[string]types.AttributeValue{
+						"id": &types.AttributeValueMemberS{Value: handover.FromMemberId},
+					},
+					ConditionExpression: aws.String("#status = :active AND #role = :role"),
+					ExpressionAttributeNames: map[string]string{
+						"#status": "status",
+						"#role":   "role",
+					},
+					ExpressionAttributeValues: map[string]types.AttributeValue{
+						":active": &types.AttributeValueMemberS{Value: string(models.TeamMemberStatusActive)},
+						":role":   &types.AttributeValueMemberS{Value: string(handover.Role)},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		var canceled *types.TransactionCanceledException
+		if errors.As(err, &canceled) && len(canceled.CancellationReasons) == 3 {
+			switch {
+			case aws.ToString(canceled.CancellationReasons[2].Code) == "ConditionalCheckFailed":
+				return nil, ErrMembershipNotActive
+			case aws.ToString(canceled.CancellationReasons[1].Code) == "ConditionalCheckFailed",
+				aws.ToString(canceled.CancellationReasons[1].Code) == "TransactionConflict":
+				return nil, ErrHandoverPending
+			}
+		}
+		return nil, err
+	}
+	return handover, nil
+}
+
+func GetHandoverById(ctx context.Context, handoverId string) (*models.Handover, error) {
+	client = GetClient()
+	result, err := client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(handoversTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: handoverId},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Item == nil {
+		return nil, nil
+	}
+	var handover models.Handover
+	if err := attributevalue.UnmarshalMap(result.Item, &handover); err != nil {
+		return nil, err
+	}
+	return &handover, nil
+}
+
+// ListHandoversByTeamId returns every handover of a team in no particular order.
+func ListHandoversByTeamId(ctx context.Context, teamId string) ([]*models.Handover, error) {
+	client = GetClient()
+	handovers := make([]*models.Handover, 0)
+	var lastKey map[string]types.AttributeValue
+	for {
+		in := &dynamodb.QueryInput{
+			TableName:              aws.String(handoversTableName),
+			IndexName:              aws.String("teamIdIndex"),
+			KeyConditionExpression: aws.String("teamId = :teamId"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":teamId": &types.AttributeValueMemberS{Value: teamId},
+			},
+		}
+		if lastKey != nil {
+			in.ExclusiveStartKey = lastKey
+		}
+		result, err := client.Query(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result.Items {
+			var handover models.Handover
+			if err := attributevalue.UnmarshalMap(item, &handover); err != nil {
+				return nil, err
+			}
+			handovers = append(handovers, &handover)
+		}
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = result.LastEvaluatedKey
+	}
+	return handovers, nil
+}
+
+// CloseHandover declines or cancels a pending handover. Roles stay as they are.
+func CloseHandover(ctx context.Context, handover *models.Handover, status models.HandoverStatus) error {
+	client = GetClient()
+	timeNow := time.Now()
+	_, err := client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{
+				Update: &types.Update{
+					TableName: aws.String(handoversTableName),
+					Key: map[string]types.AttributeValue{
+						"id": &types.AttributeValueMemberS{Value: handover.Id},
+					},
+					UpdateExpression:    aws.String("SET #status = :status, updatedAt = :now, respondedAt = :now"),
+					ConditionExpression: aws.String("#status = :pending"),
+					ExpressionAttributeNames: map[string]string{
+						"#status": "status",
+					},
+					ExpressionAttributeValues: map[string]types.AttributeValue{
+						":status":  &types.AttributeValueMemberS{Value: string(status)},
+						":pending": &types.AttributeValueMemberS{Value: string(models.HandoverStatusPending)},
+						":now":     &types.AttributeValueMemberS{Value: timeNow.Format(time.RFC3339)},
+					},
+				},
+			},
+			deletePendingHandoverMarker(handover.FromMemberId),
+		},
+	})
+	if err != nil {
+		var canceled *types.TransactionCanceledException
+		if errors.As(err, &canceled) {
+			for _, reason := range canceled.CancellationReasons {
+				if aws.ToString(reason.Code) == "ConditionalCheckFailed" {
+					return ErrHandoverChanged
+				}
+			}
+		}
+		return err
+	}
+	handover.Status = status
+	handover.UpdatedAt = timeNow
+	handover.RespondedAt = &timeNow
+	return nil
+}
+
+// CompleteHandover accepts a pending handover and swaps the roles of the nominator and the successor in one
+// transaction: the successor gets the handover's role and the nominator successorRole, the successor's role so far.
+// Nothing is written if the handover is no longer pending or either membership changed in the meantime.
+func CompleteHandover(ctx context.Context, handover *models.Handover, successorRole models.TeamMemberRole) error {
+	client = GetClient()
+	timeNow := time.Now()
+	now := &types.AttributeValueMemberS{Value: timeNow.Format(time.RFC3339)}
+	active := &types.AttributeValueMemberS{Value: string(models.TeamMemberStatusActive)}
+	swapRole := func(memberId string, from, to models.TeamMemberRole) types.TransactWriteItem {
+		return types.TransactWriteItem{
+			Update: &types.Update{
+				TableName: &teamMembersTableName,
+				Key: map[string]types.AttributeValue{
+					"id": &types.AttributeValueMemberS{Value: memberId},
+				},
+				UpdateExpression:    aws.String("SET #role = :to, updatedAt = :now"),
+				ConditionExpression: aws.String("#status = :active AND #role = :from"),
+				ExpressionAttributeNames: map[string]string{
+					"#role":   "role",
+					"#status": "status",
+				},
+				ExpressionAttributeValues: map[string]types.AttributeValue{
+					":from":   &types.AttributeValueMemberS{Value: string(from)},
+					":to":     &types.AttributeValueMemberS{Value: string(to)},
+					":active": active,
+					":now":    now,
+				},
+			},
+		}
+	}
+	_, err := client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{
+				Update: &types.Update{
+					TableName: aws.String(handoversTableName),
+					Key: map[string]types.AttributeValue{
+						"id": &types.AttributeValueMemberS{Value: handover.Id},
+					},
+					UpdateExpression:    aws.String("SET #status = :accepted, updatedAt = :now, respondedAt = :now"),
+					ConditionExpression: aws.String("#status = :pending"),
+					ExpressionAttributeNames: map[string]string{
+						"#status": "status",
+					},
+					ExpressionAttributeValues: map[string]types.AttributeValue{
+						":accepted": &types.AttributeValueMemberS{Value: string(models.HandoverStatusAccepted)},
+						":pending":  &types.AttributeValueMemberS{Value: string(models.HandoverStatusPending)},
+						":now":      now,
+					},
+				},
+			},
+			swapRole(handover.FromMemberId, handover.Role, successorRole),
+			swapRole(handover.ToMemberId, successorRole, handover.Role),
+			deletePendingHandoverMarker(handover.FromMemberId),
+		},
+	})
+	if err != nil {
+		var canceled *types.TransactionCanceledException
+		if errors.As(err, &canceled) {
+			for _, reason := range canceled.CancellationReasons {
+				if aws.ToString(reason.Code) == "ConditionalCheckFailed" {
+					return ErrHandoverChanged
+				}
+			}
+		}
+		return err
+	}
+	handover.Status = models.HandoverStatusAccepted
+	handover.UpdatedAt = timeNow
+	handover.RespondedAt = &timeNow
+	return nil
+}
+
+// DeleteHandoversByTeamId removes every handover of a team, whatever its status, and the markers of pending ones.
+func DeleteHandoversByTeamId(ctx context.Context, teamId string) error {
+	client = GetClient()
+	handovers, err := ListHandoversByTeamId(ctx, teamId)
+	if err != nil {
+		return err
+	}
+	for _, h := range handovers {
+		if h.Status == models.HandoverStatusPending {
+			_, err := client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+				TableName: aws.String(handoversTableName),
+				Key:       pendingHandoverKey(h.FromMemberId),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		_, err := client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+			TableName: aws.String(handoversTableName),
+			Key: map[string]types.AttributeValue{
+				"id": &types.AttributeValueMemberS{Value: h.Id},
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
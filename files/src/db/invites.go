@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"errors"
 	"sort"
 	"strings"
 	"time"
@@ -293,6 +294,116 @@ func RevokeInviteById(ctx context.Context, inviteId, revokedBy string) (*models.
 	return &updatedInvite, nil
 }
 
+// GetPendingInvitesByEmail returns the pending invites sent to the email address, to any team.
+func GetPendingInvitesByEmail(ctx context.Context, email string) ([]*models.Invite, error) {
+	client = GetClient()
+	invites := make([]*models.Invite, 0)
+	input := &dynamodb.ScanInput{
+		TableName:        aws.String(invitesTableName),
+		FilterExpression: aws.String("#email = :email AND #status = :status"),
+		ExpressionAttributeNames: map[string]string{
+			"#email":  "email",
+			"#status": "status",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":email":  &types.AttributeValueMemberS{Value: email},
+			":status": &types.AttributeValueMemberS{Value: string(models.InviteStatusPending)},
+		},
+	}
+	for {
+		result, err := client.Scan(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result.Items {
+			var invite models.Invite
+			if err := attributevalue.UnmarshalMap(item, &invite); err != nil {
+				return nil, err
+			}
+			invites = append(invites, &invite)
+		}
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+	return invites, nil
+}
+
+// ErrGuardianConsentClosed is returned when consent is given to an invite that is no longer pending or that a
+// guardian has already consented to.
+var ErrGuardianConsentClosed = errors.New("invite is not pending or already has guardian consent")
+
+// GiveGuardianConsent records that guardianId consented to the invitee, a minor, joining the team. Nothing is
+// written and ErrGuardianConsentClosed is returned unless the invite is pending and has no consent yet.
+func GiveGuardianConsent(ctx context.Context, inviteId, guardianId string) (*models.Invite, error) {
+	client = GetClient()
+	response, err := client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(invitesTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: inviteId},
+		},
+		UpdateExpression:    aws.String("SET #updatedAt = :now, #guardianConsentBy = :guardianId, #guardianConsentAt = :now"),
+		ConditionExpression: aws.String("#status = :pending AND (attribute_not_exists(#guardianConsentBy) OR attribute_type(#guardianConsentBy, :null))"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":now":        &types.AttributeValueMemberS{Value: time.Now().Format(time.RFC3339)},
+			":guardianId": &types.AttributeValueMemberS{Value: guardianId},
+			":pending":    &types.AttributeValueMemberS{Value: string(models.InviteStatusPending)},
+			":null":       &types.AttributeValueMemberS{Value: "NULL"},
+		},
+		ExpressionAttributeNames: map[string]string{
+			"#updatedAt":         "updatedAt",
+			"#status":            "status",
+			"#guardianConsentBy": "guardianConsentBy",
+			"#guardianConsentAt": "guardianConsentAt",
+		},
+		ReturnValues: types.ReturnValueAllNew,
+	})
+	if err != nil {
+		var failed *types.ConditionalCheckFailedException
+		if errors.As(err, &failed) {
+			return nil, ErrGuardianConsentClosed
+		}
+		return nil, err
+	}
+	var updatedInvite models.Invite
+	err = attributevalue.UnmarshalMap(response.Attributes, &updatedInvite)
+	if err != nil {
+		return nil, err
+	}
+	return &updatedInvite, nil
+}
+
+// MarkGuardianConsentRequested records that the guardians were asked to consent to the invite. It reports false
+// without writing if they were asked before, so concurrent attempts ask them only once.
+func MarkGuardianConsentRequested(ctx context.Context, inviteId string) (bool, error) {
+	client = GetClient()
+	_, err := client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(invitesTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: inviteId},
+		},
+		UpdateExpression:    aws.String("SET #updatedAt = :now, #guardianConsentRequestedAt = :now"),
+		ConditionExpression: aws.String("attribute_not_exists(#guardianConsentRequestedAt) OR attribute_type(#guardianConsentRequestedAt, :null)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":now":  &types.AttributeValueMemberS{Value: time.Now().Format(time.RFC3339)},
+			":null": &types.AttributeValueMemberS{Value: "NULL"},
+		},
+		ExpressionAttributeNames: map[string]string{
+			"#updatedAt":                  "updatedAt",
+			"#guardianConsentRequestedAt": "guardianConsentRequestedAt",
+		},
+	})
+	if err != nil {
+		var failed *types.ConditionalCheckFailedException
+		if errors.As(err, &failed) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
 func ResentInviteEmail(ctx context.Context, inviteId string) error {
 	client = GetClient()
 	_, err := client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
@@ -11,17 +11,35 @@ import (
 
 // Table Names
 var (
-	teamsTableName           = os.Getenv("TEAMS_TABLE_NAME")
-	invitesTableName         = os.Getenv("INVITE_TABLE_NAME")
-	teamMembersTableName     = os.Getenv("TEAM_MEMBERS_TABLE_NAME")
-	teamSettingsTableName    = os.Getenv("TEAM_SETTINGS_TABLE_NAME")
-	seasonsTableName         = os.Getenv("SEASONS_TABLE_NAME")
-	goalsTableName           = os.Getenv("GOALS_TABLE_NAME")
-	progressReportsTableName = os.Getenv("PROGRESS_REPORTS_TABLE_NAME")
-	progressTableName        = os.Getenv("PROGRESS_TABLE_NAME")
-	commentsTableName        = os.Getenv("COMMENTS_TABLE_NAME")
-	commentFilesTableName    = os.Getenv("COMMENT_FILES_TABLE_NAME")
-	activitiesTableName      = os.Getenv("ACTIVITIES_TABLE_NAME")
+	teamsTableName               = os.Getenv("TEAMS_TABLE_NAME")
+	invitesTableName             = os.Getenv("INVITE_TABLE_NAME")
+	teamMembersTableName         = os.Getenv("TEAM_MEMBERS_TABLE_NAME")
+	teamSettingsTableName        = os.Getenv("TEAM_SETTINGS_TABLE_NAME")
+	seasonsTableName             = os.Getenv("SEASONS_TABLE_NAME")
+	goalsTableName               = os.Getenv("GOALS_TABLE_NAME")
+	progressReportsTableName     = os.Getenv("PROGRESS_REPORTS_TABLE_NAME")
+	progressTableName            = os.Getenv("PROGRESS_TABLE_NAME")
+	commentsTableName            = os.Getenv("COMMENTS_TABLE_NAME")
+	commentFilesTableName        = os.Getenv("COMMENT_FILES_TABLE_NAME")
+	activitiesTableName          = os.Getenv("ACTIVITIES_TABLE_NAME")
+	questionnairesTableName      = os.Getenv("QUESTIONNAIRES_TABLE_NAME")
+	seasonStatsTableName         = os.Getenv("SEASON_STATS_TABLE_NAME")
+	eventsTableName              = os.Getenv("EVENTS_TABLE_NAME")
+	attendanceTableName          = os.Getenv("ATTENDANCE_TABLE_NAME")
+	matchStatsTableName          = os.Getenv("MATCH_STATS_TABLE_NAME")
+	skillCataloguesTableName     = os.Getenv("SKILL_CATALOGUES_TABLE_NAME")
+	skillAssessmentsTableName    = os.Getenv("SKILL_ASSESSMENTS_TABLE_NAME")
+	drillsTableName              = os.Getenv("DRILLS_TABLE_NAME")
+	lineupsTableName             = os.Getenv("LINEUPS_TABLE_NAME")
+	fitnessTestsTableName        = os.Getenv("FITNESS_TESTS_TABLE_NAME")
+	fitnessResultsTableName      = os.Getenv("FITNESS_RESULTS_TABLE_NAME")
+	mediaReferencesTableName     = os.Getenv("MEDIA_REFERENCES_TABLE_NAME")
+	organizationsTableName       = os.Getenv("ORGANIZATIONS_TABLE_NAME")
+	organizationMembersTableName = os.Getenv("ORGANIZATION_MEMBERS_TABLE_NAME")
+	teamRolesTableName           = os.Getenv("TEAM_ROLES_TABLE_NAME")
+	subgroupsTableName           = os.Getenv("SUBGROUPS_TABLE_NAME")
+	handoversTableName           = os.Getenv("HANDOVERS_TABLE_NAME")
+	guardiansTableName           = os.Getenv("GUARDIANS_TABLE_NAME")
 )
 
 // InitClient initializes the DynamoDB client with the provided config
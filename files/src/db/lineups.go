@@ -0,0 +1,114 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/fpgschiba/volleygoals/models"
+)
+
+// PutLineup creates or replaces the lineup of a match. CreatedAt is kept when a lineup already exists.
+func PutLineup(ctx context.Context, lineup *models.Lineup) error {
+	client = GetClient()
+	existing, err := GetLineupByEventId(ctx, lineup.EventId)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	lineup.CreatedAt = now
+	if existing != nil {
+		lineup.CreatedAt = existing.CreatedAt
+	}
+	lineup.UpdatedAt = now
+	_, err = client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(lineupsTableName),
+		Item:      lineup.ToAttributeValues(),
+	})
+	return err
+}
+
+func GetLineupByEventId(ctx context.Context, eventId string) (*models.Lineup, error) {
+	client = GetClient()
+	result, err := client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(lineupsTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: eventId},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Item == nil {
+		return nil, nil
+	}
+	var lineup models.Lineup
+	if err := attributevalue.UnmarshalMap(result.Item, &lineup); err != nil {
+		return nil, err
+	}
+	return &lineup, nil
+}
+
+func DeleteLineup(ctx context.Context, eventId string) error {
+	client = GetClient()
+	_, err := client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(lineupsTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: eventId},
+		},
+	})
+	return err
+}
+
+// ListLineupsBySeasonId returns the lineups of all matches of a season in no particular order.
+func ListLineupsBySeasonId(ctx context.Context, seasonId string) ([]*models.Lineup, error) {
+	client = GetClient()
+	lineups := make([]*models.Lineup, 0)
+	var lastKey map[string]types.AttributeValue
+	for {
+		in := &dynamodb.QueryInput{
+			TableName:              aws.String(lineupsTableName),
+			IndexName:              aws.String("seasonIdIndex"),
+			KeyConditionExpression: aws.String("seasonId = :seasonId"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":seasonId": &types.AttributeValueMemberS{Value: seasonId},
+			},
+		}
+		if lastKey != nil {
+			in.ExclusiveStartKey = lastKey
+		}
+		result, err := client.Query(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result.Items {
+			var lineup models.Lineup
+			if err := attributevalue.UnmarshalMap(item, &lineup); err != nil {
+				return nil, err
+			}
+			lineups = append(lineups, &lineup)
+		}
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = result.LastEvaluatedKey
+	}
+	return lineups, nil
+}
+
+// DeleteLineupsBySeasonId removes the lineups of all matches of a season.
+func DeleteLineupsBySeasonId(ctx context.Context, seasonId string) error {
+	lineups, err := ListLineupsBySeasonId(ctx, seasonId)
+	if err != nil {
+		return err
+	}
+	for _, l := range lineups {
+		if err := DeleteLineup(ctx, l.EventId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -14,17 +14,35 @@ import (
 
 // Table Names
 var (
-	teamsTableName           = "dev-teams"
-	invitesTableName         = "dev-invites"
-	teamMembersTableName     = "dev-team-members"
-	teamSettingsTableName    = "dev-team-settings"
-	seasonsTableName         = "dev-seasons"
-	goalsTableName           = "dev-goals"
-	progressReportsTableName = "dev-progress-reports"
-	progressTableName        = "dev-progress"
-	commentsTableName        = "dev-comments"
-	commentFilesTableName    = "dev-comment-files"
-	activitiesTableName      = "dev-activities"
+	teamsTableName               = "dev-teams"
+	invitesTableName             = "dev-invites"
+	teamMembersTableName         = "dev-team-members"
+	teamSettingsTableName        = "dev-team-settings"
+	seasonsTableName             = "dev-seasons"
+	goalsTableName               = "dev-goals"
+	progressReportsTableName     = "dev-progress-reports"
+	progressTableName            = "dev-progress"
+	commentsTableName            = "dev-comments"
+	commentFilesTableName        = "dev-comment-files"
+	activitiesTableName          = "dev-activities"
+	questionnairesTableName      = "dev-questionnaires"
+	seasonStatsTableName         = "dev-season-stats"
+	eventsTableName              = "dev-events"
+	attendanceTableName          = "dev-attendance"
+	matchStatsTableName          = "dev-match-stats"
+	skillCataloguesTableName     = "dev-skill-catalogues"
+	skillAssessmentsTableName    = "dev-skill-assessments"
+	drillsTableName              = "dev-drills"
+	lineupsTableName             = "dev-lineups"
+	fitnessTestsTableName        = "dev-fitness-tests"
+	fitnessResultsTableName      = "dev-fitness-results"
+	mediaReferencesTableName     = "dev-media-references"
+	organizationsTableName       = "dev-organizations"
+	organizationMembersTableName = "dev-organization-members"
+	teamRolesTableName           = "dev-team-roles"
+	subgroupsTableName           = "dev-subgroups"
+	handoversTableName           = "dev-handovers"
+	guardiansTableName           = "dev-guardians"
 )
 
 // InitClient initializes the DynamoDB client for local mode. If awsConfig is
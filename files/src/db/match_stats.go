@@ -0,0 +1,114 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/fpgschiba/volleygoals/models"
+)
+
+// PutMatchStats creates or replaces the box score of a match. CreatedAt is kept when a box score already exists.
+func PutMatchStats(ctx context.Context, stats *models.MatchStats) error {
+	client = GetClient()
+	existing, err := GetMatchStatsByEventId(ctx, stats.EventId)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	stats.CreatedAt = now
+	if existing != nil {
+		stats.CreatedAt = existing.CreatedAt
+	}
+	stats.UpdatedAt = now
+	_, err = client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(matchStatsTableName),
+		Item:      stats.ToAttributeValues(),
+	})
+	return err
+}
+
+func GetMatchStatsByEventId(ctx context.Context, eventId string) (*models.MatchStats, error) {
+	client = GetClient()
+	result, err := client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(matchStatsTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: eventId},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Item == nil {
+		return nil, nil
+	}
+	var stats models.MatchStats
+	if err := attributevalue.UnmarshalMap(result.Item, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+func DeleteMatchStats(ctx context.Context, eventId string) error {
+	client = GetClient()
+	_, err := client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(matchStatsTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: eventId},
+		},
+	})
+	return err
+}
+
+// ListMatchStatsBySeasonId returns the box scores of all matches of a season in no particular order.
+func ListMatchStatsBySeasonId(ctx context.Context, seasonId string) ([]*models.MatchStats, error) {
+	client = GetClient()
+	all := make([]*models.MatchStats, 0)
+	var lastKey map[string]types.AttributeValue
+	for {
+		in := &dynamodb.QueryInput{
+			TableName:              aws.String(matchStatsTableName),
+			IndexName:              aws.String("seasonIdIndex"),
+			KeyConditionExpression: aws.String("seasonId = :seasonId"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":seasonId": &types.AttributeValueMemberS{Value: seasonId},
+			},
+		}
+		if lastKey != nil {
+			in.ExclusiveStartKey = lastKey
+		}
+		result, err := client.Query(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result.Items {
+			var stats models.MatchStats
+			if err := attributevalue.UnmarshalMap(item, &stats); err != nil {
+				return nil, err
+			}
+			all = append(all, &stats)
+		}
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = result.LastEvaluatedKey
+	}
+	return all, nil
+}
+
+// DeleteMatchStatsBySeasonId removes the box scores of all matches of a season.
+func DeleteMatchStatsBySeasonId(ctx context.Context, seasonId string) error {
+	matches, err := ListMatchStatsBySeasonId(ctx, seasonId)
+	if err != nil {
+		return err
+	}
+	for _, m := range matches {
+		if err := DeleteMatchStats(ctx, m.EventId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
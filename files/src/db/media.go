@@ -0,0 +1,155 @@
+package db
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/fpgschiba/volleygoals/models"
+)
+
+// CreateMediaReference stores a new media reference. ID and timestamps are assigned here.
+func CreateMediaReference(ctx context.Context, reference *models.MediaReference) (*models.MediaReference, error) {
+	client = GetClient()
+	now := time.Now()
+	reference.Id = models.GenerateID()
+	reference.CreatedAt = now
+	reference.UpdatedAt = now
+	_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(mediaReferencesTableName),
+		Item:      reference.ToAttributeValues(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reference, nil
+}
+
+func GetMediaReferenceById(ctx context.Context, referenceId string) (*models.MediaReference, error) {
+	client = GetClient()
+	result, err := client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(mediaReferencesTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: referenceId},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Item == nil {
+		return nil, nil
+	}
+	var reference models.MediaReference
+	if err := attributevalue.UnmarshalMap(result.Item, &reference); err != nil {
+		return nil, err
+	}
+	return &reference, nil
+}
+
+// UpdateMediaReference replaces a stored media reference with the given one.
+func UpdateMediaReference(ctx context.Context, reference *models.MediaReference) error {
+	client = GetClient()
+	reference.UpdatedAt = time.Now()
+	_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(mediaReferencesTableName),
+		Item:      reference.ToAttributeValues(),
+	})
+	return err
+}
+
+func DeleteMediaReference(ctx context.Context, referenceId string) error {
+	client = GetClient()
+	_, err := client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(mediaReferencesTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: referenceId},
+		},
+	})
+	return err
+}
+
+// ListMediaReferencesByTargetId returns the media references of a goal, progress entry or comment, oldest first.
+func ListMediaReferencesByTargetId(ctx context.Context, targetId string) ([]*models.MediaReference, error) {
+	references, err := queryMediaReferences(ctx, "targetIdIndex", "targetId", targetId)
+	if err != nil {
+		return nil, err
+	}
+	sortMediaReferences(references)
+	return references, nil
+}
+
+// DeleteMediaReferencesForTarget deletes all media references of a goal, progress entry or comment.
+func DeleteMediaReferencesForTarget(ctx context.Context, targetId string) error {
+	references, err := queryMediaReferences(ctx, "targetIdIndex", "targetId", targetId)
+	if err != nil {
+		return err
+	}
+	for _, r := range references {
+		if err := DeleteMediaReference(ctx, r.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteMediaReferencesByTeamId deletes every media reference of a team.
+func DeleteMediaReferencesByTeamId(ctx context.Context, teamId string) error {
+	references, err := queryMediaReferences(ctx, "teamIdIndex", "teamId", teamId)
+	if err != nil {
+		return err
+	}
+	for _, r := range references {
+		if err := DeleteMediaReference(ctx, r.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func queryMediaReferences(ctx context.Context, indexName, key, value string) ([]*models.MediaReference, error) {
+	client = GetClient()
+	references := make([]*models.MediaReference, 0)
+	var lastKey map[string]types.AttributeValue
+	for {
+		in := &dynamodb.QueryInput{
+			TableName:              aws.String(mediaReferencesTableName),
+			IndexName:              aws.String(indexName),
+			KeyConditionExpression: aws.String(key + " = :value"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":value": &types.AttributeValueMemberS{Value: value},
+			},
+		}
+		if lastKey != nil {
+			in.ExclusiveStartKey = lastKey
+		}
+		result, err := client.Query(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result.Items {
+			var reference models.MediaReference
+			if err := attributevalue.UnmarshalMap(item, &reference); err != nil {
+				return nil, err
+			}
+			references = append(references, &reference)
+		}
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = result.LastEvaluatedKey
+	}
+	return references, nil
+}
+
+func sortMediaReferences(references []*models.MediaReference) {
+	sort.Slice(references, func(i, j int) bool {
+		if !references[i].CreatedAt.Equal(references[j].CreatedAt) {
+			return references[i].CreatedAt.Before(references[j].CreatedAt)
+		}
+		return references[i].Id < references[j].Id
+	})
+}
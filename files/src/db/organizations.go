@@ -0,0 +1,240 @@
+package db
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/fpgschiba/volleygoals/models"
+)
+
+// CreateOrganization stores a new organization. ID and timestamps are assigned here.
+func CreateOrganization(ctx context.Context, organization *models.Organization) (*models.Organization, error) {
+	client = GetClient()
+	now := time.Now()
+	organization.Id = models.GenerateID()
+	organization.CreatedAt = now
+	organization.UpdatedAt = now
+	_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(organizationsTableName),
+		Item:      organization.ToAttributeValues(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+func GetOrganizationById(ctx context.Context, organizationId string) (*models.Organization, error) {
+	client = GetClient()
+	result, err := client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(organizationsTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: organizationId},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Item == nil {
+		return nil, nil
+	}
+	var organization models.Organization
+	if err := attributevalue.UnmarshalMap(result.Item, &organization); err != nil {
+		return nil, err
+	}
+	return &organization, nil
+}
+
+// UpdateOrganization replaces a stored organization with the given one.
+func UpdateOrganization(ctx context.Context, organization *models.Organization) error {
+	client = GetClient()
+	organization.UpdatedAt = time.Now()
+	_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(organizationsTableName),
+		Item:      organization.ToAttributeValues(),
+	})
+	return err
+}
+
+// DeleteOrganization removes an organization together with its members. Its teams are not touched.
+func DeleteOrganization(ctx context.Context, organizationId string) error {
+	client = GetClient()
+	members, err := ListOrganizationMembers(ctx, organizationId)
+	if err != nil {
+		return err
+	}
+	for _, m := range members {
+		if err := DeleteOrganizationMember(ctx, m.Id); err != nil {
+			return err
+		}
+	}
+	_, err = client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(organizationsTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: organizationId},
+		},
+	})
+	return err
+}
+
+// ListOrganizations returns all organizations sorted by name.
+func ListOrganizations(ctx context.Context) ([]*models.Organization, error) {
+	client = GetClient()
+	organizations := make([]*models.Organization, 0)
+	var lastKey map[string]types.AttributeValue
+	for {
+		in := &dynamodb.ScanInput{
+			TableName: aws.String(organizationsTableName),
+		}
+		if lastKey != nil {
+			in.ExclusiveStartKey = lastKey
+		}
+		result, err := client.Scan(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result.Items {
+			var organization models.Organization
+			if err := attributevalue.UnmarshalMap(item, &organization); err != nil {
+				return nil, err
+			}
+			organizations = append(organizations, &organization)
+		}
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = result.LastEvaluatedKey
+	}
+	sort.Slice(organizations, func(i, j int) bool {
+		return strings.ToLower(organizations[i].Name) < strings.ToLower(organizations[j].Name)
+	})
+	return organizations, nil
+}
+
+// AddOrganizationMember stores a new organization membership. ID and timestamps are assigned here.
+func AddOrganizationMember(ctx context.Context, member *models.OrganizationMember) (*models.OrganizationMember, error) {
+	client = GetClient()
+	now := time.Now()
+	member.Id = models.GenerateID()
+	member.CreatedAt = now
+	member.UpdatedAt = now
+	_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(organizationMembersTableName),
+		Item:      member.ToAttributeValues(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+func GetOrganizationMemberById(ctx context.Context, memberId string) (*models.OrganizationMember, error) {
+	client = GetClient()
+	result, err := client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(organizationMembersTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: memberId},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Item == nil {
+		return nil, nil
+	}
+	var member models.OrganizationMember
+	if err := attributevalue.UnmarshalMap(result.Item, &member); err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// GetOrganizationMember returns the user's membership in the organization, or nil if the user is not a member.
+func GetOrganizationMember(ctx context.Context, organizationId, userId string) (*models.OrganizationMember, error) {
+	memberships, err := GetOrganizationMembershipsByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range memberships {
+		if m.OrganizationId == organizationId {
+			return m, nil
+		}
+	}
+	return nil, nil
+}
+
+// UpdateOrganizationMember replaces a stored organization membership with the given one.
+func UpdateOrganizationMember(ctx context.Context, member *models.OrganizationMember) error {
+	client = GetClient()
+	member.UpdatedAt = time.Now()
+	_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(organizationMembersTableName),
+		Item:      member.ToAttributeValues(),
+	})
+	return err
+}
+
+func DeleteOrganizationMember(ctx context.Context, memberId string) error {
+	client = GetClient()
+	_, err := client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(organizationMembersTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: memberId},
+		},
+	})
+	return err
+}
+
+// ListOrganizationMembers returns all members of an organization, oldest membership first.
+func ListOrganizationMembers(ctx context.Context, organizationId string) ([]*models.OrganizationMember, error) {
+	return queryOrganizationMembers(ctx, "organizationIdIndex", "organizationId", organizationId)
+}
+
+// GetOrganizationMembershipsByUserId returns the user's memberships in all organizations.
+func GetOrganizationMembershipsByUserId(ctx context.Context, userId string) ([]*models.OrganizationMember, error) {
+	return queryOrganizationMembers(ctx, "userIdIndex", "userId", userId)
+}
+
+func queryOrganizationMembers(ctx context.Context, indexName, key, value string) ([]*models.OrganizationMember, error) {
+	client = GetClient()
+	members := make([]*models.OrganizationMember, 0)
+	var lastKey map[string]types.AttributeValue
+	for {
+		in := &dynamodb.QueryInput{
+			TableName:              aws.String(organizationMembersTableName),
+			IndexName:              aws.String(indexName),
+			KeyConditionExpression: aws.String(key + " = :value"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":value": &types.AttributeValueMemberS{Value: value},
+			},
+		}
+		if lastKey != nil {
+			in.ExclusiveStartKey = lastKey
+		}
+		result, err := client.Query(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result.Items {
+			var member models.OrganizationMember
+			if err := attributevalue.UnmarshalMap(item, &member); err != nil {
+				return nil, err
+			}
+			members = append(members, &member)
+		}
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = result.LastEvaluatedKey
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].CreatedAt.Before(members[j].CreatedAt)
+	})
+	return members, nil
+}
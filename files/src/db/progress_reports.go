@@ -21,7 +21,7 @@ type ProgressEntry struct {
 	Details string
 }
 
-func CreateProgressReport(ctx context.Context, seasonId, authorId, summary, details, overallDetails string, progressEntries []ProgressEntry, authorName *string, authorPicture *string) (*models.ProgressReport, error) {
+func CreateProgressReport(ctx context.Context, seasonId, authorId, summary, details, overallDetails string, progressEntries []ProgressEntry, answers []models.QuestionAnswer, authorName *string, authorPicture *string, eventId *string) (*models.ProgressReport, error) {
 	client = GetClient()
 	now := time.Now()
 	report := &models.ProgressReport{
@@ -33,6 +33,8 @@ func CreateProgressReport(ctx context.Context, seasonId, authorId, summary, deta
 		OverallDetails: overallDetails,
 		AuthorName:     authorName,
 		AuthorPicture:  authorPicture,
+		Answers:        answers,
+		EventId:        eventId,
 		CreatedAt:      now,
 		UpdatedAt:      now,
 	}
@@ -96,7 +98,8 @@ func GetProgressById(ctx context.Context, entryId string) (*models.Progress, err
 	return &entry, nil
 }
 
-func UpdateProgressReport(ctx context.Context, reportId string, summary, details, overallDetails *string, progressEntries []ProgressEntry) (*models.ProgressReport, error) {
+// UpdateProgressReport updates the given fields of a report. An empty eventId removes the report's event link.
+func UpdateProgressReport(ctx context.Context, reportId string, summary, details, overallDetails *string, progressEntries []ProgressEntry, answers []models.QuestionAnswer, eventId *string) (*models.ProgressReport, error) {
 	client = GetClient()
 	updateParts := []string{}
 	exprAttrValues := make(map[string]types.AttributeValue)
@@ -120,11 +123,32 @@ func UpdateProgressReport(ctx context.Context, reportId string, summary, details
 		exprAttrValues[":overallDetails"] = &types.AttributeValueMemberS{Value: *overallDetails}
 	}
 
+	if answers != nil {
+		answersAttr, err := attributevalue.Marshal(answers)
+		if err != nil {
+			return nil, err
+		}
+		updateParts = append(updateParts, "#answers = :answers")
+		exprAttrNames["#answers"] = "answers"
+		exprAttrValues[":answers"] = answersAttr
+	}
+
 	updateParts = append(updateParts, "#updatedAt = :updatedAt")
 	exprAttrNames["#updatedAt"] = "updatedAt"
 	exprAttrValues[":updatedAt"] = &types.AttributeValueMemberS{Value: time.Now().Format(time.RFC3339)}
 
+	if eventId != nil && *eventId != "" {
+		updateParts = append(updateParts, "#eventId = :eventId")
+		exprAttrValues[":eventId"] = &types.AttributeValueMemberS{Value: *eventId}
+	}
+	if eventId != nil {
+		exprAttrNames["#eventId"] = "eventId"
+	}
+
 	updateExpr := "SET " + strings.Join(updateParts, ", ")
+	if eventId != nil && *eventId == "" {
+		updateExpr += " REMOVE #eventId"
+	}
 
 	result, err := client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
 		TableName:                 &progressReportsTableName,
@@ -349,6 +373,42 @@ func writeProgressEntries(ctx context.Context, reportId string, entries []Progre
 	return nil
 }
 
+// ListAllProgressReportsBySeasonId returns every progress report of a season, paging through the full scan.
+func ListAllProgressReportsBySeasonId(ctx context.Context, seasonId string) ([]*models.ProgressReport, error) {
+	client = GetClient()
+	reports := make([]*models.ProgressReport, 0)
+	var lastKey map[string]types.AttributeValue
+	for {
+		in := &dynamodb.ScanInput{
+			TableName:        aws.String(progressReportsTableName),
+			FilterExpression: aws.String("#sid = :seasonId"),
+			ExpressionAttributeNames: map[string]string{
+				"#sid": "seasonId",
+			},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":seasonId": &types.AttributeValueMemberS{Value: seasonId},
+			},
+		}
+		if lastKey != nil {
+			in.ExclusiveStartKey = lastKey
+		}
+		result, err := client.Scan(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		page, err := unmarshalProgressReports(result.Items)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, page...)
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = result.LastEvaluatedKey
+	}
+	return reports, nil
+}
+
 func ListProgressEntriesByReportId(ctx context.Context, reportId string) ([]*models.Progress, error) {
 	result, err := client.Scan(ctx, &dynamodb.ScanInput{
 		TableName:        aws.String(progressTableName),
@@ -374,38 +434,52 @@ func ListProgressEntriesByReportId(ctx context.Context, reportId string) ([]*mod
 	return entries, nil
 }
 
+// ListProgressEntriesByReportIds returns the progress entries of the given reports, keyed by report ID.
+// Report IDs are filtered in chunks to keep the filter expression within DynamoDB's size limits.
 func ListProgressEntriesByReportIds(ctx context.Context, reportIds []string) (map[string][]*models.Progress, error) {
+	client = GetClient()
 	result := make(map[string][]*models.Progress)
-	if len(reportIds) == 0 {
-		return result, nil
-	}
-
-	filterParts := make([]string, 0, len(reportIds))
-	exprAttrValues := make(map[string]types.AttributeValue)
-	for i, id := range reportIds {
-		placeholder := fmt.Sprintf(":r%d", i)
-		filterParts = append(filterParts, fmt.Sprintf("#rid = %s", placeholder))
-		exprAttrValues[placeholder] = &types.AttributeValueMemberS{Value: id}
-	}
+	const chunkSize = 50
+	for start := 0; start < len(reportIds); start += chunkSize {
+		end := start + chunkSize
+		if end > len(reportIds) {
+			end = len(reportIds)
+		}
 
-	scanResult, err := client.Scan(ctx, &dynamodb.ScanInput{
-		TableName:        aws.String(progressTableName),
-		FilterExpression: aws.String(strings.Join(filterParts, " OR ")),
-		ExpressionAttributeNames: map[string]string{
-			"#rid": "progressReportId",
-		},
-		ExpressionAttributeValues: exprAttrValues,
-	})
-	if err != nil {
-		return nil, err
-	}
+		filterParts := make([]string, 0, end-start)
+		exprAttrValues := make(map[string]types.AttributeValue)
+		for i, id := range reportIds[start:end] {
+			placeholder := fmt.Sprintf(":r%d", i)
+			filterParts = append(filterParts, fmt.Sprintf("#rid = %s", placeholder))
+			exprAttrValues[placeholder] = &types.AttributeValueMemberS{Value: id}
+		}
 
-	for _, item := range scanResult.Items {
-		var p models.Progress
-		if err := attributevalue.UnmarshalMap(item, &p); err != nil {
-			return nil, err
+		var lastKey map[string]types.AttributeValue
+		for {
+			scanResult, err := client.Scan(ctx, &dynamodb.ScanInput{
+				TableName:        aws.String(progressTableName),
+				FilterExpression: aws.String(strings.Join(filterParts, " OR ")),
+				ExpressionAttributeNames: map[string]string{
+					"#rid": "progressReportId",
+				},
+				ExpressionAttributeValues: exprAttrValues,
+				ExclusiveStartKey:         lastKey,
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range scanResult.Items {
+				var p models.Progress
+				if err := attributevalue.UnmarshalMap(item, &p); err != nil {
+					return nil, err
+				}
+				result[p.ProgressReportId] = append(result[p.ProgressReportId], &p)
+			}
+			if scanResult.LastEvaluatedKey == nil {
+				break
+			}
+			lastKey = scanResult.LastEvaluatedKey
 		}
-		result[p.ProgressReportId] = append(result[p.ProgressReportId], &p)
 	}
 	return result, nil
 }
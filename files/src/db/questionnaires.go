@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/fpgschiba/volleygoals/models"
+)
+
+func CreateQuestionnaire(ctx context.Context, teamId, seasonId string, questions []models.Question) (*models.Questionnaire, error) {
+	client = GetClient()
+	now := time.Now()
+	questionnaire := &models.Questionnaire{
+		Id:        models.GenerateID(),
+		TeamId:    teamId,
+		SeasonId:  seasonId,
+		Questions: questions,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(questionnairesTableName),
+		Item:      questionnaire.ToAttributeValues(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return questionnaire, nil
+}
+
+// GetQuestionnaireBySeasonId returns the questionnaire attached to a season, or nil if the season has none.
+func GetQuestionnaireBySeasonId(ctx context.Context, seasonId string) (*models.Questionnaire, error) {
+	client = GetClient()
+	result, err := client.Query(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(questionnairesTableName),
+		IndexName:              aws.String("seasonIdIndex"),
+		KeyConditionExpression: aws.String("seasonId = :seasonId"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":seasonId": &types.AttributeValueMemberS{Value: seasonId},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Items) == 0 {
+		return nil, nil
+	}
+	var questionnaire models.Questionnaire
+	err = attributevalue.UnmarshalMap(result.Items[0], &questionnaire)
+	if err != nil {
+		return nil, err
+	}
+	return &questionnaire, nil
+}
+
+func UpdateQuestionnaire(ctx context.Context, questionnaire *models.Questionnaire) error {
+	client = GetClient()
+	questionnaire.UpdatedAt = time.Now()
+	_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(questionnairesTableName),
+		Item:      questionnaire.ToAttributeValues(),
+	})
+	return err
+}
+
+func DeleteQuestionnaire(ctx context.Context, questionnaireId string) error {
+	client = GetClient()
+	_, err := client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(questionnairesTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: questionnaireId},
+		},
+	})
+	return err
+}
+
+// DeleteQuestionnaireBySeasonId removes the questionnaire of a season, if it has one.
+func DeleteQuestionnaireBySeasonId(ctx context.Context, seasonId string) error {
+	questionnaire, err := GetQuestionnaireBySeasonId(ctx, seasonId)
+	if err != nil || questionnaire == nil {
+		return err
+	}
+	return DeleteQuestionnaire(ctx, questionnaire.Id)
+}
@@ -0,0 +1,43 @@
+package db
+
+import (
+	"context"
+	"sync"
+)
+
+type requestCacheKey struct{}
+
+// requestCache holds lookups made while serving one request, keyed by what was looked up.
+type requestCache struct {
+	mu      sync.Mutex
+	entries map[string]interface{}
+}
+
+// WithRequestCache attaches an empty cache to the context. Lookups made through Remember with the returned
+// context are answered from memory after the first one, until the request ends.
+func WithRequestCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, requestCacheKey{}, &requestCache{entries: make(map[string]interface{})})
+}
+
+// Remember returns the value an earlier call in the same request stored under key, or loads and stores it.
+// Failed loads are not stored. Without a request cache on the context it always loads.
+func Remember[T any](ctx context.Context, key string, load func() (T, error)) (T, error) {
+	cache, ok := ctx.Value(requestCacheKey{}).(*requestCache)
+	if !ok {
+		return load()
+	}
+	cache.mu.Lock()
+	if value, found := cache.entries[key]; found {
+		cache.mu.Unlock()
+		return value.(T), nil
+	}
+	cache.mu.Unlock()
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+	cache.mu.Lock()
+	cache.entries[key] = value
+	cache.mu.Unlock()
+	return value, nil
+}
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/fpgschiba/volleygoals/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetSeasonStats returns the stored stats rollup of a season, or nil if none is stored.
+// Expired rollups are returned as well; callers decide on freshness using ExpiresAt.
+func GetSeasonStats(ctx context.Context, seasonId string) (*models.SeasonStats, error) {
+	client = GetClient()
+	result, err := client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(seasonStatsTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: seasonId},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Item == nil {
+		return nil, nil
+	}
+	var stats models.SeasonStats
+	if err := attributevalue.UnmarshalMap(result.Item, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+func PutSeasonStats(ctx context.Context, stats *models.SeasonStats) error {
+	client = GetClient()
+	_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(seasonStatsTableName),
+		Item:      stats.ToAttributeValues(),
+	})
+	return err
+}
+
+// InvalidateSeasonStats drops the stats rollup of a season so the next read recomputes it.
+// Failures are only logged: a stale rollup still expires on its own.
+func InvalidateSeasonStats(ctx context.Context, seasonId string) {
+	client = GetClient()
+	_, err := client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(seasonStatsTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: seasonId},
+		},
+	})
+	if err != nil {
+		log.WithError(err).WithField("seasonId", seasonId).Warn("failed to invalidate season stats")
+	}
+}
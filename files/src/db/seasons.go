@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"strings"
 	"time"
@@ -252,7 +253,73 @@ func GetAllSeasonIdsByTeamId(ctx context.Context, teamId string) (map[string]str
 	return seasonIds, nil
 }
 
+// GetAllSeasonsByTeamId returns every season of a team, paginating through all DynamoDB pages.
+func GetAllSeasonsByTeamId(ctx context.Context, teamId string) ([]*models.Season, error) {
+	seasons := make([]*models.Season, 0)
+	var cursor *models.Cursor
+	for {
+		page, _, nextCursor, hasMore, err := ListSeasons(ctx, SeasonFilter{
+			FilterOptions: FilterOptions{Limit: 100, Cursor: cursor},
+			TeamId:        teamId,
+		})
+		if err != nil {
+			return nil, err
+		}
+		seasons = append(seasons, page...)
+		if !hasMore {
+			break
+		}
+		cursor = nextCursor
+	}
+	return seasons, nil
+}
+
+// ListSeasonsByStatus scans the whole seasons table for seasons in one of the given statuses.
+func ListSeasonsByStatus(ctx context.Context, statuses ...models.SeasonStatus) ([]*models.Season, error) {
+	client = GetClient()
+	placeholders := make([]string, 0, len(statuses))
+	values := make(map[string]types.AttributeValue, len(statuses))
+	for i, status := range statuses {
+		key := fmt.Sprintf(":status%d", i)
+		placeholders = append(placeholders, key)
+		values[key] = &types.AttributeValueMemberS{Value: string(status)}
+	}
+
+	seasons := make([]*models.Season, 0)
+	var lastKey map[string]types.AttributeValue
+	for {
+		result, err := client.Scan(ctx, &dynamodb.ScanInput{
+			TableName:                 aws.String(seasonsTableName),
+			FilterExpression:          aws.String(fmt.Sprintf("#status IN (%s)", strings.Join(placeholders, ", "))),
+			ExpressionAttributeNames:  map[string]string{"#status": "status"},
+			ExpressionAttributeValues: values,
+			ExclusiveStartKey:         lastKey,
+		})
+		if err != nil {
+			return nil, err
+		}
+		page, err := unmarshalSeasons(result.Items)
+		if err != nil {
+			return nil, err
+		}
+		seasons = append(seasons, page...)
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = result.LastEvaluatedKey
+	}
+	return seasons, nil
+}
+
+// GetTeamIdBySeasonId returns the team a season belongs to, or an empty string if the season does not exist.
+// A season never moves between teams, so the answer is remembered for the rest of the request.
 func GetTeamIdBySeasonId(ctx context.Context, seasonId string) (string, error) {
+	return Remember(ctx, "seasonTeam#"+seasonId, func() (string, error) {
+		return getTeamIdBySeasonId(ctx, seasonId)
+	})
+}
+
+func getTeamIdBySeasonId(ctx context.Context, seasonId string) (string, error) {
 	client = GetClient()
 	result, err := client.GetItem(ctx, &dynamodb.GetItemInput{
 		TableName: &seasonsTableName,
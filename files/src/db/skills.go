@@ -0,0 +1,160 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/fpgschiba/volleygoals/models"
+)
+
+// GetSkillCatalogue returns the skill catalogue of a team, or nil if the team has none.
+func GetSkillCatalogue(ctx context.Context, teamId string) (*models.SkillCatalogue, error) {
+	client = GetClient()
+	result, err := client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(skillCataloguesTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: teamId},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Item == nil {
+		return nil, nil
+	}
+	var catalogue models.SkillCatalogue
+	if err := attributevalue.UnmarshalMap(result.Item, &catalogue); err != nil {
+		return nil, err
+	}
+	return &catalogue, nil
+}
+
+// PutSkillCatalogue creates or replaces the skill catalogue of a team. CreatedAt must be set by the caller when
+// a catalogue is replaced.
+func PutSkillCatalogue(ctx context.Context, catalogue *models.SkillCatalogue) error {
+	client = GetClient()
+	now := time.Now()
+	if catalogue.CreatedAt.IsZero() {
+		catalogue.CreatedAt = now
+	}
+	catalogue.UpdatedAt = now
+	_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(skillCataloguesTableName),
+		Item:      catalogue.ToAttributeValues(),
+	})
+	return err
+}
+
+func CreateSkillAssessment(ctx context.Context, assessment *models.SkillAssessment) (*models.SkillAssessment, error) {
+	client = GetClient()
+	now := time.Now()
+	assessment.Id = models.GenerateID()
+	assessment.CreatedAt = now
+	assessment.UpdatedAt = now
+	_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(skillAssessmentsTableName),
+		Item:      assessment.ToAttributeValues(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return assessment, nil
+}
+
+func GetSkillAssessmentById(ctx context.Context, assessmentId string) (*models.SkillAssessment, error) {
+	client = GetClient()
+	result, err := client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(skillAssessmentsTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: assessmentId},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Item == nil {
+		return nil, nil
+	}
+	var assessment models.SkillAssessment
+	if err := attributevalue.UnmarshalMap(result.Item, &assessment); err != nil {
+		return nil, err
+	}
+	return &assessment, nil
+}
+
+func DeleteSkillAssessment(ctx context.Context, assessmentId string) error {
+	client = GetClient()
+	_, err := client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(skillAssessmentsTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: assessmentId},
+		},
+	})
+	return err
+}
+
+// ListSkillAssessmentsByTeamId returns every skill assessment of a team in no particular order.
+func ListSkillAssessmentsByTeamId(ctx context.Context, teamId string) ([]*models.SkillAssessment, error) {
+	client = GetClient()
+	assessments := make([]*models.SkillAssessment, 0)
+	var lastKey map[string]types.AttributeValue
+	for {
+		in := &dynamodb.QueryInput{
+			TableName:              aws.String(skillAssessmentsTableName),
+			IndexName:              aws.String("teamIdIndex"),
+			KeyConditionExpression: aws.String("teamId = :teamId"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":teamId": &types.AttributeValueMemberS{Value: teamId},
+			},
+		}
+		if lastKey != nil {
+			in.ExclusiveStartKey = lastKey
+		}
+		result, err := client.Query(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result.Items {
+			var assessment models.SkillAssessment
+			if err := attributevalue.UnmarshalMap(item, &assessment); err != nil {
+				return nil, err
+			}
+			assessments = append(assessments, &assessment)
+		}
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = result.LastEvaluatedKey
+	}
+	return assessments, nil
+}
+
+// DeleteSkillCatalogue removes the skill catalogue of a team.
+func DeleteSkillCatalogue(ctx context.Context, teamId string) error {
+	client = GetClient()
+	_, err := client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(skillCataloguesTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: teamId},
+		},
+	})
+	return err
+}
+
+// DeleteSkillAssessmentsByTeamId removes every skill assessment of a team.
+func DeleteSkillAssessmentsByTeamId(ctx context.Context, teamId string) error {
+	assessments, err := ListSkillAssessmentsByTeamId(ctx, teamId)
+	if err != nil {
+		return err
+	}
+	for _, a := range assessments {
+		if err := DeleteSkillAssessment(ctx, a.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/fpgschiba/volleygoals/models"
+)
+
+// CreateSubgroup stores a new subgroup. ID and timestamps are assigned here.
+func CreateSubgroup(ctx context.Context, subgroup *models.Subgroup) (*models.Subgroup, error) {
+	client = GetClient()
+	now := time.Now()
+	subgroup.Id = models.GenerateID()
+	subgroup.CreatedAt = now
+	subgroup.UpdatedAt = now
+	_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(subgroupsTableName),
+		Item:      subgroup.ToAttributeValues(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return subgroup, nil
+}
+
+func GetSubgroupById(ctx context.Context, subgroupId string) (*models.Subgroup, error) {
+	client = GetClient()
+	result, err := client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(subgroupsTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: subgroupId},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Item == nil {
+		return nil, nil
+	}
+	var subgroup models.Subgroup
+	if err := attributevalue.UnmarshalMap(result.Item, &subgroup); err != nil {
+		return nil, err
+	}
+	return &subgroup, nil
+}
+
+// UpdateSubgroup replaces a stored subgroup with the given one.
+func UpdateSubgroup(ctx context.Context, subgroup *models.Subgroup) error {
+	client = GetClient()
+	subgroup.UpdatedAt = time.Now()
+	_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(subgroupsTableName),
+		Item:      subgroup.ToAttributeValues(),
+	})
+	return err
+}
+
+func DeleteSubgroup(ctx context.Context, subgroupId string) error {
+	client = GetClient()
+	_, err := client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(subgroupsTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: subgroupId},
+		},
+	})
+	return err
+}
+
+// ListSubgroupsByTeamId returns every subgroup of a team in no particular order.
+func ListSubgroupsByTeamId(ctx context.Context, teamId string) ([]*models.Subgroup, error) {
+	client = GetClient()
+	subgroups := make([]*models.Subgroup, 0)
+	var lastKey map[string]types.AttributeValue
+	for {
+		in := &dynamodb.QueryInput{
+			TableName:              aws.String(subgroupsTableName),
+			IndexName:              aws.String("teamIdIndex"),
+			KeyConditionExpression: aws.String("teamId = :teamId"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":teamId": &types.AttributeValueMemberS{Value: teamId},
+			},
+		}
+		if lastKey != nil {
+			in.ExclusiveStartKey = lastKey
+		}
+		result, err := client.Query(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result.Items {
+			var subgroup models.Subgroup
+			if err := attributevalue.UnmarshalMap(item, &subgroup); err != nil {
+				return nil, err
+			}
+			subgroups = append(subgroups, &subgroup)
+		}
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = result.LastEvaluatedKey
+	}
+	return subgroups, nil
+}
+
+// UnassignGoalsFromSubgroup removes the subgroup from every goal of the team's seasons assigned to it.
+func UnassignGoalsFromSubgroup(ctx context.Context, teamId, subgroupId string) error {
+	seasons, err := GetAllSeasonsByTeamId(ctx, teamId)
+	if err != nil {
+		return err
+	}
+	none := ""
+	for _, season := range seasons {
+		goals, err := ListAllGoalsBySeasonId(ctx, season.Id)
+		if err != nil {
+			return err
+		}
+		for _, g := range goals {
+			if g.SubgroupId != subgroupId {
+				continue
+			}
+			if _, err := UpdateGoal(ctx, g.Id, nil, nil, nil, nil, nil, nil, nil, &none); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// DeleteSubgroupsByTeamId removes every subgroup of a team.
+func DeleteSubgroupsByTeamId(ctx context.Context, teamId string) error {
+	subgroups, err := ListSubgroupsByTeamId(ctx, teamId)
+	if err != nil {
+		return err
+	}
+	for _, s := range subgroups {
+		if err := DeleteSubgroup(ctx, s.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -2,8 +2,10 @@ package db
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -41,7 +43,6 @@ func GetTeamMemberByUserIDAndTeamID(ctx context.Context, userID string, teamID s
 		ExpressionAttributeValues: map[string]types.AttributeValue{
 			":activeStatus": &types.AttributeValueMemberS{Value: string(models.TeamMemberStatusActive)},
 		},
-		Limit: aws.Int32(1),
 	})
 	if err != nil {
 		return nil, err
@@ -57,6 +58,29 @@ func GetTeamMemberByUserIDAndTeamID(ctx context.Context, userID string, teamID s
 	return &teamMember, nil
 }
 
+// GetTeamMemberById returns a membership regardless of its status, or nil if it does not exist.
+func GetTeamMemberById(ctx context.Context, teamMemberId string) (*models.TeamMember, error) {
+	client = GetClient()
+	result, err := client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: &teamMembersTableName,
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: teamMemberId},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Item == nil {
+		return nil, nil
+	}
+	var teamMember models.TeamMember
+	err = attributevalue.UnmarshalMap(result.Item, &teamMember)
+	if err != nil {
+		return nil, err
+	}
+	return &teamMember, nil
+}
+
 func HasRoleOnTeam(ctx context.Context, userID string, teamID string, role models.TeamMemberRole) (bool, error) {
 	teamMember, err := GetTeamMemberByUserIDAndTeamID(ctx, userID, teamID)
 	if err != nil {
@@ -100,6 +124,41 @@ func GetMembershipsByUserID(ctx context.Context, userID string) ([]*models.TeamM
 	return teamMembers, nil
 }
 
+// ListMembershipHistoryByUserID returns every membership the user ever had, including removed, left and
+// transferred ones.
+func ListMembershipHistoryByUserID(ctx context.Context, userID string) ([]*models.TeamMember, error) {
+	client = GetClient()
+	memberships := make([]*models.TeamMember, 0)
+	var lastKey map[string]types.AttributeValue
+	for {
+		in := &dynamodb.QueryInput{
+			TableName:              &teamMembersTableName,
+			IndexName:              aws.String("userIdIndex"),
+			KeyConditionExpression: aws.String("userId = :userId"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":userId": &types.AttributeValueMemberS{Value: userID},
+			},
+		}
+		if lastKey != nil {
+			in.ExclusiveStartKey = lastKey
+		}
+		result, err := client.Query(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		var page []*models.TeamMember
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, page...)
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = result.LastEvaluatedKey
+	}
+	return memberships, nil
+}
+
 func DeleteTeamMembershipsByTeamID(ctx context.Context, teamID string) error {
 	memberships, err := GetMembershipsByTeamID(ctx, teamID)
 	if err != nil {
@@ -209,7 +268,9 @@ func IsUserMemberOfTeam(ctx context.Context, userID string, teamID string) (bool
 	return teamMember != nil, nil
 }
 
-func GetTeamAssignmentsByUserID(ctx context.Context, userID string) ([]*models.TeamAssignment, error) {
+// GetTeamAssignmentsByUserID returns the user's memberships together with their teams. Archived teams are left out
+// unless includeArchived is set.
+func GetTeamAssignmentsByUserID(ctx context.Context, userID string, includeArchived bool) ([]*models.TeamAssignment, error) {
 	teamMembers, err := GetMembershipsByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -224,6 +285,9 @@ func GetTeamAssignmentsByUserID(ctx context.Context, userID string) ([]*models.T
 			log.Printf("[WARN] GetTeamAssignmentsByUserID: team not found for teamId %s", tm.TeamId)
 			continue
 		}
+		if team.Status == models.TeamStatusInactive && !includeArchived {
+			continue
+		}
 		teamAssignment := &models.TeamAssignment{
 			Team:   *team,
 			Role:   tm.Role,
@@ -391,9 +455,14 @@ func AddTeamMember(ctx context.Context, teamId, userId string, role models.TeamM
 	return teamMember, nil
 }
 
-func UpdateTeamMember(ctx context.Context, teamMemberId string, role *models.TeamMemberRole, status *models.TeamMemberStatus) (*models.TeamMember, error) {
+// UpdateTeamMember updates the given fields of a membership. Empty positions, a jersey number of zero, an empty
+// dominant hand and empty trainer notes remove the field from the member's profile. With a keeperId the change is
+// only written while that membership is still an active admin or trainer, and fails with ErrLastAdminOrTrainer
+// otherwise.
+func UpdateTeamMember(ctx context.Context, teamMemberId string, keeperId string, role *models.TeamMemberRole, status *models.TeamMemberStatus, positions *[]models.PlayingPosition, jerseyNumber *int, dominantHand *models.DominantHand, trainerNotes *string) (*models.TeamMember, error) {
 	client = GetClient()
 	var updateExpressions []string
+	var removes []string
 	exprAttrValues := make(map[string]types.AttributeValue)
 	exprAttrNames := make(map[string]string)
 
@@ -409,11 +478,75 @@ func UpdateTeamMember(ctx context.Context, teamMemberId string, role *models.Tea
 		exprAttrNames["#status"] = "status"
 	}
 
+	if positions != nil && len(*positions) > 0 {
+		av, err := attributevalue.Marshal(*positions)
+		if err != nil {
+			return nil, err
+		}
+		updateExpressions = append(updateExpressions, "#positions = :positions")
+		exprAttrValues[":positions"] = av
+		exprAttrNames["#positions"] = "positions"
+	} else if positions != nil {
+		removes = append(removes, "#positions")
+		exprAttrNames["#positions"] = "positions"
+	}
+
+	if jerseyNumber != nil && *jerseyNumber > 0 {
+		updateExpressions = append(updateExpressions, "#jerseyNumber = :jerseyNumber")
+		exprAttrValues[":jerseyNumber"] = &types.AttributeValueMemberN{Value: strconv.Itoa(*jerseyNumber)}
+		exprAttrNames["#jerseyNumber"] = "jerseyNumber"
+	} else if jerseyNumber != nil {
+		removes = append(removes, "#jerseyNumber")
+		exprAttrNames["#jerseyNumber"] = "jerseyNumber"
+	}
+
+	if dominantHand != nil && *dominantHand != "" {
+		updateExpressions = append(updateExpressions, "#dominantHand = :dominantHand")
+		exprAttrValues[":dominantHand"] = &types.AttributeValueMemberS{Value: string(*dominantHand)}
+		exprAttrNames["#dominantHand"] = "dominantHand"
+	} else if dominantHand != nil {
+		removes = append(removes, "#dominantHand")
+		exprAttrNames["#dominantHand"] = "dominantHand"
+	}
+
+	if trainerNotes != nil && *trainerNotes != "" {
+		updateExpressions = append(updateExpressions, "#trainerNotes = :trainerNotes")
+		exprAttrValues[":trainerNotes"] = &types.AttributeValueMemberS{Value: *trainerNotes}
+		exprAttrNames["#trainerNotes"] = "trainerNotes"
+	} else if trainerNotes != nil {
+		removes = append(removes, "#trainerNotes")
+		exprAttrNames["#trainerNotes"] = "trainerNotes"
+	}
+
 	updateExpressions = append(updateExpressions, "#updatedAt = :updatedAt")
 	exprAttrValues[":updatedAt"] = &types.AttributeValueMemberS{Value: time.Now().Format(time.RFC3339)}
 	exprAttrNames["#updatedAt"] = "updatedAt"
 
 	updateExpr := "SET " + strings.Join(updateExpressions, ", ")
+	if len(removes) > 0 {
+		updateExpr += " REMOVE " + strings.Join(removes, ", ")
+	}
+
+	if keeperId != "" {
+		_, err := client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+			TransactItems: []types.TransactWriteItem{
+				{
+					Update: &types.Update{
+						TableName:                 &teamMembersTableName,
+						Key:                       map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: teamMemberId}},
+						UpdateExpression:          aws.String(updateExpr),
+						ExpressionAttributeValues: exprAttrValues,
+						ExpressionAttributeNames:  exprAttrNames,
+					},
+				},
+				keeperCheck(keeperId),
+			},
+		})
+		if err != nil {
+			return nil, keeperError(err)
+		}
+		return GetTeamMemberById(ctx, teamMemberId)
+	}
 
 	result, err := client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
 		TableName:                 &teamMembersTableName,
@@ -446,19 +579,85 @@ func GetUserRoleOnTeam(ctx context.Context, userID string, teamID string) (*mode
 }
 
 func HasOtherAdminOrTrainer(ctx context.Context, teamID string, excludingUserID string) (bool, error) {
-	teamMembers, err := GetMembershipsByTeamID(ctx, teamID)
+	other, err := OtherAdminOrTrainer(ctx, teamID, excludingUserID)
 	if err != nil {
 		return false, err
 	}
+	return other != nil, nil
+}
+
+// OtherAdminOrTrainer returns an active admin or trainer of the team other than the user, or nil if there is none.
+func OtherAdminOrTrainer(ctx context.Context, teamID string, excludingUserID string) (*models.TeamMember, error) {
+	teamMembers, err := GetMembershipsByTeamID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
 	for _, member := range teamMembers {
 		if member.UserId != excludingUserID && (member.Role == models.TeamMemberRoleAdmin || member.Role == models.TeamMemberRoleTrainer) && member.Status == models.TeamMemberStatusActive {
-			return true, nil
+			return member, nil
 		}
 	}
-	return false, nil
+	return nil, nil
+}
+
+// ErrLastAdminOrTrainer is returned when the membership that was to keep the team's admin or trainer role lost it
+// or ended before a change was written.
+var ErrLastAdminOrTrainer = errors.New("no other active admin or trainer left on the team")
+
+// keeperCheck checks in a transaction that the membership is still an active admin or trainer.
+func keeperCheck(keeperId string) types.TransactWriteItem {
+	return types.TransactWriteItem{
+		ConditionCheck: &types.ConditionCheck{
+			TableName: &teamMembersTableName,
+			Key: map[string]types.AttributeValue{
+				"id": &types.AttributeValueMemberS{Value: keeperId},
+			},
+			ConditionExpression: aws.String("#status = :active AND #role IN (:admin, :trainer)"),
+			ExpressionAttributeNames: map[string]string{
+				"#status": "status",
+				"#role":   "role",
+			},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":active":  &types.AttributeValueMemberS{Value: string(models.TeamMemberStatusActive)},
+				":admin":   &types.AttributeValueMemberS{Value: string(models.TeamMemberRoleAdmin)},
+				":trainer": &types.AttributeValueMemberS{Value: string(models.TeamMemberRoleTrainer)},
+			},
+		},
+	}
+}
+
+// keeperError turns a transaction canceled by the keeper check into ErrLastAdminOrTrainer. A transaction canceled
+// by a concurrent change of the keeper counts the same, so two admins demoting each other cannot both succeed.
+func keeperError(err error) error {
+	var canceled *types.TransactionCanceledException
+	if errors.As(err, &canceled) {
+		for _, reason := range canceled.CancellationReasons {
+			switch aws.ToString(reason.Code) {
+			case "ConditionalCheckFailed", "TransactionConflict":
+				return ErrLastAdminOrTrainer
+			}
+		}
+	}
+	return err
 }
 
-func LeaveTeam(ctx context.Context, teamID string, userID string) error {
+// IsLastAdminOrTrainer reports whether the membership is an active admin or trainer and no other active admin or
+// trainer is left on the team. Such a membership may not lose its role or end without orphaning the team.
+func IsLastAdminOrTrainer(ctx context.Context, member *models.TeamMember) (bool, error) {
+	if member.Status != models.TeamMemberStatusActive || !member.Role.IsAdminOrTrainer() {
+		return false, nil
+	}
+	hasOther, err := HasOtherAdminOrTrainer(ctx, member.TeamId, member.UserId)
+	if err != nil {
+		return false, err
+	}
+	return !hasOther, nil
+}
+
+// LeaveTeam ends the user's membership with status left. With a keeperId the membership only ends while that
+// membership is still an active admin or trainer, and fails with ErrLastAdminOrTrainer otherwise.
+func LeaveTeam(ctx context.Context, teamID string, userID string, keeperId string) error {
+	client = GetClient()
 	teamMember, err := GetTeamMemberByUserIDAndTeamID(ctx, userID, teamID)
 	if err != nil {
 		return err
@@ -469,22 +668,42 @@ func LeaveTeam(ctx context.Context, teamID string, userID string) error {
 	// Set status to left and update UpdatedAt and LeftAt
 	timeNow := time.Now()
 	updateExpr := "SET #status = :status, #updatedAt = :updatedAt, #leftAt = :leftAt"
+	key := map[string]types.AttributeValue{
+		"id": &types.AttributeValueMemberS{Value: teamMember.Id},
+	}
+	names := map[string]string{
+		"#status":    "status",
+		"#updatedAt": "updatedAt",
+		"#leftAt":    "leftAt",
+	}
+	values := map[string]types.AttributeValue{
+		":status":    &types.AttributeValueMemberS{Value: string(models.TeamMemberStatusLeft)},
+		":updatedAt": &types.AttributeValueMemberS{Value: timeNow.Format(time.RFC3339)},
+		":leftAt":    &types.AttributeValueMemberS{Value: timeNow.Format(time.RFC3339)},
+	}
+	if keeperId != "" {
+		_, err = client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+			TransactItems: []types.TransactWriteItem{
+				{
+					Update: &types.Update{
+						TableName:                 &teamMembersTableName,
+						Key:                       key,
+						UpdateExpression:          aws.String(updateExpr),
+						ExpressionAttributeNames:  names,
+						ExpressionAttributeValues: values,
+					},
+				},
+				keeperCheck(keeperId),
+			},
+		})
+		return keeperError(err)
+	}
 	_, err = client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
-		TableName: &teamMembersTableName,
-		Key: map[string]types.AttributeValue{
-			"id": &types.AttributeValueMemberS{Value: teamMember.Id},
-		},
-		UpdateExpression: aws.String(updateExpr),
-		ExpressionAttributeNames: map[string]string{
-			"#status":    "status",
-			"#updatedAt": "updatedAt",
-			"#leftAt":    "leftAt",
-		},
-		ExpressionAttributeValues: map[string]types.AttributeValue{
-			":status":    &types.AttributeValueMemberS{Value: string(models.TeamMemberStatusLeft)},
-			":updatedAt": &types.AttributeValueMemberS{Value: timeNow.Format(time.RFC3339)},
-			":leftAt":    &types.AttributeValueMemberS{Value: timeNow.Format(time.RFC3339)},
-		},
+		TableName:                 &teamMembersTableName,
+		Key:                       key,
+		UpdateExpression:          aws.String(updateExpr),
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
 	})
 	if err != nil {
 		return err
@@ -492,8 +711,91 @@ func LeaveTeam(ctx context.Context, teamID string, userID string) error {
 	return nil
 }
 
-func RemoveTeamMember(ctx context.Context, teamMemberId string) error {
+// ErrMembershipNotActive is returned when a membership to be ended is no longer active.
+var ErrMembershipNotActive = errors.New("membership is not active")
+
+// TransferTeamMember ends the active membership with status left and starts a membership of the same user in the
+// target team, in one transaction. Positions and dominant hand carry over; the jersey number and trainer notes
+// belong to the old team.
+func TransferTeamMember(ctx context.Context, member *models.TeamMember, targetTeamId string, role models.TeamMemberRole) (*models.TeamMember, error) {
+	client = GetClient()
+	timeNow := time.Now()
+	transferred := &models.TeamMember{
+		Id:                    models.GenerateID(),
+		TeamId:                targetTeamId,
+		UserId:                member.UserId,
+		Role:                  role,
+		Status:                models.TeamMemberStatusActive,
+		Positions:             member.Positions,
+		DominantHand:          member.DominantHand,
+		CreatedAt:             timeNow,
+		UpdatedAt:             timeNow,
+		JoinedAt:              &timeNow,
+		TransferredFromTeamId: member.TeamId,
+	}
+	item, err := attributevalue.MarshalMap(transferred)
+	if err != nil {
+		return nil, err
+	}
+	_, err = client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{
+				Update: &types.Update{
+					TableName: &teamMembersTableName,
+					Key: map[string]types.AttributeValue{
+						"id": &types.AttributeValueMemberS{Value: member.Id},
+					},
+					UpdateExpression:    aws.String("SET #status = :left, updatedAt = :now, leftAt = :now, transferredToTeamId = :targetTeamId"),
+					ConditionExpression: aws.String("#status = :active"),
+					ExpressionAttributeNames: map[string]string{
+						"#status": "status",
+					},
+					ExpressionAttributeValues: map[string]types.AttributeValue{
+						":left":         &types.AttributeValueMemberS{Value: string(models.TeamMemberStatusLeft)},
+						":active":       &types.AttributeValueMemberS{Value: string(models.TeamMemberStatusActive)},
+						":now":          &types.AttributeValueMemberS{Value: timeNow.Format(time.RFC3339)},
+						":targetTeamId": &types.AttributeValueMemberS{Value: targetTeamId},
+					},
+				},
+			},
+			{
+				Put: &types.Put{
+					TableName: &teamMembersTableName,
+					Item:      item,
+				},
+			},
+		},
+	})
+	if err != nil {
+		var canceled *types.TransactionCanceledException
+		if errors.As(err, &canceled) && len(canceled.CancellationReasons) > 0 && aws.ToString(canceled.CancellationReasons[0].Code) == "ConditionalCheckFailed" {
+			return nil, ErrMembershipNotActive
+		}
+		return nil, err
+	}
+	return transferred, nil
+}
+
+// RemoveTeamMember deletes the membership. With a keeperId it is only deleted while that membership is still an
+// active admin or trainer, and fails with ErrLastAdminOrTrainer otherwise.
+func RemoveTeamMember(ctx context.Context, teamMemberId string, keeperId string) error {
 	client = GetClient()
+	if keeperId != "" {
+		_, err := client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+			TransactItems: []types.TransactWriteItem{
+				{
+					Delete: &types.Delete{
+						TableName: &teamMembersTableName,
+						Key: map[string]types.AttributeValue{
+							"id": &types.AttributeValueMemberS{Value: teamMemberId},
+						},
+					},
+				},
+				keeperCheck(keeperId),
+			},
+		})
+		return keeperError(err)
+	}
 	_, err := client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
 		TableName: &teamMembersTableName,
 		Key: map[string]types.AttributeValue{
@@ -0,0 +1,192 @@
+package db
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/fpgschiba/volleygoals/models"
+)
+
+// CreateTeamRole stores a new custom team role. ID and timestamps are assigned here.
+func CreateTeamRole(ctx context.Context, role *models.TeamRole) (*models.TeamRole, error) {
+	client = GetClient()
+	now := time.Now()
+	role.Id = models.GenerateID()
+	role.BuiltIn = false
+	role.CreatedAt = &now
+	role.UpdatedAt = &now
+	_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(teamRolesTableName),
+		Item:      role.ToAttributeValues(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func GetTeamRoleById(ctx context.Context, roleId string) (*models.TeamRole, error) {
+	client = GetClient()
+	result, err := client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(teamRolesTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: roleId},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Item == nil {
+		return nil, nil
+	}
+	var role models.TeamRole
+	if err := attributevalue.UnmarshalMap(result.Item, &role); err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+// GetTeamRoleByName returns the team's custom role with the given name, or nil if the team has none.
+func GetTeamRoleByName(ctx context.Context, teamId string, name models.TeamMemberRole) (*models.TeamRole, error) {
+	roles, err := ListTeamRolesByTeamId(ctx, teamId)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range roles {
+		if r.Name == name {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+// UpdateTeamRole replaces a stored custom team role with the given one.
+func UpdateTeamRole(ctx context.Context, role *models.TeamRole) error {
+	client = GetClient()
+	now := time.Now()
+	role.UpdatedAt = &now
+	_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(teamRolesTableName),
+		Item:      role.ToAttributeValues(),
+	})
+	return err
+}
+
+func DeleteTeamRole(ctx context.Context, roleId string) error {
+	client = GetClient()
+	_, err := client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(teamRolesTableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: roleId},
+		},
+	})
+	return err
+}
+
+// DeleteTeamRolesByTeamId removes all custom roles of a team.
+func DeleteTeamRolesByTeamId(ctx context.Context, teamId string) error {
+	roles, err := ListTeamRolesByTeamId(ctx, teamId)
+	if err != nil {
+		return err
+	}
+	for _, r := range roles {
+		if err := DeleteTeamRole(ctx, r.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListTeamRolesByTeamId returns the custom roles of a team sorted by name. Built-in roles are not stored.
+func ListTeamRolesByTeamId(ctx context.Context, teamId string) ([]*models.TeamRole, error) {
+	client = GetClient()
+	roles := make([]*models.TeamRole, 0)
+	var lastKey map[string]types.AttributeValue
+	for {
+		in := &dynamodb.QueryInput{
+			TableName:              aws.String(teamRolesTableName),
+			IndexName:              aws.String("teamIdIndex"),
+			KeyConditionExpression: aws.String("teamId = :teamId"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":teamId": &types.AttributeValueMemberS{Value: teamId},
+			},
+		}
+		if lastKey != nil {
+			in.ExclusiveStartKey = lastKey
+		}
+		result, err := client.Query(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result.Items {
+			var role models.TeamRole
+			if err := attributevalue.UnmarshalMap(item, &role); err != nil {
+				return nil, err
+			}
+			roles = append(roles, &role)
+		}
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = result.LastEvaluatedKey
+	}
+	sort.Slice(roles, func(i, j int) bool {
+		return roles[i].Name < roles[j].Name
+	})
+	return roles, nil
+}
+
+// IsTeamRoleInUse reports whether an active or invited member of the team holds the role, or a pending invite
+// would grant it.
+func IsTeamRoleInUse(ctx context.Context, teamId string, name models.TeamMemberRole) (bool, error) {
+	client = GetClient()
+	queries := []struct {
+		table    string
+		statuses []string
+	}{
+		{teamMembersTableName, []string{string(models.TeamMemberStatusActive), string(models.TeamMemberStatusInvited)}},
+		{invitesTableName, []string{string(models.InviteStatusPending)}},
+	}
+	for _, q := range queries {
+		var lastKey map[string]types.AttributeValue
+		for {
+			in := &dynamodb.QueryInput{
+				TableName:              aws.String(q.table),
+				IndexName:              aws.String("teamIdIndex"),
+				KeyConditionExpression: aws.String("teamId = :teamId"),
+				FilterExpression:       aws.String("#role = :role AND #status IN (:s0, :s1)"),
+				ExpressionAttributeNames: map[string]string{
+					"#role":   "role",
+					"#status": "status",
+				},
+				ExpressionAttributeValues: map[string]types.AttributeValue{
+					":teamId": &types.AttributeValueMemberS{Value: teamId},
+					":role":   &types.AttributeValueMemberS{Value: string(name)},
+					":s0":     &types.AttributeValueMemberS{Value: q.statuses[0]},
+					":s1":     &types.AttributeValueMemberS{Value: q.statuses[len(q.statuses)-1]},
+				},
+				Select: types.SelectCount,
+			}
+			if lastKey != nil {
+				in.ExclusiveStartKey = lastKey
+			}
+			result, err := client.Query(ctx, in)
+			if err != nil {
+				return false, err
+			}
+			if result.Count > 0 {
+				return true, nil
+			}
+			if result.LastEvaluatedKey == nil {
+				break
+			}
+			lastKey = result.LastEvaluatedKey
+		}
+	}
+	return false, nil
+}
@@ -90,6 +90,40 @@ func ListTeams(ctx context.Context, filter TeamFilter) ([]*models.Team, int, *mo
 	return teams, len(teams), nextCursor, hasMore, nil
 }
 
+// ListTeamsByOrganizationId returns all teams owned by the organization.
+func ListTeamsByOrganizationId(ctx context.Context, organizationId string) ([]*models.Team, error) {
+	client = GetClient()
+	teams := make([]*models.Team, 0)
+	var lastKey map[string]types.AttributeValue
+	for {
+		in := &dynamodb.ScanInput{
+			TableName:        aws.String(teamsTableName),
+			FilterExpression: aws.String("organizationId = :organizationId"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":organizationId": &types.AttributeValueMemberS{Value: organizationId},
+			},
+		}
+		if lastKey != nil {
+			in.ExclusiveStartKey = lastKey
+		}
+		result, err := client.Scan(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		page, err := unmarshalTeams(result.Items)
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, page...)
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = result.LastEvaluatedKey
+	}
+	sortTeams(teams, "name", "asc")
+	return teams, nil
+}
+
 func unmarshalTeams(items []map[string]types.AttributeValue) ([]*models.Team, error) {
 	teams := make([]*models.Team, 0, len(items))
 	for _, it := range items {
@@ -140,7 +174,8 @@ func nextCursorFromLEK(lek map[string]types.AttributeValue) (*models.Cursor, boo
 	return c, true
 }
 
-func CreateTeam(ctx context.Context, name string) (*models.Team, error) {
+// CreateTeam stores a new team with default settings. organizationId may be empty for a team outside an organization.
+func CreateTeam(ctx context.Context, name string, organizationId string) (*models.Team, error) {
 	client = GetClient()
 	existingTeam, err := findTeamByName(ctx, name)
 	if err != nil {
@@ -150,11 +185,13 @@ func CreateTeam(ctx context.Context, name string) (*models.Team, error) {
 		return nil, errors.New("team already exists")
 	}
 	team := &models.Team{
-		Id:        models.GenerateID(),
-		Name:      name,
-		Status:    models.TeamStatusActive,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		Id:             models.GenerateID(),
+		Name:           name,
+		Status:         models.TeamStatusActive,
+		Timezone:       "UTC",
+		OrganizationId: organizationId,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
 	}
 	err = createTeamSettings(ctx, team.Id)
 	if err != nil {
@@ -246,18 +283,42 @@ func DeleteTeamByID(ctx context.Context, teamId string) error {
 			}
 		}
 
-		// 4. Delete the season itself
+		// 4. For each season, delete its calendar with attendance, match statistics and lineups, its questionnaire and
+		// its stats rollup
+		if err := DeleteAttendanceBySeasonId(ctx, season.Id); err != nil {
+			log.Printf("[WARN] DeleteTeamByID: failed to delete attendance for season %s: %v", season.Id, err)
+		}
+		if err := DeleteMatchStatsBySeasonId(ctx, season.Id); err != nil {
+			log.Printf("[WARN] DeleteTeamByID: failed to delete match stats for season %s: %v", season.Id, err)
+		}
+		if err := DeleteLineupsBySeasonId(ctx, season.Id); err != nil {
+			log.Printf("[WARN] DeleteTeamByID: failed to delete lineups for season %s: %v", season.Id, err)
+		}
+		if err := DeleteEventsBySeasonId(ctx, season.Id); err != nil {
+			log.Printf("[WARN] DeleteTeamByID: failed to delete events for season %s: %v", season.Id, err)
+		}
+		if err := DeleteQuestionnaireBySeasonId(ctx, season.Id); err != nil {
+			log.Printf("[WARN] DeleteTeamByID: failed to delete questionnaire for season %s: %v", season.Id, err)
+		}
+		InvalidateSeasonStats(ctx, season.Id)
+
+		// 5. Delete the season itself
 		if err := DeleteSeason(ctx, season.Id); err != nil {
 			log.Printf("[WARN] DeleteTeamByID: failed to delete season %s: %v", season.Id, err)
 		}
 	}
 
-	// 5. Delete all team members
+	// 6. Delete the media references of the team's goals, progress entries and comments
+	if err := DeleteMediaReferencesByTeamId(ctx, teamId); err != nil {
+		log.Printf("[WARN] DeleteTeamByID: failed to delete media references for team %s: %v", teamId, err)
+	}
+
+	// 7. Delete all team members
 	if err := DeleteTeamMembershipsByTeamID(ctx, teamId); err != nil {
 		log.Printf("[WARN] DeleteTeamByID: failed to delete team memberships for team %s: %v", teamId, err)
 	}
 
-	// 6. Delete all invites
+	// 8. Delete all invites
 	invites, _, _, _, err := GetInvitesByTeamId(ctx, teamId, TeamInviteFilter{FilterOptions: FilterOptions{Limit: 1000}})
 	if err != nil {
 		log.Printf("[WARN] DeleteTeamByID: failed to list invites for team %s: %v", teamId, err)
@@ -268,12 +329,37 @@ func DeleteTeamByID(ctx context.Context, teamId string) error {
 		}
 	}
 
-	// 7. Delete team settings
+	// 9. Delete team settings
 	if err := DeleteTeamSettingsByTeamID(ctx, teamId); err != nil {
 		log.Printf("[WARN] DeleteTeamByID: failed to delete team settings for team %s: %v", teamId, err)
 	}
 
-	// 8. Delete the team itself
+	// 10. Delete the team's custom roles
+	if err := DeleteTeamRolesByTeamId(ctx, teamId); err != nil {
+		log.Printf("[WARN] DeleteTeamByID: failed to delete roles for team %s: %v", teamId, err)
+	}
+
+	// 11. Delete the team's subgroups, handovers, drills, skill catalogue and assessments, and fitness tests and results
+	if err := DeleteSubgroupsByTeamId(ctx, teamId); err != nil {
+		log.Printf("[WARN] DeleteTeamByID: failed to delete subgroups for team %s: %v", teamId, err)
+	}
+	if err := DeleteHandoversByTeamId(ctx, teamId); err != nil {
+		log.Printf("[WARN] DeleteTeamByID: failed to delete handovers for team %s: %v", teamId, err)
+	}
+	if err := DeleteDrillsByTeamId(ctx, teamId); err != nil {
+		log.Printf("[WARN] DeleteTeamByID: failed to delete drills for team %s: %v", teamId, err)
+	}
+	if err := DeleteSkillAssessmentsByTeamId(ctx, teamId); err != nil {
+		log.Printf("[WARN] DeleteTeamByID: failed to delete skill assessments for team %s: %v", teamId, err)
+	}
+	if err := DeleteSkillCatalogue(ctx, teamId); err != nil {
+		log.Printf("[WARN] DeleteTeamByID: failed to delete skill catalogue for team %s: %v", teamId, err)
+	}
+	if err := DeleteFitnessTestsByTeamId(ctx, teamId); err != nil {
+		log.Printf("[WARN] DeleteTeamByID: failed to delete fitness tests for team %s: %v", teamId, err)
+	}
+
+	// 12. Delete the team itself
 	client = GetClient()
 	_, err = client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
 		TableName: aws.String(teamsTableName),
@@ -0,0 +1,187 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fpgschiba/volleygoals/models"
+)
+
+type Format string
+
+const (
+	FormatMarkdown Format = "markdown"
+	FormatPDF      Format = "pdf"
+)
+
+// ContentType returns the MIME type of an export in this format.
+func (f Format) ContentType() string {
+	if f == FormatPDF {
+		return "application/pdf"
+	}
+	return "text/markdown; charset=utf-8"
+}
+
+// Extension returns the file extension (including the dot) of an export in this format.
+func (f Format) Extension() string {
+	if f == FormatPDF {
+		return ".pdf"
+	}
+	return ".md"
+}
+
+// ParseFormat maps the format query parameter to a Format. An empty value defaults to PDF.
+func ParseFormat(value string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "pdf":
+		return FormatPDF, nil
+	case "markdown", "md":
+		return FormatMarkdown, nil
+	}
+	return "", fmt.Errorf("unsupported format %q", value)
+}
+
+// Document is a printable collection of progress reports.
+type Document struct {
+	Title    string
+	Subtitle string
+	Reports  []Report
+}
+
+// Report is a progress report with everything needed to print it.
+type Report struct {
+	Report   *models.ProgressReport
+	Entries  []Entry
+	Answers  []Answer
+	Comments []*models.Comment
+}
+
+// Entry is a goal rating within a report, with the rated goal's title and its trainer comments.
+type Entry struct {
+	Progress  *models.Progress
+	GoalTitle string
+	Comments  []*models.Comment
+}
+
+// Answer is a questionnaire answer resolved to its question prompt.
+type Answer struct {
+	Prompt string
+	Value  string
+}
+
+type blockKind int
+
+const (
+	blockTitle blockKind = iota
+	blockHeading
+	blockSubheading
+	blockParagraph
+	blockBullet
+	blockQuote
+)
+
+type block struct {
+	kind blockKind
+	text string
+}
+
+// Render renders the document in the requested format.
+func Render(doc *Document, format Format) ([]byte, error) {
+	switch format {
+	case FormatMarkdown:
+		return renderMarkdown(doc.blocks()), nil
+	case FormatPDF:
+		return renderPDF(doc.Title, doc.blocks())
+	}
+	return nil, fmt.Errorf("unsupported format %q", format)
+}
+
+// blocks flattens the document into a format-independent sequence of text blocks.
+func (d *Document) blocks() []block {
+	blocks := []block{{blockTitle, d.Title}}
+	if d.Subtitle != "" {
+		blocks = append(blocks, block{blockParagraph, d.Subtitle})
+	}
+	if len(d.Reports) == 0 {
+		blocks = append(blocks, block{blockParagraph, "No progress reports."})
+	}
+
+	for _, r := range d.Reports {
+		heading := r.Report.CreatedAt.Format("2006-01-02")
+		if r.Report.AuthorName != nil && *r.Report.AuthorName != "" {
+			heading = fmt.Sprintf("%s — %s", heading, *r.Report.AuthorName)
+		}
+		blocks = append(blocks, block{blockHeading, heading})
+		if r.Report.Summary != "" {
+			blocks = append(blocks, block{blockParagraph, r.Report.Summary})
+		}
+		if r.Report.Details != "" {
+			blocks = append(blocks, block{blockParagraph, r.Report.Details})
+		}
+		if r.Report.OverallDetails != "" {
+			blocks = append(blocks, block{blockSubheading, "Overall"}, block{blockParagraph, r.Report.OverallDetails})
+		}
+
+		if len(r.Entries) > 0 {
+			blocks = append(blocks, block{blockSubheading, "Goals"})
+			for _, e := range r.Entries {
+				line := fmt.Sprintf("%s — rating %d", e.GoalTitle, e.Progress.Rating)
+				if e.Progress.Details != "" {
+					line = fmt.Sprintf("%s: %s", line, e.Progress.Details)
+				}
+				blocks = append(blocks, block{blockBullet, line})
+				for _, c := range e.Comments {
+					blocks = append(blocks, block{blockQuote, commentLine(c)})
+				}
+			}
+		}
+
+		if len(r.Answers) > 0 {
+			blocks = append(blocks, block{blockSubheading, "Questionnaire"})
+			for _, a := range r.Answers {
+				blocks = append(blocks, block{blockBullet, fmt.Sprintf("%s: %s", a.Prompt, a.Value)})
+			}
+		}
+
+		if len(r.Comments) > 0 {
+			blocks = append(blocks, block{blockSubheading, "Trainer comments"})
+			for _, c := range r.Comments {
+				blocks = append(blocks, block{blockQuote, commentLine(c)})
+			}
+		}
+	}
+	return blocks
+}
+
+func commentLine(c *models.Comment) string {
+	author := c.AuthorId
+	if c.AuthorName != nil && *c.AuthorName != "" {
+		author = *c.AuthorName
+	}
+	return fmt.Sprintf("%s (%s): %s", author, c.CreatedAt.Format("2006-01-02"), c.Content)
+}
+
+func renderMarkdown(blocks []block) []byte {
+	var sb strings.Builder
+	for i, b := range blocks {
+		if i > 0 && b.kind != blockBullet && b.kind != blockQuote {
+			sb.WriteString("\n")
+		}
+		text := strings.TrimSpace(b.text)
+		switch b.kind {
+		case blockTitle:
+			sb.WriteString("# " + text + "\n")
+		case blockHeading:
+			sb.WriteString("## " + text + "\n")
+		case blockSubheading:
+			sb.WriteString("### " + text + "\n")
+		case blockParagraph:
+			sb.WriteString(text + "\n")
+		case blockBullet:
+			sb.WriteString("- " + strings.ReplaceAll(text, "\n", "\n  ") + "\n")
+		case blockQuote:
+			sb.WriteString("  > " + strings.ReplaceAll(text, "\n", "\n  > ") + "\n")
+		}
+	}
+	return []byte(sb.String())
+}
@@ -0,0 +1,219 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A minimal PDF 1.4 writer: A4 pages, the standard Helvetica fonts with WinAnsi encoding
+// and word-wrapped text. This is enough for printable reports without pulling in a PDF library.
+
+const (
+	pageWidth    = 595.0
+	pageHeight   = 842.0
+	pageMargin   = 56.0
+	lineSpacing  = 1.35
+	bulletIndent = 14.0
+	quoteIndent  = 28.0
+)
+
+type pdfStyle struct {
+	font      string // resource name, F1 = Helvetica, F2 = Helvetica-Bold
+	size      float64
+	indent    float64
+	spaceOver float64
+}
+
+var pdfStyles = map[blockKind]pdfStyle{
+	blockTitle:      {"F2", 18, 0, 0},
+	blockHeading:    {"F2", 14, 0, 14},
+	blockSubheading: {"F2", 11.5, 0, 8},
+	blockParagraph:  {"F1", 10.5, 0, 4},
+	blockBullet:     {"F1", 10.5, bulletIndent, 2},
+	blockQuote:      {"F1", 9.5, quoteIndent, 1},
+}
+
+type pdfLine struct {
+	font string
+	size float64
+	x, y float64
+	text []byte
+}
+
+func renderPDF(title string, blocks []block) ([]byte, error) {
+	pages := layoutPages(blocks)
+
+	var buf bytes.Buffer
+	offsets := make([]int, 0)
+	writeObj := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-5 are fixed; each page then adds a page object followed by its content stream.
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+2*i)
+	}
+	writeObj("<< /Type /Catalog /Pages 2 0 R >>")
+	writeObj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	writeObj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	writeObj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	writeObj(fmt.Sprintf("<< /Title (%s) /Producer (VolleyGoals) >>", encodeWinAnsi(title)))
+
+	for i, lines := range pages {
+		var content bytes.Buffer
+		for _, l := range lines {
+			fmt.Fprintf(&content, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", l.font, l.size, l.x, l.y, l.text)
+		}
+		footer := encodeWinAnsi(fmt.Sprintf("%d / %d", i+1, len(pages)))
+		fmt.Fprintf(&content, "BT /F1 8.0 Tf %.2f %.2f Td (%s) Tj ET\n", pageWidth-pageMargin-textWidth(string(footer), "F1", 8), pageMargin/2, footer)
+
+		writeObj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, len(offsets)+2))
+		writeObj(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return buf.Bytes(), nil
+}
+
+// layoutPages wraps every block to the printable width and distributes the lines over pages.
+func layoutPages(blocks []block) [][]pdfLine {
+	pages := [][]pdfLine{{}}
+	y := pageHeight - pageMargin
+	for _, b := range blocks {
+		style := pdfStyles[b.kind]
+		lineHeight := style.size * lineSpacing
+		y -= style.spaceOver
+
+		text := b.text
+		if b.kind == blockBullet {
+			text = "• " + text
+		}
+		for _, line := range wrapText(text, style.font, style.size, pageWidth-2*pageMargin-style.indent) {
+			if y-lineHeight < pageMargin {
+				pages = append(pages, []pdfLine{})
+				y = pageHeight - pageMargin
+			}
+			y -= lineHeight
+			pages[len(pages)-1] = append(pages[len(pages)-1], pdfLine{
+				font: style.font,
+				size: style.size,
+				x:    pageMargin + style.indent,
+				y:    y,
+				text: encodeWinAnsi(line),
+			})
+		}
+	}
+	return pages
+}
+
+// wrapText splits text into lines no wider than maxWidth. Explicit newlines are kept.
+func wrapText(text, font string, size, maxWidth float64) []string {
+	lines := make([]string, 0)
+	for _, paragraph := range strings.Split(text, "\n") {
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+		current := ""
+		for _, w := range words {
+			candidate := w
+			if current != "" {
+				candidate = current + " " + w
+			}
+			if current != "" && textWidth(candidate, font, size) > maxWidth {
+				lines = append(lines, current)
+				candidate = w
+			}
+			// Hard-break words that do not fit on a line of their own.
+			for textWidth(candidate, font, size) > maxWidth && len([]rune(candidate)) > 1 {
+				runes := []rune(candidate)
+				cut := len(runes) - 1
+				for cut > 1 && textWidth(string(runes[:cut]), font, size) > maxWidth {
+					cut--
+				}
+				lines = append(lines, string(runes[:cut]))
+				candidate = string(runes[cut:])
+			}
+			current = candidate
+		}
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+func textWidth(text, font string, size float64) float64 {
+	widths := helveticaWidths
+	if font == "F2" {
+		widths = helveticaBoldWidths
+	}
+	total := 0
+	for _, r := range text {
+		if r >= 32 && r <= 126 {
+			total += widths[r-32]
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// encodeWinAnsi converts text to a WinAnsi byte string and escapes it for use inside a PDF string literal.
+// Characters the standard fonts cannot show are replaced with '?'.
+func encodeWinAnsi(text string) []byte {
+	out := make([]byte, 0, len(text))
+	for _, r := range text {
+		var c byte
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			out = append(out, '\\', byte(r))
+			continue
+		case r == '\t':
+			c = ' '
+		case r >= 32 && r <= 126, r >= 0xA0 && r <= 0xFF:
+			c = byte(r)
+		default:
+			var ok bool
+			if c, ok = winAnsiSpecials[r]; !ok {
+				c = '?'
+			}
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+var winAnsiSpecials = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// Glyph widths (1/1000 em) for ASCII 32-126 from the standard Helvetica AFM metrics.
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
@@ -4,6 +4,7 @@ package main
 
 import (
 	"context"
+	"encoding/base64"
 	"io"
 	"net/http"
 	"os"
@@ -155,7 +156,17 @@ func Adapter(handlerName string) gin.HandlerFunc {
 			contentType = "application/json"
 		}
 
-		c.Data(resp.StatusCode, contentType, []byte(resp.Body))
+		body := []byte(resp.Body)
+		if resp.IsBase64Encoded {
+			decoded, err := base64.StdEncoding.DecodeString(resp.Body)
+			if err != nil {
+				c.String(http.StatusInternalServerError, "failed to decode response body: %v", err)
+				return
+			}
+			body = decoded
+		}
+
+		c.Data(resp.StatusCode, contentType, body)
 	}
 }
 
@@ -191,6 +202,7 @@ func GetRouter() *gin.Engine {
 	engine.POST("/api/v1/invites/complete", Adapter("CompleteInvite"))
 	engine.POST("/health", Adapter("HealthCheck"))
 
+	// Access rules are enforced by router/policy; the comments below summarize them.
 	apiGroup := engine.Group("/api/v1")
 	{
 		// Apply auth for subsequent routes
@@ -202,28 +214,88 @@ func GetRouter() *gin.Engine {
 			selfGroup.PATCH("", Adapter("UpdateSelf"))
 			selfGroup.GET("/picture/presign", Adapter("UploadSelfPicture"))
 		}
+		organizationsGroup := apiGroup.Group("/organizations")
+		{
+			organizationsGroup.POST("", Adapter("CreateOrganization")) // Admin only
+			organizationsGroup.GET("", Adapter("ListOrganizations"))   // Admin, or User for own organizations
+			organizationGroup := organizationsGroup.Group(":organizationId")
+			{
+				organizationGroup.GET("", Adapter("GetOrganization"))                               // Admin or Organization member
+				organizationGroup.PATCH("", Adapter("UpdateOrganization"))                          // Admin or Organization owner/admin
+				organizationGroup.DELETE("", Adapter("DeleteOrganization"))                         // Admin only
+				organizationGroup.GET("/members", Adapter("ListOrganizationMembers"))               // Admin or Organization member
+				organizationGroup.POST("/members", Adapter("AddOrganizationMember"))                // Admin or Organization owner/admin
+				organizationGroup.PATCH("/members/:memberId", Adapter("UpdateOrganizationMember"))  // Admin or Organization owner/admin
+				organizationGroup.DELETE("/members/:memberId", Adapter("RemoveOrganizationMember")) // Admin or Organization owner/admin
+			}
+		}
 		teamsGroup := apiGroup.Group("/teams")
 		{
-			teamsGroup.POST("", Adapter("CreateTeam")) // Admin only
-			teamsGroup.GET("", Adapter("ListTeams"))   // Admin only
+			teamsGroup.POST("", Adapter("CreateTeam")) // Admin or Organization owner/admin
+			teamsGroup.GET("", Adapter("ListTeams"))   // Admin, or Organization members for their organizations' teams
 			teamGroup := teamsGroup.Group(":teamId")   // Admin or User for specific Team
 			{
-				teamGroup.DELETE("", Adapter("DeleteTeam"))                     // Admin only
+				teamGroup.DELETE("", Adapter("DeleteTeam"))                     // Admin or Organization owner/admin
 				teamGroup.GET("", Adapter("GetTeam"))                           // Admin or User for Team
-				teamGroup.PATCH("/settings", Adapter("UpdateTeamSettings"))     // Admin or User with Role Trainer on Team
+				teamGroup.PATCH("/settings", Adapter("UpdateTeamSettings"))     // Admin only
 				teamGroup.GET("/invites", Adapter("GetTeamInvites"))            // Admin or User with Role Trainer on Team
 				teamGroup.GET("/picture/presign", Adapter("UploadTeamPicture")) // Admin or User with Role Trainer on Team
-				teamGroup.PATCH("", Adapter("UpdateTeam"))                      // Admin or User with Role Trainer on Team
+				teamGroup.PATCH("", Adapter("UpdateTeam"))                      // Admin or Organization owner/admin
 				membersGroup := teamGroup.Group("/members")
 				{
-					membersGroup.POST("", Adapter("AddTeamMember"))               // Admin only
-					membersGroup.GET("", Adapter("ListTeamMembers"))              // Admin or User for Team
-					membersGroup.DELETE("", Adapter("LeaveTeam"))                 // User only and Trainers only if another Trainer exists
-					membersGroup.DELETE(":memberId", Adapter("RemoveTeamMember")) // Admin and User with Role Trainer on Team
-					membersGroup.PATCH(":memberId", Adapter("UpdateTeamMember"))  // Admin and User with Role Trainer on Team
+					membersGroup.POST("", Adapter("AddTeamMember"))                            // Admin and User with Role Trainer on Team
+					membersGroup.GET("", Adapter("ListTeamMembers"))                           // Admin or User for Team
+					membersGroup.DELETE("", Adapter("LeaveTeam"))                              // Members only and Trainers only if another Trainer exists
+					membersGroup.DELETE(":memberId", Adapter("RemoveTeamMember"))              // Admin and User with Role Trainer on Team
+					membersGroup.PATCH(":memberId", Adapter("UpdateTeamMember"))               // Admin and User with Role Trainer on Team
+					membersGroup.POST(":memberId/transfer", Adapter("TransferTeamMember"))     // Admin and User with Role Trainer on both Teams
+					membersGroup.GET(":memberId/development", Adapter("GetMemberDevelopment")) // Admin, Trainer on Team or the Member themselves
+					membersGroup.GET(":memberId/skills", Adapter("GetMemberSkillRadar"))       // Admin, Trainer on Team or the Member themselves
+					membersGroup.GET(":memberId/fitness", Adapter("GetMemberPersonalBests"))   // All team members
+				}
+				rolesGroup := teamGroup.Group("/roles")
+				{
+					rolesGroup.GET("", Adapter("ListTeamRoles"))            // All team members
+					rolesGroup.POST("", Adapter("CreateTeamRole"))          // Admin or roles.manage on Team
+					rolesGroup.PATCH(":roleId", Adapter("UpdateTeamRole"))  // Admin or roles.manage on Team
+					rolesGroup.DELETE(":roleId", Adapter("DeleteTeamRole")) // Admin or roles.manage on Team
+				}
+				handoversGroup := teamGroup.Group("/handovers")
+				{
+					handoversGroup.POST("", Adapter("CreateHandover"))                       // Active admin or trainer on Team
+					handoversGroup.GET("", Adapter("ListHandovers"))                         // All team members
+					handoversGroup.POST(":handoverId/respond", Adapter("RespondToHandover")) // Nominated successor only
+					handoversGroup.DELETE(":handoverId", Adapter("CancelHandover"))          // Nominator or members.manage on Team
 				}
 				teamGroup.GET("/activity", Adapter("GetTeamActivity")) // All team members
 
+				teamGroup.GET("/skills", Adapter("GetSkillCatalogue"))                                 // All team members
+				teamGroup.PUT("/skills", Adapter("UpdateSkillCatalogue"))                              // Admin or User with Role Trainer on Team
+				teamGroup.GET("/skills/matrix", Adapter("GetSkillMatrix"))                             // All team members, members only see their own row
+				teamGroup.POST("/skill-assessments", Adapter("CreateSkillAssessment"))                 // Admin or User with Role Trainer on Team
+				teamGroup.GET("/skill-assessments", Adapter("ListSkillAssessments"))                   // All team members, members only see their own
+				teamGroup.DELETE("/skill-assessments/:assessmentId", Adapter("DeleteSkillAssessment")) // Admin or User with Role Trainer on Team
+
+				teamGroup.POST("/drills", Adapter("CreateDrill"))                            // Admin or User with Role Trainer on Team
+				teamGroup.GET("/drills", Adapter("ListDrills"))                              // All team members
+				teamGroup.GET("/drills/:drillId", Adapter("GetDrill"))                       // All team members
+				teamGroup.PATCH("/drills/:drillId", Adapter("UpdateDrill"))                  // Admin or User with Role Trainer on Team
+				teamGroup.DELETE("/drills/:drillId", Adapter("DeleteDrill"))                 // Admin or User with Role Trainer on Team
+				teamGroup.GET("/drills/:drillId/media/presign", Adapter("UploadDrillMedia")) // Admin or User with Role Trainer on Team
+				teamGroup.POST("/subgroups", Adapter("CreateSubgroup"))                      // Admin or User with Role Trainer on Team
+				teamGroup.GET("/subgroups", Adapter("ListSubgroups"))                        // All team members
+				teamGroup.GET("/subgroups/:subgroupId", Adapter("GetSubgroup"))              // All team members
+				teamGroup.PATCH("/subgroups/:subgroupId", Adapter("UpdateSubgroup"))         // Admin, User with Role Trainer on Team or trainer of the subgroup
+				teamGroup.DELETE("/subgroups/:subgroupId", Adapter("DeleteSubgroup"))        // Admin or User with Role Trainer on Team
+
+				teamGroup.POST("/fitness-tests", Adapter("CreateFitnessTest"))                               // Admin or User with Role Trainer on Team
+				teamGroup.GET("/fitness-tests", Adapter("ListFitnessTests"))                                 // All team members
+				teamGroup.PATCH("/fitness-tests/:testId", Adapter("UpdateFitnessTest"))                      // Admin or User with Role Trainer on Team
+				teamGroup.DELETE("/fitness-tests/:testId", Adapter("DeleteFitnessTest"))                     // Admin or User with Role Trainer on Team
+				teamGroup.POST("/fitness-tests/:testId/results", Adapter("CreateFitnessResult"))             // Admin or User with Role Trainer on Team
+				teamGroup.GET("/fitness-tests/:testId/results", Adapter("ListFitnessResults"))               // All team members
+				teamGroup.DELETE("/fitness-tests/:testId/results/:resultId", Adapter("DeleteFitnessResult")) // Admin or User with Role Trainer on Team
+				teamGroup.GET("/fitness-tests/:testId/leaderboard", Adapter("GetFitnessLeaderboard"))        // All team members
 			}
 		}
 		invitesGroup := apiGroup.Group("/invites") // Admin or User with Role Trainer on Team
@@ -232,6 +304,7 @@ func GetRouter() *gin.Engine {
 			invitesGroup.DELETE(":inviteId", Adapter("RevokeInvite"))
 			invitesGroup.PATCH(":inviteId", Adapter("ResendInvite"))
 			invitesGroup.GET(":inviteToken", Adapter("GetInviteByToken"))
+			invitesGroup.POST(":inviteId/consent", Adapter("GiveGuardianConsent")) // Guardian of the invited minor
 		}
 		usersGroup := apiGroup.Group("/users") // Admin only
 		{
@@ -239,6 +312,15 @@ func GetRouter() *gin.Engine {
 			usersGroup.GET(":userSub", Adapter("GetUser"))
 			usersGroup.DELETE(":userSub", Adapter("DeleteUser"))
 			usersGroup.PATCH(":userSub", Adapter("UpdateUser"))
+			usersGroup.GET(":userSub/memberships", Adapter("ListUserMemberships"))                 // Admin or Organization owner/admin
+			usersGroup.POST(":userSub/guardians", Adapter("CreateGuardianship"))                   // Admin or members.manage on a team of the player
+			usersGroup.GET(":userSub/guardians", Adapter("ListGuardians"))                         // Admin, the player, their guardians or members.view on a team of the player
+			usersGroup.DELETE(":userSub/guardians/:guardianshipId", Adapter("DeleteGuardianship")) // Admin, the guardian or members.manage on a team of the player
+		}
+		wardsGroup := apiGroup.Group("/wards") // Guardians
+		{
+			wardsGroup.GET("", Adapter("ListWards"))
+			wardsGroup.GET(":playerId/overview", Adapter("GetWardOverview"))
 		}
 		seasonsGroup := apiGroup.Group("/seasons") // Admin or User with Role Trainer on Team
 		{
@@ -250,22 +332,56 @@ func GetRouter() *gin.Engine {
 				seasonGroup.PATCH("", Adapter("UpdateSeason"))
 				seasonGroup.DELETE("", Adapter("DeleteSeason"))
 				seasonGroup.GET("/stats", Adapter("GetSeasonStats"))
+				seasonGroup.POST("/clone", Adapter("CloneSeason"))
+				seasonGroup.GET("/questionnaire", Adapter("GetSeasonQuestionnaire"))       // All team members
+				seasonGroup.PUT("/questionnaire", Adapter("UpdateSeasonQuestionnaire"))    // Admin or User with Role Trainer on Team
+				seasonGroup.DELETE("/questionnaire", Adapter("DeleteSeasonQuestionnaire")) // Admin or User with Role Trainer on Team
+				seasonGroup.GET("/attendance", Adapter("ListSeasonAttendance"))            // All team members, members only see their own records
+				seasonGroup.GET("/match-stats", Adapter("GetSeasonMatchStats"))            // All team members
+				seasonGroup.GET("/match-stats/:userId", Adapter("GetPlayerMatchStats"))    // All team members
+				seasonGroup.GET("/lineups", Adapter("GetSeasonLineupHistory"))             // All team members
+				seasonGroup.GET("/lineups/:userId", Adapter("GetPlayerLineupHistory"))     // All team members
 				goalsGroup := seasonGroup.Group("/goals")
 				{
-					goalsGroup.POST("", Adapter("CreateGoal")) // Admin or User with Role Trainer on Team
+					goalsGroup.POST("", Adapter("CreateGoal")) // All team members, team goals need goals.create
 					goalsGroup.GET(":goalId", Adapter("GetGoal"))
 					goalsGroup.GET("", Adapter("ListGoals"))
-					goalsGroup.PATCH(":goalId", Adapter("UpdateGoal"))  // Admin or User with Role Trainer on Team
-					goalsGroup.DELETE(":goalId", Adapter("DeleteGoal")) // Admin or User with Role Trainer on Team
+					goalsGroup.PATCH(":goalId", Adapter("UpdateGoal"))  // Goal owner, Admin or User with Role Trainer on Team
+					goalsGroup.DELETE(":goalId", Adapter("DeleteGoal")) // Goal owner, Admin or User with Role Trainer on Team
 					goalsGroup.GET(":goalId/picture/presign", Adapter("UploadGoalFile"))
+					goalsGroup.GET(":goalId/suggested-drills", Adapter("SuggestDrillsForGoal"))  // All team members
+					goalsGroup.POST(":goalId/media", Adapter("CreateMediaReference"))            // Goal owner, Admin or User with Role Trainer on Team
+					goalsGroup.PATCH(":goalId/media/:mediaId", Adapter("UpdateMediaReference"))  // Creator, Admin or User with Role Trainer on Team
+					goalsGroup.DELETE(":goalId/media/:mediaId", Adapter("DeleteMediaReference")) // Creator, Admin or User with Role Trainer on Team
+				}
+				eventsGroup := seasonGroup.Group("/events")
+				{
+					eventsGroup.POST("", Adapter("CreateEvent")) // Admin or User with Role Trainer on Team
+					eventsGroup.GET("", Adapter("ListEvents"))   // All team members
+					eventsGroup.GET(":eventId", Adapter("GetEvent"))
+					eventsGroup.PATCH(":eventId", Adapter("UpdateEvent"))                    // Admin or User with Role Trainer on Team
+					eventsGroup.DELETE(":eventId", Adapter("DeleteEvent"))                   // Admin or User with Role Trainer on Team
+					eventsGroup.GET(":eventId/attendance", Adapter("GetEventAttendance"))    // All team members, members only see their own record
+					eventsGroup.PUT(":eventId/attendance", Adapter("UpdateEventAttendance")) // Admin or User with Role Trainer on Team
+					eventsGroup.GET(":eventId/match-stats", Adapter("GetMatchStats"))        // All team members
+					eventsGroup.PUT(":eventId/match-stats", Adapter("UpdateMatchStats"))     // Admin or User with Role Trainer on Team
+					eventsGroup.DELETE(":eventId/match-stats", Adapter("DeleteMatchStats"))  // Admin or User with Role Trainer on Team
+					eventsGroup.GET(":eventId/lineup", Adapter("GetLineup"))                 // All team members
+					eventsGroup.PUT(":eventId/lineup", Adapter("UpdateLineup"))              // Admin or User with Role Trainer on Team
+					eventsGroup.DELETE(":eventId/lineup", Adapter("DeleteLineup"))           // Admin or User with Role Trainer on Team
 				}
 				progressReportGroup := seasonGroup.Group("/progress-reports")
 				{
-					progressReportGroup.POST("", Adapter("CreateProgressReport")) // Admin or User with Role Trainer on Team
+					progressReportGroup.POST("", Adapter("CreateProgressReport")) // All team members
 					progressReportGroup.GET(":reportId", Adapter("GetProgressReport"))
 					progressReportGroup.GET("", Adapter("ListProgressReports"))
-					progressReportGroup.PATCH(":reportId", Adapter("UpdateProgressReport"))  // Admin or User with Role Trainer on Team
-					progressReportGroup.DELETE(":reportId", Adapter("DeleteProgressReport")) // Admin or User with Role Trainer on Team
+					progressReportGroup.PATCH(":reportId", Adapter("UpdateProgressReport"))                                      // Report author, Admin or User with Role Trainer on Team
+					progressReportGroup.DELETE(":reportId", Adapter("DeleteProgressReport"))                                     // Report author, Admin or User with Role Trainer on Team
+					progressReportGroup.GET("export", Adapter("ExportMemberProgressReports"))                                    // All team members
+					progressReportGroup.GET(":reportId/export", Adapter("ExportProgressReport"))                                 // All team members
+					progressReportGroup.POST(":reportId/progress/:progressId/media", Adapter("CreateMediaReference"))            // Report author, Admin or User with Role Trainer on Team
+					progressReportGroup.PATCH(":reportId/progress/:progressId/media/:mediaId", Adapter("UpdateMediaReference"))  // Creator, Admin or User with Role Trainer on Team
+					progressReportGroup.DELETE(":reportId/progress/:progressId/media/:mediaId", Adapter("DeleteMediaReference")) // Creator, Admin or User with Role Trainer on Team
 				}
 			}
 		}
@@ -276,9 +392,12 @@ func GetRouter() *gin.Engine {
 			commentsGroup.POST("", Adapter("CreateComment"))
 			commentsGroup.GET(":commentId", Adapter("GetComment"))
 			commentsGroup.GET("", Adapter("ListComments"))
-			commentsGroup.PATCH(":commentId", Adapter("UpdateComment"))  // Admin or User with Role Trainer on Team
-			commentsGroup.DELETE(":commentId", Adapter("DeleteComment")) // Admin or User with Role Trainer on Team
+			commentsGroup.PATCH(":commentId", Adapter("UpdateComment"))  // Comment author, Admin or User with Role Trainer on Team
+			commentsGroup.DELETE(":commentId", Adapter("DeleteComment")) // Comment author, Admin or User with Role Trainer on Team
 			commentsGroup.GET(":commentId/file/presign", Adapter("UploadCommentFile"))
+			commentsGroup.POST(":commentId/media", Adapter("CreateMediaReference"))            // Comment author, Admin or User with Role Trainer on Team
+			commentsGroup.PATCH(":commentId/media/:mediaId", Adapter("UpdateMediaReference"))  // Creator, Admin or User with Role Trainer on Team
+			commentsGroup.DELETE(":commentId/media/:mediaId", Adapter("DeleteMediaReference")) // Creator, Admin or User with Role Trainer on Team
 		}
 	}
 
@@ -0,0 +1,39 @@
+package mail
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+	"github.com/fpgschiba/volleygoals/models"
+)
+
+// SendGuardianEmail tells a guardian about something that happened to their ward on a team.
+func SendGuardianEmail(ctx context.Context, toEmail, recipientName, playerName string, team *models.Team, message string) error {
+	client = GetClient()
+	templateData, err := json.Marshal(map[string]string{
+		"recipientName": recipientName,
+		"playerName":    playerName,
+		"teamName":      team.Name,
+		"message":       message,
+		"appLink":       FrontendBaseUrl,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = client.SendEmail(ctx, &sesv2.SendEmailInput{
+		Destination: &types.Destination{
+			ToAddresses: []string{toEmail},
+		},
+		Content: &types.EmailContent{
+			Template: &types.Template{
+				TemplateArn:  aws.String(GuardianTemplateArn),
+				TemplateData: aws.String(string(templateData)),
+			},
+		},
+		FromEmailAddress: aws.String(EmailSender),
+	})
+	return err
+}
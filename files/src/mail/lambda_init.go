@@ -10,11 +10,13 @@ import (
 )
 
 var (
-	EmailSender          = os.Getenv("EMAIL_SENDER")
-	TenantName           = os.Getenv("TENANT_NAME")
-	ConfigurationSetName = os.Getenv("CONFIGURATION_SET_NAME")
-	FrontendBaseUrl      = os.Getenv("FRONTEND_BASE_URL")
-	InviteTemplateArn    = os.Getenv("INVITE_TEMPLATE_ARN")
+	EmailSender             = os.Getenv("EMAIL_SENDER")
+	TenantName              = os.Getenv("TENANT_NAME")
+	ConfigurationSetName    = os.Getenv("CONFIGURATION_SET_NAME")
+	FrontendBaseUrl         = os.Getenv("FRONTEND_BASE_URL")
+	InviteTemplateArn       = os.Getenv("INVITE_TEMPLATE_ARN")
+	SeasonStatusTemplateArn = os.Getenv("SEASON_STATUS_TEMPLATE_ARN")
+	GuardianTemplateArn     = os.Getenv("GUARDIAN_TEMPLATE_ARN")
 )
 
 // InitClient initializes the DynamoDB client with the provided config
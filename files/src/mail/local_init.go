@@ -13,11 +13,13 @@ import (
 )
 
 var (
-	EmailSender          = "no-reply@volleygoals-test.schiba-apps.net"
-	TenantName           = "volleygoals"
-	ConfigurationSetName = "volleygoals"
-	FrontendBaseUrl      = "http://localhost:3000"
-	InviteTemplateArn    = "arn:aws:ses:eu-central-1:771805193031:template/dev-invitation"
+	EmailSender             = "no-reply@volleygoals-test.schiba-apps.net"
+	TenantName              = "volleygoals"
+	ConfigurationSetName    = "volleygoals"
+	FrontendBaseUrl         = "http://localhost:3000"
+	InviteTemplateArn       = "arn:aws:ses:eu-central-1:771805193031:template/dev-invitation"
+	SeasonStatusTemplateArn = "arn:aws:ses:eu-central-1:771805193031:template/dev-season-status"
+	GuardianTemplateArn     = "arn:aws:ses:eu-central-1:771805193031:template/dev-guardian-notification"
 )
 
 // InitClient initializes the DynamoDB client for local mode. If awsConfig is
@@ -0,0 +1,41 @@
+package mail
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+	"github.com/fpgschiba/volleygoals/models"
+)
+
+// SendSeasonStatusEmail tells a trainer that a season of their team changed its status.
+func SendSeasonStatusEmail(ctx context.Context, toEmail, recipientName string, team *models.Team, season *models.Season) error {
+	client = GetClient()
+	templateData, err := json.Marshal(map[string]string{
+		"recipientName": recipientName,
+		"teamName":      team.Name,
+		"seasonName":    season.Name,
+		"status":        string(season.Status),
+		"startDate":     season.StartDate.Format("2006-01-02"),
+		"endDate":       season.EndDate.Format("2006-01-02"),
+		"appLink":       FrontendBaseUrl,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = client.SendEmail(ctx, &sesv2.SendEmailInput{
+		Destination: &types.Destination{
+			ToAddresses: []string{toEmail},
+		},
+		Content: &types.EmailContent{
+			Template: &types.Template{
+				TemplateArn:  aws.String(SeasonStatusTemplateArn),
+				TemplateData: aws.String(string(templateData)),
+			},
+		},
+		FromEmailAddress: aws.String(EmailSender),
+	})
+	return err
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type AttendanceStatus string
+
+const (
+	AttendanceStatusPresent AttendanceStatus = "present"
+	AttendanceStatusAbsent  AttendanceStatus = "absent"
+	AttendanceStatusExcused AttendanceStatus = "excused"
+	AttendanceStatusLate    AttendanceStatus = "late"
+)
+
+// Attendance records whether a member attended a calendar event. There is at most one record per event and member.
+type Attendance struct {
+	Id         string           `dynamodbav:"id" json:"id"`
+	TeamId     string           `dynamodbav:"teamId" json:"teamId"`
+	SeasonId   string           `dynamodbav:"seasonId" json:"seasonId"`
+	EventId    string           `dynamodbav:"eventId" json:"eventId"`
+	UserId     string           `dynamodbav:"userId" json:"userId"`
+	Status     AttendanceStatus `dynamodbav:"status" json:"status"`
+	Reason     string           `dynamodbav:"reason" json:"reason"`
+	RecordedBy string           `dynamodbav:"recordedBy" json:"recordedBy"`
+	CreatedAt  time.Time        `dynamodbav:"createdAt" json:"createdAt"`
+	UpdatedAt  time.Time        `dynamodbav:"updatedAt" json:"updatedAt"`
+}
+
+func (a *Attendance) ToAttributeValues() map[string]types.AttributeValue {
+	m, err := ToDynamoMap(a)
+	if err != nil {
+		return nil
+	}
+	return m
+}
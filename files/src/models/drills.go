@@ -0,0 +1,52 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Drill is an exercise of a team's drill library. Its focus skills are tags that goals are matched against when
+// suggesting drills.
+type Drill struct {
+	Id              string    `dynamodbav:"id" json:"id"`
+	TeamId          string    `dynamodbav:"teamId" json:"teamId"`
+	Name            string    `dynamodbav:"name" json:"name"`
+	Description     string    `dynamodbav:"description" json:"description"`
+	DurationMinutes int       `dynamodbav:"durationMinutes" json:"durationMinutes"`
+	FocusSkills     []string  `dynamodbav:"focusSkills" json:"focusSkills"`
+	MediaUrl        string    `dynamodbav:"mediaUrl" json:"mediaUrl"`
+	GoalIds         []string  `dynamodbav:"goalIds" json:"goalIds"`
+	EventIds        []string  `dynamodbav:"eventIds" json:"eventIds"`
+	CreatedBy       string    `dynamodbav:"createdBy" json:"createdBy"`
+	CreatedAt       time.Time `dynamodbav:"createdAt" json:"createdAt"`
+	UpdatedAt       time.Time `dynamodbav:"updatedAt" json:"updatedAt"`
+}
+
+func (d *Drill) ToAttributeValues() map[string]types.AttributeValue {
+	m, err := ToDynamoMap(d)
+	if err != nil {
+		return nil
+	}
+	return m
+}
+
+// NormalizeTags trims and lowercases tags and drops empty and repeated ones, so drill focus skills and goal tags
+// match regardless of how they were typed.
+func NormalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" {
+			continue
+		}
+		if _, dup := seen[t]; dup {
+			continue
+		}
+		seen[t] = struct{}{}
+		normalized = append(normalized, t)
+	}
+	return normalized
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type EventType string
+
+const (
+	EventTypeTraining   EventType = "training"
+	EventTypeMatch      EventType = "match"
+	EventTypeTournament EventType = "tournament"
+	EventTypeMeeting    EventType = "meeting"
+)
+
+// Event is a calendar entry within a season, such as a training session or a match.
+type Event struct {
+	Id        string     `dynamodbav:"id" json:"id"`
+	TeamId    string     `dynamodbav:"teamId" json:"teamId"`
+	SeasonId  string     `dynamodbav:"seasonId" json:"seasonId"`
+	Type      EventType  `dynamodbav:"type" json:"type"`
+	Title     string     `dynamodbav:"title" json:"title"`
+	StartsAt  time.Time  `dynamodbav:"startsAt" json:"startsAt"`
+	EndsAt    *time.Time `dynamodbav:"endsAt,omitempty" json:"endsAt,omitempty"`
+	Location  string     `dynamodbav:"location" json:"location"`
+	Opponent  *string    `dynamodbav:"opponent,omitempty" json:"opponent,omitempty"`
+	Notes     string     `dynamodbav:"notes" json:"notes"`
+	CreatedBy string     `dynamodbav:"createdBy" json:"createdBy"`
+	CreatedAt time.Time  `dynamodbav:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time  `dynamodbav:"updatedAt" json:"updatedAt"`
+}
+
+func (e *Event) ToAttributeValues() map[string]types.AttributeValue {
+	m, err := ToDynamoMap(e)
+	if err != nil {
+		return nil
+	}
+	return m
+}
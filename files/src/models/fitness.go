@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type FitnessTestScoring string
+
+const (
+	FitnessTestScoringHigherIsBetter FitnessTestScoring = "higher_is_better"
+	FitnessTestScoringLowerIsBetter  FitnessTestScoring = "lower_is_better"
+)
+
+// FitnessTest is a physical test a team runs regularly, such as a spike jump or a sprint.
+type FitnessTest struct {
+	Id          string             `dynamodbav:"id" json:"id"`
+	TeamId      string             `dynamodbav:"teamId" json:"teamId"`
+	Name        string             `dynamodbav:"name" json:"name"`
+	Description string             `dynamodbav:"description" json:"description"`
+	Unit        string             `dynamodbav:"unit" json:"unit"`
+	Scoring     FitnessTestScoring `dynamodbav:"scoring" json:"scoring"`
+	CreatedBy   string             `dynamodbav:"createdBy" json:"createdBy"`
+	CreatedAt   time.Time          `dynamodbav:"createdAt" json:"createdAt"`
+	UpdatedAt   time.Time          `dynamodbav:"updatedAt" json:"updatedAt"`
+}
+
+// Better reports whether value a is a better result of the test than value b.
+func (t *FitnessTest) Better(a, b float64) bool {
+	if t.Scoring == FitnessTestScoringLowerIsBetter {
+		return a < b
+	}
+	return a > b
+}
+
+// FitnessResult is the dated result of one member in a fitness test.
+type FitnessResult struct {
+	Id         string    `dynamodbav:"id" json:"id"`
+	TeamId     string    `dynamodbav:"teamId" json:"teamId"`
+	TestId     string    `dynamodbav:"testId" json:"testId"`
+	UserId     string    `dynamodbav:"userId" json:"userId"`
+	Value      float64   `dynamodbav:"value" json:"value"`
+	MeasuredAt time.Time `dynamodbav:"measuredAt" json:"measuredAt"`
+	Notes      string    `dynamodbav:"notes" json:"notes"`
+	RecordedBy string    `dynamodbav:"recordedBy" json:"recordedBy"`
+	CreatedAt  time.Time `dynamodbav:"createdAt" json:"createdAt"`
+	UpdatedAt  time.Time `dynamodbav:"updatedAt" json:"updatedAt"`
+}
+
+func (t *FitnessTest) ToAttributeValues() map[string]types.AttributeValue {
+	m, err := ToDynamoMap(t)
+	if err != nil {
+		return nil
+	}
+	return m
+}
+
+func (r *FitnessResult) ToAttributeValues() map[string]types.AttributeValue {
+	m, err := ToDynamoMap(r)
+	if err != nil {
+		return nil
+	}
+	return m
+}
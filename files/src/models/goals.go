@@ -8,6 +8,8 @@ import (
 
 type GoalType string
 type GoalStatus string
+type GoalMetricSource string
+type GoalMetricComparison string
 
 const (
 	GoalTypeIndividual GoalType = "individual"
@@ -17,20 +19,54 @@ const (
 	GoalStatusInProgress GoalStatus = "in_progress"
 	GoalStatusCompleted  GoalStatus = "completed"
 	GoalStatusArchived   GoalStatus = "archived"
+
+	GoalMetricSourceMatchStats   GoalMetricSource = "match_stats"
+	GoalMetricSourceFitnessTests GoalMetricSource = "fitness_tests"
+
+	GoalMetricComparisonAtLeast GoalMetricComparison = "at_least"
+	GoalMetricComparisonAtMost  GoalMetricComparison = "at_most"
 )
 
 type Goal struct {
-	Id          string     `dynamodbav:"id" json:"id"`
-	SeasonId    string     `dynamodbav:"seasonId" json:"seasonId"`
-	OwnerId     string     `dynamodbav:"ownerId" json:"ownerId"`
-	GoalType    GoalType   `dynamodbav:"goalType" json:"goalType"`
-	Picture     string     `dynamodbav:"picture" json:"picture"`
-	Title       string     `dynamodbav:"title" json:"title"`
-	Description string     `dynamodbav:"description" json:"description"`
-	Status      GoalStatus `dynamodbav:"status" json:"status"`
-	CreatedBy   string     `dynamodbav:"createdBy" json:"createdBy"`
-	CreatedAt   time.Time  `dynamodbav:"createdAt" json:"createdAt"`
-	UpdatedAt   time.Time  `dynamodbav:"updatedAt" json:"updatedAt"`
+	Id          string      `dynamodbav:"id" json:"id"`
+	SeasonId    string      `dynamodbav:"seasonId" json:"seasonId"`
+	OwnerId     string      `dynamodbav:"ownerId" json:"ownerId"`
+	GoalType    GoalType    `dynamodbav:"goalType" json:"goalType"`
+	Picture     string      `dynamodbav:"picture" json:"picture"`
+	Title       string      `dynamodbav:"title" json:"title"`
+	Description string      `dynamodbav:"description" json:"description"`
+	Status      GoalStatus  `dynamodbav:"status" json:"status"`
+	EventId     *string     `dynamodbav:"eventId,omitempty" json:"eventId,omitempty"`
+	Metric      *GoalMetric `dynamodbav:"metric,omitempty" json:"metric,omitempty"`
+	Tags        []string    `dynamodbav:"tags,omitempty" json:"tags,omitempty"`
+	SubgroupId  string      `dynamodbav:"subgroupId,omitempty" json:"subgroupId,omitempty"`
+	CreatedBy   string      `dynamodbav:"createdBy" json:"createdBy"`
+	CreatedAt   time.Time   `dynamodbav:"createdAt" json:"createdAt"`
+	UpdatedAt   time.Time   `dynamodbav:"updatedAt" json:"updatedAt"`
+}
+
+// GoalMetric makes a goal measurable. Current is kept up to date from the metric's source: for individual goals from
+// the owner's values, for team goals from the whole team's or, for a goal of a subgroup, its members'. A goal is
+// completed once Current reaches Target. For match statistics Stat names a box score counter or rate, for fitness
+// tests it is the ID of the test.
+type GoalMetric struct {
+	Source     GoalMetricSource     `dynamodbav:"source" json:"source"`
+	Stat       string               `dynamodbav:"stat" json:"stat"`
+	Comparison GoalMetricComparison `dynamodbav:"comparison" json:"comparison"`
+	Target     float64              `dynamodbav:"target" json:"target"`
+	Current    *float64             `dynamodbav:"current,omitempty" json:"current"`
+	MeasuredAt *time.Time           `dynamodbav:"measuredAt,omitempty" json:"measuredAt"`
+}
+
+// Reached reports whether the current value meets the target.
+func (m *GoalMetric) Reached() bool {
+	if m.Current == nil {
+		return false
+	}
+	if m.Comparison == GoalMetricComparisonAtMost {
+		return *m.Current <= m.Target
+	}
+	return *m.Current >= m.Target
 }
 
 func (g *Goal) ToAttributeValues() map[string]types.AttributeValue {
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// AgeOfMajority is the age from which a player no longer counts as a minor.
+const AgeOfMajority = 18
+
+// Guardianship links a guardian, typically a parent, to a player they look after. Guardians may read the player's
+// goals, progress reports and attendance; with Notify they are emailed about the player's team life.
+type Guardianship struct {
+	Id         string    `dynamodbav:"id" json:"id"`
+	GuardianId string    `dynamodbav:"guardianId" json:"guardianId"`
+	PlayerId   string    `dynamodbav:"playerId" json:"playerId"`
+	Notify     bool      `dynamodbav:"notify" json:"notify"`
+	CreatedBy  string    `dynamodbav:"createdBy" json:"createdBy"`
+	CreatedAt  time.Time `dynamodbav:"createdAt" json:"createdAt"`
+	UpdatedAt  time.Time `dynamodbav:"updatedAt" json:"updatedAt"`
+}
+
+func (g *Guardianship) ToAttributeValues() map[string]types.AttributeValue {
+	m, err := ToDynamoMap(g)
+	if err != nil {
+		return nil
+	}
+	return m
+}
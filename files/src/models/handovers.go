@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type HandoverStatus string
+
+const (
+	HandoverStatusPending   HandoverStatus = "pending"
+	HandoverStatusAccepted  HandoverStatus = "accepted"
+	HandoverStatusDeclined  HandoverStatus = "declined"
+	HandoverStatusCancelled HandoverStatus = "cancelled"
+)
+
+// Handover is an admin's or trainer's nomination of a successor on the team. Role is the nominator's role; once the
+// successor accepts, the two swap roles, so the successor gets Role and the nominator the successor's previous role.
+type Handover struct {
+	Id           string         `dynamodbav:"id" json:"id"`
+	TeamId       string         `dynamodbav:"teamId" json:"teamId"`
+	FromUserId   string         `dynamodbav:"fromUserId" json:"fromUserId"`
+	FromMemberId string         `dynamodbav:"fromMemberId" json:"fromMemberId"`
+	ToUserId     string         `dynamodbav:"toUserId" json:"toUserId"`
+	ToMemberId   string         `dynamodbav:"toMemberId" json:"toMemberId"`
+	Role         TeamMemberRole `dynamodbav:"role" json:"role"`
+	Status       HandoverStatus `dynamodbav:"status" json:"status"`
+	CreatedAt    time.Time      `dynamodbav:"createdAt" json:"createdAt"`
+	UpdatedAt    time.Time      `dynamodbav:"updatedAt" json:"updatedAt"`
+	RespondedAt  *time.Time     `dynamodbav:"respondedAt" json:"respondedAt"`
+}
+
+func (h *Handover) ToAttributeValues() map[string]types.AttributeValue {
+	m, err := ToDynamoMap(h)
+	if err != nil {
+		return nil
+	}
+	return m
+}
@@ -33,6 +33,11 @@ type Invite struct {
 	AcceptedAt *time.Time     `dynamodbav:"acceptedAt" json:"acceptedAt"`
 	DeclinedAt *time.Time     `dynamodbav:"declinedAt" json:"declinedAt"`
 	RevokedAt  *time.Time     `dynamodbav:"revokedAt" json:"revokedAt"`
+	// GuardianConsentBy and GuardianConsentAt record the guardian who consented to a minor joining the team.
+	GuardianConsentBy *string    `dynamodbav:"guardianConsentBy" json:"guardianConsentBy"`
+	GuardianConsentAt *time.Time `dynamodbav:"guardianConsentAt" json:"guardianConsentAt"`
+	// GuardianConsentRequestedAt records when the guardians were asked to consent; they are asked only once.
+	GuardianConsentRequestedAt *time.Time `dynamodbav:"guardianConsentRequestedAt" json:"guardianConsentRequestedAt"`
 }
 
 func (inv *Invite) ToAttributeValues() map[string]types.AttributeValue {
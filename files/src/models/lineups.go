@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// LineupSlot places a starting player on a rotation position. Positions 2, 3 and 4 are the front row.
+type LineupSlot struct {
+	Position int    `dynamodbav:"position" json:"position"`
+	UserId   string `dynamodbav:"userId" json:"userId"`
+}
+
+// Lineup is the starting lineup of a match event. It is stored keyed by event ID, so a match has at most one.
+type Lineup struct {
+	EventId     string       `dynamodbav:"id" json:"eventId"`
+	TeamId      string       `dynamodbav:"teamId" json:"teamId"`
+	SeasonId    string       `dynamodbav:"seasonId" json:"seasonId"`
+	Starters    []LineupSlot `dynamodbav:"starters" json:"starters"`
+	LiberoId    *string      `dynamodbav:"liberoId,omitempty" json:"liberoId"`
+	Substitutes []string     `dynamodbav:"substitutes" json:"substitutes"`
+	Notes       string       `dynamodbav:"notes" json:"notes"`
+	RecordedBy  string       `dynamodbav:"recordedBy" json:"recordedBy"`
+	CreatedAt   time.Time    `dynamodbav:"createdAt" json:"createdAt"`
+	UpdatedAt   time.Time    `dynamodbav:"updatedAt" json:"updatedAt"`
+}
+
+func (l *Lineup) ToAttributeValues() map[string]types.AttributeValue {
+	m, err := ToDynamoMap(l)
+	if err != nil {
+		return nil
+	}
+	return m
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// MatchStat names a box score counter or a rate derived from the counters. Measurable goals refer to it.
+type MatchStat string
+
+const (
+	MatchStatServeAces        MatchStat = "serveAces"
+	MatchStatServeErrors      MatchStat = "serveErrors"
+	MatchStatReceptionPerfect MatchStat = "receptionPerfect"
+	MatchStatReceptionGood    MatchStat = "receptionGood"
+	MatchStatReceptionErrors  MatchStat = "receptionErrors"
+	MatchStatAttackKills      MatchStat = "attackKills"
+	MatchStatAttackErrors     MatchStat = "attackErrors"
+	MatchStatAttackAttempts   MatchStat = "attackAttempts"
+	MatchStatBlocks           MatchStat = "blocks"
+	MatchStatDigs             MatchStat = "digs"
+
+	MatchStatReceptionEfficiency MatchStat = "receptionEfficiency"
+	MatchStatAttackEfficiency    MatchStat = "attackEfficiency"
+	MatchStatKillRate            MatchStat = "killRate"
+)
+
+// PlayerMatchStats is the box score line of one player in one match.
+type PlayerMatchStats struct {
+	UserId           string `dynamodbav:"userId" json:"userId"`
+	ServeAces        int    `dynamodbav:"serveAces" json:"serveAces"`
+	ServeErrors      int    `dynamodbav:"serveErrors" json:"serveErrors"`
+	ReceptionPerfect int    `dynamodbav:"receptionPerfect" json:"receptionPerfect"`
+	ReceptionGood    int    `dynamodbav:"receptionGood" json:"receptionGood"`
+	ReceptionErrors  int    `dynamodbav:"receptionErrors" json:"receptionErrors"`
+	AttackKills      int    `dynamodbav:"attackKills" json:"attackKills"`
+	AttackErrors     int    `dynamodbav:"attackErrors" json:"attackErrors"`
+	AttackAttempts   int    `dynamodbav:"attackAttempts" json:"attackAttempts"`
+	Blocks           int    `dynamodbav:"blocks" json:"blocks"`
+	Digs             int    `dynamodbav:"digs" json:"digs"`
+}
+
+// MatchStats is the box score of a match event. It is stored keyed by event ID, so a match has at most one.
+type MatchStats struct {
+	EventId    string             `dynamodbav:"id" json:"eventId"`
+	TeamId     string             `dynamodbav:"teamId" json:"teamId"`
+	SeasonId   string             `dynamodbav:"seasonId" json:"seasonId"`
+	Players    []PlayerMatchStats `dynamodbav:"players" json:"players"`
+	RecordedBy string             `dynamodbav:"recordedBy" json:"recordedBy"`
+	CreatedAt  time.Time          `dynamodbav:"createdAt" json:"createdAt"`
+	UpdatedAt  time.Time          `dynamodbav:"updatedAt" json:"updatedAt"`
+}
+
+// MatchStatTotals sums the box score lines of one player or the whole team over several matches.
+// Rates are nil while nothing they are based on was recorded.
+type MatchStatTotals struct {
+	Matches             int      `json:"matches"`
+	ServeAces           int      `json:"serveAces"`
+	ServeErrors         int      `json:"serveErrors"`
+	ReceptionPerfect    int      `json:"receptionPerfect"`
+	ReceptionGood       int      `json:"receptionGood"`
+	ReceptionErrors     int      `json:"receptionErrors"`
+	AttackKills         int      `json:"attackKills"`
+	AttackErrors        int      `json:"attackErrors"`
+	AttackAttempts      int      `json:"attackAttempts"`
+	Blocks              int      `json:"blocks"`
+	Digs                int      `json:"digs"`
+	ReceptionEfficiency *float64 `json:"receptionEfficiency"`
+	AttackEfficiency    *float64 `json:"attackEfficiency"`
+	KillRate            *float64 `json:"killRate"`
+}
+
+func (m *MatchStats) ToAttributeValues() map[string]types.AttributeValue {
+	ms, err := ToDynamoMap(m)
+	if err != nil {
+		return nil
+	}
+	return ms
+}
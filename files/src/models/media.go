@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type MediaTargetType string
+type MediaSource string
+
+const (
+	MediaTargetTypeGoal          MediaTargetType = "Goal"
+	MediaTargetTypeProgressEntry MediaTargetType = "ProgressEntry"
+	MediaTargetTypeComment       MediaTargetType = "Comment"
+
+	MediaSourceUpload   MediaSource = "upload"
+	MediaSourceExternal MediaSource = "external"
+)
+
+// MediaReference points to a video clip attached to a goal, progress entry or comment. The clip is either an
+// uploaded file or an external URL; StartSeconds and EndSeconds mark the relevant part of the video.
+type MediaReference struct {
+	Id           string          `dynamodbav:"id" json:"id"`
+	TeamId       string          `dynamodbav:"teamId" json:"teamId"`
+	TargetType   MediaTargetType `dynamodbav:"targetType" json:"targetType"`
+	TargetId     string          `dynamodbav:"targetId" json:"targetId"`
+	Source       MediaSource     `dynamodbav:"source" json:"source"`
+	Url          string          `dynamodbav:"url" json:"url"`
+	StorageKey   string          `dynamodbav:"storageKey,omitempty" json:"storageKey,omitempty"`
+	ContentType  string          `dynamodbav:"contentType,omitempty" json:"contentType,omitempty"`
+	StartSeconds *float64        `dynamodbav:"startSeconds,omitempty" json:"startSeconds,omitempty"`
+	EndSeconds   *float64        `dynamodbav:"endSeconds,omitempty" json:"endSeconds,omitempty"`
+	Caption      string          `dynamodbav:"caption" json:"caption"`
+	CreatedBy    string          `dynamodbav:"createdBy" json:"createdBy"`
+	CreatedAt    time.Time       `dynamodbav:"createdAt" json:"createdAt"`
+	UpdatedAt    time.Time       `dynamodbav:"updatedAt" json:"updatedAt"`
+}
+
+func (m *MediaReference) ToAttributeValues() map[string]types.AttributeValue {
+	av, err := ToDynamoMap(m)
+	if err != nil {
+		return nil
+	}
+	return av
+}
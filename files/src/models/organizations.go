@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type OrganizationRole string
+
+const (
+	// OrganizationRoleOwner manages the organization, its members and all of its teams.
+	OrganizationRoleOwner OrganizationRole = "owner"
+	// OrganizationRoleAdmin manages all teams of the organization like a team admin.
+	OrganizationRoleAdmin OrganizationRole = "admin"
+	// OrganizationRoleViewer can read all teams of the organization.
+	OrganizationRoleViewer OrganizationRole = "viewer"
+)
+
+// Organization is a club or association that owns several teams.
+type Organization struct {
+	Id        string    `dynamodbav:"id" json:"id"`
+	Name      string    `dynamodbav:"name" json:"name"`
+	CreatedBy string    `dynamodbav:"createdBy" json:"createdBy"`
+	CreatedAt time.Time `dynamodbav:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time `dynamodbav:"updatedAt" json:"updatedAt"`
+}
+
+// OrganizationMember grants a user a role on every team of an organization, without a team membership.
+type OrganizationMember struct {
+	Id             string           `dynamodbav:"id" json:"id"`
+	OrganizationId string           `dynamodbav:"organizationId" json:"organizationId"`
+	UserId         string           `dynamodbav:"userId" json:"userId"`
+	Role           OrganizationRole `dynamodbav:"role" json:"role"`
+	CreatedAt      time.Time        `dynamodbav:"createdAt" json:"createdAt"`
+	UpdatedAt      time.Time        `dynamodbav:"updatedAt" json:"updatedAt"`
+}
+
+// OrganizationAssignment is an organization together with the caller's role in it. Platform admins see no role.
+type OrganizationAssignment struct {
+	Organization Organization     `json:"organization"`
+	Role         OrganizationRole `json:"role,omitempty"`
+}
+
+func (o *Organization) ToAttributeValues() map[string]types.AttributeValue {
+	m, err := ToDynamoMap(o)
+	if err != nil {
+		return nil
+	}
+	return m
+}
+
+func (m *OrganizationMember) ToAttributeValues() map[string]types.AttributeValue {
+	av, err := ToDynamoMap(m)
+	if err != nil {
+		return nil
+	}
+	return av
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type QuestionType string
+
+const (
+	QuestionTypeText   QuestionType = "text"
+	QuestionTypeScale  QuestionType = "scale"
+	QuestionTypeChoice QuestionType = "choice"
+)
+
+// Question is a single typed prompt within a season questionnaire.
+// Min and Max apply to scale questions, Options to choice questions.
+type Question struct {
+	Id       string       `dynamodbav:"id" json:"id"`
+	Prompt   string       `dynamodbav:"prompt" json:"prompt"`
+	Type     QuestionType `dynamodbav:"type" json:"type"`
+	Required bool         `dynamodbav:"required" json:"required"`
+	Min      *int         `dynamodbav:"min,omitempty" json:"min,omitempty"`
+	Max      *int         `dynamodbav:"max,omitempty" json:"max,omitempty"`
+	Options  []string     `dynamodbav:"options,omitempty" json:"options,omitempty"`
+}
+
+type Questionnaire struct {
+	Id        string     `dynamodbav:"id" json:"id"`
+	TeamId    string     `dynamodbav:"teamId" json:"teamId"`
+	SeasonId  string     `dynamodbav:"seasonId" json:"seasonId"`
+	Questions []Question `dynamodbav:"questions" json:"questions"`
+	CreatedAt time.Time  `dynamodbav:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time  `dynamodbav:"updatedAt" json:"updatedAt"`
+}
+
+// QuestionAnswer is the answer to one questionnaire question, stored on a progress report.
+// Exactly one of Text, Scale or Choice is set, matching the question type.
+type QuestionAnswer struct {
+	QuestionId string  `dynamodbav:"questionId" json:"questionId"`
+	Text       *string `dynamodbav:"text,omitempty" json:"text,omitempty"`
+	Scale      *int    `dynamodbav:"scale,omitempty" json:"scale,omitempty"`
+	Choice     *string `dynamodbav:"choice,omitempty" json:"choice,omitempty"`
+}
+
+func (q *Questionnaire) ToAttributeValues() map[string]types.AttributeValue {
+	m, err := ToDynamoMap(q)
+	if err != nil {
+		return nil
+	}
+	return m
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// SeasonStats is the precomputed analytics rollup of a season. It is stored keyed by season ID and
+// recomputed once it expires or a goal, progress report, questionnaire or attendance record of the season changes.
+// Stats narrowed down to a subgroup are computed on request and never stored.
+type SeasonStats struct {
+	SeasonId            string                    `dynamodbav:"id" json:"seasonId"`
+	SubgroupId          string                    `dynamodbav:"-" json:"subgroupId,omitempty"`
+	GoalCount           int                       `dynamodbav:"goalCount" json:"goalCount"`
+	CompletedGoalCount  int                       `dynamodbav:"completedGoalCount" json:"completedGoalCount"`
+	OpenGoalCount       int                       `dynamodbav:"openGoalCount" json:"openGoalCount"`
+	InProgressGoalCount int                       `dynamodbav:"inProgressGoalCount" json:"inProgressGoalCount"`
+	ReportCount         int                       `dynamodbav:"reportCount" json:"reportCount"`
+	MemberCount         int                       `dynamodbav:"memberCount" json:"memberCount"`
+	AverageRating       *float64                  `dynamodbav:"averageRating" json:"averageRating"`
+	RatingDistribution  map[string]int            `dynamodbav:"ratingDistribution" json:"ratingDistribution"`
+	CompletionByType    map[string]GoalCompletion `dynamodbav:"completionByType" json:"completionByType"`
+	CompletionByTag     map[string]GoalCompletion `dynamodbav:"completionByTag" json:"completionByTag"`
+	ReportsPerWeek      []WeeklyReportCount       `dynamodbav:"reportsPerWeek" json:"reportsPerWeek"`
+	Members             []MemberSeasonStats       `dynamodbav:"members" json:"members"`
+	Answers             []QuestionAggregate       `dynamodbav:"answers" json:"answers"`
+	ComputedAt          time.Time                 `dynamodbav:"computedAt" json:"computedAt"`
+	ExpiresAt           int64                     `dynamodbav:"expiresAt" json:"-"` // DynamoDB TTL, epoch seconds
+}
+
+// GoalCompletion counts the non-archived goals of one kind and how many of them are completed.
+type GoalCompletion struct {
+	GoalCount          int     `dynamodbav:"goalCount" json:"goalCount"`
+	CompletedGoalCount int     `dynamodbav:"completedGoalCount" json:"completedGoalCount"`
+	CompletionRate     float64 `dynamodbav:"completionRate" json:"completionRate"`
+}
+
+// WeeklyReportCount is the number of progress reports created in one ISO week.
+type WeeklyReportCount struct {
+	Week      string    `dynamodbav:"week" json:"week"`
+	WeekStart time.Time `dynamodbav:"weekStart" json:"weekStart"`
+	Count     int       `dynamodbav:"count" json:"count"`
+}
+
+// MemberSeasonStats summarises the goals and reports of one active team member in a season.
+type MemberSeasonStats struct {
+	UserId             string            `dynamodbav:"userId" json:"userId"`
+	Role               TeamMemberRole    `dynamodbav:"role" json:"role"`
+	GoalCount          int               `dynamodbav:"goalCount" json:"goalCount"`
+	CompletedGoalCount int               `dynamodbav:"completedGoalCount" json:"completedGoalCount"`
+	CompletionRate     float64           `dynamodbav:"completionRate" json:"completionRate"`
+	ReportCount        int               `dynamodbav:"reportCount" json:"reportCount"`
+	RatingCount        int               `dynamodbav:"ratingCount" json:"ratingCount"`
+	AverageRating      *float64          `dynamodbav:"averageRating" json:"averageRating"`
+	RatingDistribution map[string]int    `dynamodbav:"ratingDistribution" json:"ratingDistribution"`
+	ActiveWeeks        int               `dynamodbav:"activeWeeks" json:"activeWeeks"`
+	LastReportAt       *time.Time        `dynamodbav:"lastReportAt" json:"lastReportAt"`
+	EngagementScore    float64           `dynamodbav:"engagementScore" json:"engagementScore"`
+	EngagementRank     int               `dynamodbav:"engagementRank" json:"engagementRank"`
+	Attendance         *MemberAttendance `dynamodbav:"attendance" json:"attendance,omitempty"`
+}
+
+// MemberAttendance counts a member's attendance records in a season. Rate is the share of events the member
+// attended, on time or late, out of the recorded events they were not excused from.
+type MemberAttendance struct {
+	Recorded int      `dynamodbav:"recorded" json:"recorded"`
+	Present  int      `dynamodbav:"present" json:"present"`
+	Late     int      `dynamodbav:"late" json:"late"`
+	Excused  int      `dynamodbav:"excused" json:"excused"`
+	Absent   int      `dynamodbav:"absent" json:"absent"`
+	Rate     *float64 `dynamodbav:"rate" json:"rate"`
+}
+
+// QuestionAggregate summarises all answers given to one question in a season.
+// Average is only set for scale questions; Distribution counts answers per scale value or choice option.
+type QuestionAggregate struct {
+	QuestionId   string         `dynamodbav:"questionId" json:"questionId"`
+	Prompt       string         `dynamodbav:"prompt" json:"prompt"`
+	Type         QuestionType   `dynamodbav:"type" json:"type"`
+	AnswerCount  int            `dynamodbav:"answerCount" json:"answerCount"`
+	Average      *float64       `dynamodbav:"average,omitempty" json:"average,omitempty"`
+	Distribution map[string]int `dynamodbav:"distribution,omitempty" json:"distribution,omitempty"`
+}
+
+func (s *SeasonStats) ToAttributeValues() map[string]types.AttributeValue {
+	m, err := ToDynamoMap(s)
+	if err != nil {
+		return nil
+	}
+	return m
+}
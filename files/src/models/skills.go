@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Skill is one entry of a team's skill catalogue.
+type Skill struct {
+	Id          string `dynamodbav:"id" json:"id"`
+	Name        string `dynamodbav:"name" json:"name"`
+	Description string `dynamodbav:"description" json:"description"`
+}
+
+// SkillCatalogue lists the skills a team assesses its players on. It is stored keyed by team ID.
+type SkillCatalogue struct {
+	TeamId    string    `dynamodbav:"id" json:"teamId"`
+	Skills    []Skill   `dynamodbav:"skills" json:"skills"`
+	CreatedAt time.Time `dynamodbav:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time `dynamodbav:"updatedAt" json:"updatedAt"`
+}
+
+// SkillRating is the rating of one catalogue skill within an assessment.
+type SkillRating struct {
+	SkillId string `dynamodbav:"skillId" json:"skillId"`
+	Rating  int    `dynamodbav:"rating" json:"rating"`
+}
+
+// SkillAssessment is a trainer's dated rating of one player's skills. Not every catalogue skill has to be rated.
+type SkillAssessment struct {
+	Id         string        `dynamodbav:"id" json:"id"`
+	TeamId     string        `dynamodbav:"teamId" json:"teamId"`
+	UserId     string        `dynamodbav:"userId" json:"userId"`
+	AssessedAt time.Time     `dynamodbav:"assessedAt" json:"assessedAt"`
+	AssessedBy string        `dynamodbav:"assessedBy" json:"assessedBy"`
+	Ratings    []SkillRating `dynamodbav:"ratings" json:"ratings"`
+	Notes      string        `dynamodbav:"notes" json:"notes"`
+	CreatedAt  time.Time     `dynamodbav:"createdAt" json:"createdAt"`
+	UpdatedAt  time.Time     `dynamodbav:"updatedAt" json:"updatedAt"`
+}
+
+func (c *SkillCatalogue) ToAttributeValues() map[string]types.AttributeValue {
+	m, err := ToDynamoMap(c)
+	if err != nil {
+		return nil
+	}
+	return m
+}
+
+func (a *SkillAssessment) ToAttributeValues() map[string]types.AttributeValue {
+	m, err := ToDynamoMap(a)
+	if err != nil {
+		return nil
+	}
+	return m
+}
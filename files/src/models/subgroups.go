@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Subgroup is a squad within a team, such as the setters or the second team. Members are referenced by user ID;
+// trainers are the assistant trainers the subgroup is scoped to, who may manage it and its goals without holding
+// the team-wide permissions.
+type Subgroup struct {
+	Id          string    `dynamodbav:"id" json:"id"`
+	TeamId      string    `dynamodbav:"teamId" json:"teamId"`
+	Name        string    `dynamodbav:"name" json:"name"`
+	Description string    `dynamodbav:"description" json:"description"`
+	MemberIds   []string  `dynamodbav:"memberIds" json:"memberIds"`
+	TrainerIds  []string  `dynamodbav:"trainerIds" json:"trainerIds"`
+	CreatedBy   string    `dynamodbav:"createdBy" json:"createdBy"`
+	CreatedAt   time.Time `dynamodbav:"createdAt" json:"createdAt"`
+	UpdatedAt   time.Time `dynamodbav:"updatedAt" json:"updatedAt"`
+}
+
+func (s *Subgroup) ToAttributeValues() map[string]types.AttributeValue {
+	m, err := ToDynamoMap(s)
+	if err != nil {
+		return nil
+	}
+	return m
+}
+
+// HasMember reports whether the user is assigned to the subgroup.
+func (s *Subgroup) HasMember(userId string) bool {
+	for _, id := range s.MemberIds {
+		if id == userId {
+			return true
+		}
+	}
+	return false
+}
+
+// HasTrainer reports whether the user is one of the subgroup's assistant trainers.
+func (s *Subgroup) HasTrainer(userId string) bool {
+	for _, id := range s.TrainerIds {
+		if id == userId {
+			return true
+		}
+	}
+	return false
+}
@@ -20,16 +20,55 @@ const (
 	TeamMemberStatusLeft    TeamMemberStatus = "left"
 )
 
+// IsAdminOrTrainer reports whether the role is one of the built-in roles every team needs at least one active
+// member with.
+func (r TeamMemberRole) IsAdminOrTrainer() bool {
+	return r == TeamMemberRoleAdmin || r == TeamMemberRoleTrainer
+}
+
+// PlayingPosition is a position a member plays on the court.
+type PlayingPosition string
+
+const (
+	PlayingPositionSetter   PlayingPosition = "setter"
+	PlayingPositionOutside  PlayingPosition = "outside"
+	PlayingPositionOpposite PlayingPosition = "opposite"
+	PlayingPositionMiddle   PlayingPosition = "middle"
+	PlayingPositionLibero   PlayingPosition = "libero"
+)
+
+// DominantHand is the hand a member hits and serves with.
+type DominantHand string
+
+const (
+	DominantHandLeft  DominantHand = "left"
+	DominantHandRight DominantHand = "right"
+	DominantHandBoth  DominantHand = "both"
+)
+
+// MaxJerseyNumber is the highest jersey number a member can wear.
+const MaxJerseyNumber = 99
+
+// TeamMember is a user's membership in a team. Positions, jersey number, dominant hand and trainer notes make up
+// the member's profile within the team; trainer notes are only shown to members who may view all members. Ended
+// memberships are kept as the user's membership history.
 type TeamMember struct {
-	Id        string           `dynamodbav:"id" json:"id"`
-	UserId    string           `dynamodbav:"userId" json:"userId"`
-	TeamId    string           `dynamodbav:"teamId" json:"teamId"`
-	Role      TeamMemberRole   `dynamodbav:"role" json:"role"`
-	Status    TeamMemberStatus `dynamodbav:"status" json:"status"`
-	CreatedAt time.Time        `dynamodbav:"createdAt" json:"createdAt"`
-	UpdatedAt time.Time        `dynamodbav:"updatedAt" json:"updatedAt"`
-	JoinedAt  *time.Time       `dynamodbav:"joinedAt" json:"joinedAt"`
-	LeftAt    *time.Time       `dynamodbav:"leftAt" json:"leftAt"`
+	Id           string            `dynamodbav:"id" json:"id"`
+	UserId       string            `dynamodbav:"userId" json:"userId"`
+	TeamId       string            `dynamodbav:"teamId" json:"teamId"`
+	Role         TeamMemberRole    `dynamodbav:"role" json:"role"`
+	Status       TeamMemberStatus  `dynamodbav:"status" json:"status"`
+	Positions    []PlayingPosition `dynamodbav:"positions,omitempty" json:"positions"`
+	JerseyNumber *int              `dynamodbav:"jerseyNumber,omitempty" json:"jerseyNumber"`
+	DominantHand DominantHand      `dynamodbav:"dominantHand,omitempty" json:"dominantHand,omitempty"`
+	TrainerNotes string            `dynamodbav:"trainerNotes,omitempty" json:"trainerNotes,omitempty"`
+	CreatedAt    time.Time         `dynamodbav:"createdAt" json:"createdAt"`
+	UpdatedAt    time.Time         `dynamodbav:"updatedAt" json:"updatedAt"`
+	JoinedAt     *time.Time        `dynamodbav:"joinedAt" json:"joinedAt"`
+	LeftAt       *time.Time        `dynamodbav:"leftAt" json:"leftAt"`
+	// TransferredFromTeamId and TransferredToTeamId link the memberships of a member who moved between teams.
+	TransferredFromTeamId string `dynamodbav:"transferredFromTeamId,omitempty" json:"transferredFromTeamId,omitempty"`
+	TransferredToTeamId   string `dynamodbav:"transferredToTeamId,omitempty" json:"transferredToTeamId,omitempty"`
 }
 
 type TeamAssignment struct {
@@ -45,3 +84,13 @@ func (t *TeamMember) ToAttributeValues() map[string]types.AttributeValue {
 	}
 	return m
 }
+
+// WithoutTrainerNotes returns a copy of the membership without its trainer notes, for callers who may not see them.
+func (t *TeamMember) WithoutTrainerNotes() *TeamMember {
+	if t == nil {
+		return nil
+	}
+	member := *t
+	member.TrainerNotes = ""
+	return &member
+}
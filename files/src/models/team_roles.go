@@ -0,0 +1,113 @@
+package models
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Permission names a team-scoped action. Handlers check permissions, never role names.
+type Permission string
+
+const (
+	PermissionTeamUpdate           Permission = "team.update"
+	PermissionRolesManage          Permission = "roles.manage"
+	PermissionMembersView          Permission = "members.view"
+	PermissionMembersInvite        Permission = "members.invite"
+	PermissionMembersManage        Permission = "members.manage"
+	PermissionSeasonsManage        Permission = "seasons.manage"
+	PermissionQuestionnairesManage Permission = "questionnaires.manage"
+	PermissionGoalsCreate          Permission = "goals.create"
+	PermissionGoalsManage          Permission = "goals.manage"
+	PermissionReportsReview        Permission = "reports.review"
+	PermissionCommentsModerate     Permission = "comments.moderate"
+	PermissionMediaManage          Permission = "media.manage"
+	PermissionEventsManage         Permission = "events.manage"
+	PermissionMatchesManage        Permission = "matches.manage"
+	PermissionSkillsAssess         Permission = "skills.assess"
+	PermissionDrillsManage         Permission = "drills.manage"
+	PermissionFitnessManage        Permission = "fitness.manage"
+	PermissionActivityViewAll      Permission = "activity.viewAll"
+	PermissionSubgroupsManage      Permission = "subgroups.manage"
+)
+
+// AllPermissions lists every permission a team role can grant.
+var AllPermissions = []Permission{
+	PermissionTeamUpdate,
+	PermissionRolesManage,
+	PermissionMembersView,
+	PermissionMembersInvite,
+	PermissionMembersManage,
+	PermissionSeasonsManage,
+	PermissionQuestionnairesManage,
+	PermissionGoalsCreate,
+	PermissionGoalsManage,
+	PermissionReportsReview,
+	PermissionCommentsModerate,
+	PermissionMediaManage,
+	PermissionEventsManage,
+	PermissionMatchesManage,
+	PermissionSkillsAssess,
+	PermissionDrillsManage,
+	PermissionFitnessManage,
+	PermissionActivityViewAll,
+	PermissionSubgroupsManage,
+}
+
+// TeamRole defines a role members of a team can hold. The built-in roles admin, trainer and member exist on
+// every team and cannot be changed; teams add their own roles such as captain or physio.
+type TeamRole struct {
+	Id          string         `dynamodbav:"id" json:"id,omitempty"`
+	TeamId      string         `dynamodbav:"teamId" json:"teamId,omitempty"`
+	Name        TeamMemberRole `dynamodbav:"name" json:"name"`
+	Description string         `dynamodbav:"description" json:"description"`
+	Permissions []Permission   `dynamodbav:"permissions" json:"permissions"`
+	BuiltIn     bool           `dynamodbav:"builtIn" json:"builtIn"`
+	CreatedBy   string         `dynamodbav:"createdBy" json:"createdBy,omitempty"`
+	CreatedAt   *time.Time     `dynamodbav:"createdAt" json:"createdAt,omitempty"`
+	UpdatedAt   *time.Time     `dynamodbav:"updatedAt" json:"updatedAt,omitempty"`
+}
+
+// BuiltInTeamRoles returns the roles every team has. Admins hold every permission, trainers every permission
+// except managing roles, and members none beyond team access.
+func BuiltInTeamRoles() []*TeamRole {
+	trainer := make([]Permission, 0, len(AllPermissions))
+	for _, p := range AllPermissions {
+		if p != PermissionRolesManage {
+			trainer = append(trainer, p)
+		}
+	}
+	return []*TeamRole{
+		{Name: TeamMemberRoleAdmin, Description: "Manages the team, its roles and members", Permissions: append([]Permission(nil), AllPermissions...), BuiltIn: true},
+		{Name: TeamMemberRoleTrainer, Description: "Manages seasons, goals, events and members", Permissions: trainer, BuiltIn: true},
+		{Name: TeamMemberRoleMember, Description: "Team access and own goals and reports", Permissions: []Permission{}, BuiltIn: true},
+	}
+}
+
+// BuiltInTeamRole returns the built-in role with the given name, or nil for a custom role.
+func BuiltInTeamRole(name TeamMemberRole) *TeamRole {
+	for _, r := range BuiltInTeamRoles() {
+		if r.Name == name {
+			return r
+		}
+	}
+	return nil
+}
+
+// Grants reports whether the role includes the permission.
+func (r *TeamRole) Grants(permission Permission) bool {
+	for _, p := range r.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *TeamRole) ToAttributeValues() map[string]types.AttributeValue {
+	m, err := ToDynamoMap(r)
+	if err != nil {
+		return nil
+	}
+	return m
+}
@@ -12,6 +12,7 @@ type TeamSettings struct {
 	AllowFileUploads            bool      `dynamodbav:"allowFileUploads" json:"allowFileUploads"`
 	AllowTeamGoalComments       bool      `dynamodbav:"allowTeamGoalComments" json:"allowTeamGoalComments"`
 	AllowIndividualGoalComments bool      `dynamodbav:"allowIndividualGoalComments" json:"allowIndividualGoalComments"`
+	RequireGuardianConsent      bool      `dynamodbav:"requireGuardianConsent" json:"requireGuardianConsent"`
 	CreatedAt                   time.Time `dynamodbav:"createdAt" json:"createdAt"`
 	UpdatedAt                   time.Time `dynamodbav:"updatedAt" json:"updatedAt"`
 }
@@ -2,6 +2,7 @@ package models
 
 import (
 	"time"
+	_ "time/tzdata" // Lambda runtimes ship without a zoneinfo database
 
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
@@ -14,13 +15,16 @@ const (
 )
 
 type Team struct {
-	Id        string     `dynamodbav:"id" json:"id"`
-	Name      string     `dynamodbav:"teamName" json:"name"`
-	Status    TeamStatus `dynamodbav:"status" json:"status"`
-	Picture   string     `dynamodbav:"picture" json:"picture"`
-	CreatedAt time.Time  `dynamodbav:"createdAt" json:"createdAt"`
-	UpdatedAt time.Time  `dynamodbav:"updatedAt" json:"updatedAt"`
-	DeletedAt *time.Time `dynamodbav:"deletedAt" json:"deletedAt"`
+	Id       string     `dynamodbav:"id" json:"id"`
+	Name     string     `dynamodbav:"teamName" json:"name"`
+	Status   TeamStatus `dynamodbav:"status" json:"status"`
+	Picture  string     `dynamodbav:"picture" json:"picture"`
+	Timezone string     `dynamodbav:"timezone" json:"timezone"`
+	// OrganizationId is the organization owning the team, empty for teams outside an organization.
+	OrganizationId string     `dynamodbav:"organizationId" json:"organizationId"`
+	CreatedAt      time.Time  `dynamodbav:"createdAt" json:"createdAt"`
+	UpdatedAt      time.Time  `dynamodbav:"updatedAt" json:"updatedAt"`
+	DeletedAt      *time.Time `dynamodbav:"deletedAt" json:"deletedAt"`
 }
 
 func (t *Team) ToAttributeValues() map[string]types.AttributeValue {
@@ -30,3 +34,15 @@ func (t *Team) ToAttributeValues() map[string]types.AttributeValue {
 	}
 	return m
 }
+
+// Location returns the team's time zone. Teams without a (valid) time zone use UTC.
+func (t *Team) Location() *time.Location {
+	if t.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(t.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
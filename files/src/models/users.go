@@ -43,6 +43,15 @@ type User struct {
 	Language          *string    `json:"language,omitempty"`
 }
 
+// IsMinor reports whether the user is younger than the age of majority on the given day. Users without a birthdate
+// are not treated as minors.
+func (u *User) IsMinor(now time.Time) bool {
+	if u.Birthdate == nil {
+		return false
+	}
+	return now.Before(u.Birthdate.AddDate(AgeOfMajority, 0, 0))
+}
+
 func UserFromCognito(user types.UserType, userType UserType) *User {
 	sub := ""
 	email := ""
@@ -11,9 +11,13 @@ import (
 	"github.com/google/uuid"
 )
 
-// determineKey returns the DynamoDB key name for a struct field.
+// determineKey returns the DynamoDB key name for a struct field. Options after the name, such as omitempty, are not
+// part of it, the same as for attributevalue.UnmarshalMap, which reads every item back. A "-" name skips the field.
 func determineKey(sf reflect.StructField) string {
 	key := sf.Tag.Get("dynamodbav")
+	if idx := strings.Index(key, ","); idx != -1 {
+		key = key[:idx]
+	}
 	if key != "" {
 		return key
 	}
@@ -59,6 +63,9 @@ func ToDynamoMap[T any](obj *T) (map[string]types.AttributeValue, error) {
 			continue
 		}
 		key := determineKey(sf)
+		if key == "-" {
+			continue
+		}
 		fv := v.Field(i)
 		// handle pointer fields: skip nil pointers, dereference non-nil
 		if fv.Kind() == reflect.Ptr {
@@ -67,6 +74,10 @@ func ToDynamoMap[T any](obj *T) (map[string]types.AttributeValue, error) {
 			}
 			fv = fv.Elem()
 		}
+		// skip nil slices and maps so optional collections are not stored as NULL
+		if (fv.Kind() == reflect.Slice || fv.Kind() == reflect.Map) && fv.IsNil() {
+			continue
+		}
 		m[key] = valueToInterface(fv)
 	}
 
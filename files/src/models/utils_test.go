@@ -0,0 +1,112 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// TestToDynamoMapRoundTrip checks every model stored through ToDynamoMap: each field written has to be read back
+// under the same key by attributevalue.UnmarshalMap, which every db read uses.
+func TestToDynamoMapRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		model interface {
+			ToAttributeValues() map[string]types.AttributeValue
+		}
+	}{
+		{"Activity", &Activity{}},
+		{"Attendance", &Attendance{}},
+		{"CommentFile", &CommentFile{}},
+		{"Comment", &Comment{}},
+		{"Drill", &Drill{}},
+		{"Event", &Event{}},
+		{"FitnessTest", &FitnessTest{}},
+		{"FitnessResult", &FitnessResult{}},
+		{"Goal", &Goal{}},
+		{"Guardianship", &Guardianship{}},
+		{"Handover", &Handover{}},
+		{"Invite", &Invite{}},
+		{"Lineup", &Lineup{}},
+		{"MatchStats", &MatchStats{}},
+		{"MediaReference", &MediaReference{}},
+		{"Organization", &Organization{}},
+		{"OrganizationMember", &OrganizationMember{}},
+		{"Progress", &Progress{}},
+		{"ProgressReport", &ProgressReport{}},
+		{"Questionnaire", &Questionnaire{}},
+		{"SeasonStats", &SeasonStats{}},
+		{"Season", &Season{}},
+		{"SkillCatalogue", &SkillCatalogue{}},
+		{"SkillAssessment", &SkillAssessment{}},
+		{"Subgroup", &Subgroup{}},
+		{"TeamMember", &TeamMember{}},
+		{"TeamRole", &TeamRole{}},
+		{"TeamSettings", &TeamSettings{}},
+		{"Team", &Team{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fill(reflect.ValueOf(tt.model).Elem())
+			m := tt.model.ToAttributeValues()
+			if m == nil {
+				t.Fatal("ToAttributeValues returned nil")
+			}
+			if _, ok := m["-"]; ok {
+				t.Error("field tagged dynamodbav:\"-\" was written")
+			}
+			read := reflect.New(reflect.TypeOf(tt.model).Elem()).Interface()
+			if err := attributevalue.UnmarshalMap(m, read); err != nil {
+				t.Fatalf("UnmarshalMap: %v", err)
+			}
+			if !reflect.DeepEqual(tt.model, read) {
+				t.Errorf("read back\n%+v\nwant\n%+v", read, tt.model)
+			}
+		})
+	}
+}
+
+// fill sets every field of v to a non-zero value.
+func fill(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		fill(v.Elem())
+	case reflect.Struct:
+		if v.Type() == reflect.TypeOf(time.Time{}) {
+			v.Set(reflect.ValueOf(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)))
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			sf := v.Type().Field(i)
+			if sf.PkgPath == "" && sf.Tag.Get("dynamodbav") != "-" {
+				fill(v.Field(i))
+			}
+		}
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		fill(v.Index(0))
+	case reflect.Map:
+		v.Set(reflect.MakeMap(v.Type()))
+		key := reflect.New(v.Type().Key()).Elem()
+		fill(key)
+		value := reflect.New(v.Type().Elem()).Elem()
+		fill(value)
+		v.SetMapIndex(key, value)
+	case reflect.String:
+		v.SetString("x")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1.5)
+	case reflect.Interface:
+		v.Set(reflect.ValueOf("x"))
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/fpgschiba/volleygoals/db"
 	"github.com/fpgschiba/volleygoals/models"
+	"github.com/fpgschiba/volleygoals/router/subgroups"
 	"github.com/fpgschiba/volleygoals/users"
 	"github.com/fpgschiba/volleygoals/utils"
 )
@@ -19,38 +20,29 @@ func GetTeamActivity(ctx context.Context, event events.APIGatewayProxyRequest) (
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
 	}
 
-	if !utils.IsAdmin(event.RequestContext.Authorizer) && !utils.HasTeamAccess(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-
 	filter, err := db.ActivityFilterFromQuery(event.QueryStringParameters)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
 	}
 	filter.TeamId = teamId
+	subgroup, resp, err := subgroups.LoadForTeam(ctx, teamId, event.QueryStringParameters)
+	if resp != nil {
+		return resp, err
+	}
 
-	items, count, nextCursor, hasMore, err := db.ListTeamActivities(ctx, filter)
-	if err != nil {
-		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	// Without activity.viewAll only "all"-visibility events are shown
+	if !utils.HasPermission(ctx, event.RequestContext.Authorizer, teamId, models.PermissionActivityViewAll) {
+		filter.Visibility = string(models.ActivityVisibilityAll)
+	}
+	// With a subgroup only the activity of its members and trainers is shown
+	if subgroup != nil {
+		filter.ActorIds = append(append(make([]string, 0), subgroup.MemberIds...), subgroup.TrainerIds...)
 	}
 
-	// Members only see "all"-visibility events
-	callerRole, err := utils.GetUserRoleOnTeam(ctx, event.RequestContext.Authorizer, teamId)
+	items, count, nextCursor, hasMore, err := db.ListTeamActivities(ctx, filter)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
 	}
-	isMember := !utils.IsAdmin(event.RequestContext.Authorizer) &&
-		(callerRole == nil || *callerRole == models.TeamMemberRoleMember)
-	if isMember {
-		filtered := items[:0]
-		for _, a := range items {
-			if a.Visibility == models.ActivityVisibilityAll {
-				filtered = append(filtered, a)
-			}
-		}
-		items = filtered
-		count = len(items)
-	}
 
 	nextToken := ""
 	if nextCursor != nil {
@@ -159,6 +151,32 @@ func EmitMemberRemoved(ctx context.Context, teamId, userId, memberId string) {
 	))
 }
 
+// EmitMemberTransferred records on the old team that a member moved to another team.
+func EmitMemberTransferred(ctx context.Context, teamId, userId, memberId, targetTeamName string) {
+	u, _ := users.GetUserBySub(ctx, userId)
+	actorName, actorPicture := ResolveActorInfo(u)
+	db.EmitActivity(ctx, NewActivity(
+		teamId, userId, actorName, actorPicture,
+		"member.transferred",
+		fmt.Sprintf("A member was transferred to %s", targetTeamName),
+		"team_member", memberId,
+		models.ActivityVisibilityAdminTrainer,
+	))
+}
+
+// EmitHandoverAccepted records that a successor accepted a handover and took over the role.
+func EmitHandoverAccepted(ctx context.Context, teamId, userId string, role models.TeamMemberRole, handoverId string) {
+	u, _ := users.GetUserBySub(ctx, userId)
+	actorName, actorPicture := ResolveActorInfo(u)
+	db.EmitActivity(ctx, NewActivity(
+		teamId, userId, actorName, actorPicture,
+		"member.handover_accepted",
+		fmt.Sprintf("A member took over the %s role", string(role)),
+		"handover", handoverId,
+		models.ActivityVisibilityAll,
+	))
+}
+
 func EmitTeamSettingsUpdated(ctx context.Context, teamId, userId string) {
 	u, _ := users.GetUserBySub(ctx, userId)
 	actorName, actorPicture := ResolveActorInfo(u)
@@ -170,3 +188,26 @@ func EmitTeamSettingsUpdated(ctx context.Context, teamId, userId string) {
 		models.ActivityVisibilityAdminTrainer,
 	))
 }
+
+// EmitSeasonStatusChanged records a season transition made by the scheduled lifecycle handler, so there is no acting user.
+func EmitSeasonStatusChanged(ctx context.Context, teamId, seasonName string, status models.SeasonStatus, seasonId string) {
+	db.EmitActivity(ctx, NewActivity(
+		teamId, "", "VolleyGoals", "",
+		"season.status_changed",
+		fmt.Sprintf("Season \"%s\" is now %s", seasonName, string(status)),
+		"season", seasonId,
+		models.ActivityVisibilityAll,
+	))
+}
+
+func EmitEventScheduled(ctx context.Context, teamId, userId, eventTitle string, eventType models.EventType, eventId string) {
+	u, _ := users.GetUserBySub(ctx, userId)
+	actorName, actorPicture := ResolveActorInfo(u)
+	db.EmitActivity(ctx, NewActivity(
+		teamId, userId, actorName, actorPicture,
+		"event.scheduled",
+		fmt.Sprintf("A %s was scheduled: \"%s\"", string(eventType), eventTitle),
+		"event", eventId,
+		models.ActivityVisibilityAll,
+	))
+}
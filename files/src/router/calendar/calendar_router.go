@@ -0,0 +1,515 @@
+package calendar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpgschiba/volleygoals/db"
+	"github.com/fpgschiba/volleygoals/models"
+	"github.com/fpgschiba/volleygoals/router/activity"
+	match_stats "github.com/fpgschiba/volleygoals/router/match-stats"
+	"github.com/fpgschiba/volleygoals/utils"
+)
+
+func CreateEvent(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	if seasonId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	season, err := db.GetSeasonById(ctx, seasonId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if season == nil {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorSeasonNotFound, nil)
+	}
+
+	var request CreateEventRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+
+	callerId := utils.GetCognitoUsername(event.RequestContext.Authorizer)
+	calendarEvent := &models.Event{
+		TeamId:    season.TeamId,
+		SeasonId:  seasonId,
+		Type:      request.Type,
+		Title:     strings.TrimSpace(request.Title),
+		StartsAt:  request.StartsAt,
+		EndsAt:    request.EndsAt,
+		Location:  request.Location,
+		Opponent:  request.Opponent,
+		Notes:     request.Notes,
+		CreatedBy: callerId,
+	}
+	if fieldErrors := validateEvent(season, calendarEvent); len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidEvent, fieldErrors)
+	}
+
+	calendarEvent, err = db.CreateEvent(ctx, calendarEvent)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	activity.EmitEventScheduled(ctx, season.TeamId, callerId, calendarEvent.Title, calendarEvent.Type, calendarEvent.Id)
+
+	return utils.SuccessResponse(http.StatusCreated, utils.MsgSuccess, map[string]interface{}{
+		"event": calendarEvent,
+	})
+}
+
+// ListEvents returns the events of a season ordered by start time, earliest first.
+func ListEvents(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	if seasonId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	filter, err := db.EventFilterFromQuery(event.QueryStringParameters)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+
+	all, err := db.ListAllEventsBySeasonId(ctx, seasonId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	matching := make([]*models.Event, 0, len(all))
+	for _, e := range all {
+		if filter.Type != "" && string(e.Type) != filter.Type {
+			continue
+		}
+		if filter.From != nil && e.StartsAt.Before(*filter.From) {
+			continue
+		}
+		if filter.To != nil && e.StartsAt.After(*filter.To) {
+			continue
+		}
+		matching = append(matching, e)
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		if !matching[i].StartsAt.Equal(matching[j].StartsAt) {
+			return matching[i].StartsAt.Before(matching[j].StartsAt)
+		}
+		return matching[i].Id < matching[j].Id
+	})
+
+	// The cursor holds the ID of the last event of the previous page.
+	start := 0
+	if filter.Cursor != nil && filter.Cursor.LastID != "" {
+		start = len(matching)
+		for i, e := range matching {
+			if e.Id == filter.Cursor.LastID {
+				start = i + 1
+				break
+			}
+		}
+	}
+	end := start + filter.Limit
+	if end > len(matching) {
+		end = len(matching)
+	}
+	items := matching[start:end]
+	hasMore := end < len(matching)
+	nextToken := ""
+	if hasMore {
+		nextToken, err = models.EncodeCursor(&models.Cursor{LastID: items[len(items)-1].Id})
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"items":     items,
+		"count":     len(items),
+		"nextToken": nextToken,
+		"hasMore":   hasMore,
+	})
+}
+
+func GetEvent(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	eventId := event.PathParameters["eventId"]
+	if seasonId == "" || eventId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	calendarEvent, err := db.GetEventById(ctx, eventId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if calendarEvent == nil || calendarEvent.SeasonId != seasonId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorEventNotFound, nil)
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"event": calendarEvent,
+	})
+}
+
+func UpdateEvent(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	eventId := event.PathParameters["eventId"]
+	if seasonId == "" || eventId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	calendarEvent, err := db.GetEventById(ctx, eventId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if calendarEvent == nil || calendarEvent.SeasonId != seasonId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorEventNotFound, nil)
+	}
+
+	var request UpdateEventRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+
+	season, err := db.GetSeasonById(ctx, seasonId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if season == nil {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorSeasonNotFound, nil)
+	}
+
+	if request.Type != nil {
+		calendarEvent.Type = *request.Type
+	}
+	if request.Title != nil {
+		calendarEvent.Title = strings.TrimSpace(*request.Title)
+	}
+	if request.StartsAt != nil {
+		calendarEvent.StartsAt = *request.StartsAt
+	}
+	if request.EndsAt != nil {
+		calendarEvent.EndsAt = request.EndsAt
+	}
+	if request.Location != nil {
+		calendarEvent.Location = *request.Location
+	}
+	if request.Opponent != nil {
+		// An empty opponent clears it, e.g. when a match is turned into a training session.
+		calendarEvent.Opponent = request.Opponent
+		if *request.Opponent == "" {
+			calendarEvent.Opponent = nil
+		}
+	}
+	if request.Notes != nil {
+		calendarEvent.Notes = *request.Notes
+	}
+	if fieldErrors := validateEvent(season, calendarEvent); len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidEvent, fieldErrors)
+	}
+
+	if err := db.UpdateEvent(ctx, calendarEvent); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"event": calendarEvent,
+	})
+}
+
+// DeleteEvent removes an event with its attendance records, box score and lineup. Goals, progress reports and drills linked
+// to it are kept but lose the link.
+func DeleteEvent(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	eventId := event.PathParameters["eventId"]
+	if seasonId == "" || eventId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	calendarEvent, err := db.GetEventById(ctx, eventId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if calendarEvent == nil || calendarEvent.SeasonId != seasonId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorEventNotFound, nil)
+	}
+
+	if err := db.UnlinkEvent(ctx, eventId); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if err := db.UnlinkEventFromDrills(ctx, calendarEvent.TeamId, eventId); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if err := db.DeleteAttendanceByEventId(ctx, eventId); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if err := db.DeleteLineup(ctx, eventId); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	matchStats, err := db.GetMatchStatsByEventId(ctx, eventId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if matchStats != nil {
+		if err := db.DeleteMatchStats(ctx, eventId); err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+	}
+	if err := db.DeleteEvent(ctx, eventId); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	db.InvalidateSeasonStats(ctx, seasonId)
+	if matchStats != nil {
+		callerId := utils.GetCognitoUsername(event.RequestContext.Authorizer)
+		if err := match_stats.RefreshMeasurableGoals(ctx, calendarEvent.TeamId, seasonId, callerId); err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+	}
+
+	return utils.SuccessResponse(http.StatusNoContent, utils.MsgSuccess, nil)
+}
+
+// GetEventAttendance returns the attendance records of an event. Members only get their own record.
+func GetEventAttendance(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	eventId := event.PathParameters["eventId"]
+	if seasonId == "" || eventId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	calendarEvent, err := db.GetEventById(ctx, eventId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if calendarEvent == nil || calendarEvent.SeasonId != seasonId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorEventNotFound, nil)
+	}
+
+	records, err := db.ListAttendanceByEventId(ctx, eventId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	records = visibleAttendance(ctx, event.RequestContext.Authorizer, calendarEvent.TeamId, records)
+	sort.Slice(records, func(i, j int) bool { return records[i].UserId < records[j].UserId })
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"items": records,
+		"count": len(records),
+	})
+}
+
+// UpdateEventAttendance records the attendance of several members of an event in one call. Members that are
+// already recorded are overwritten, members not mentioned in the request are left unchanged.
+func UpdateEventAttendance(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	eventId := event.PathParameters["eventId"]
+	if seasonId == "" || eventId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	calendarEvent, err := db.GetEventById(ctx, eventId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if calendarEvent == nil || calendarEvent.SeasonId != seasonId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorEventNotFound, nil)
+	}
+
+	var request UpdateAttendanceRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+
+	fieldErrors, err := validateAttendance(ctx, calendarEvent.TeamId, request.Records)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidAttendance, fieldErrors)
+	}
+
+	callerId := utils.GetCognitoUsername(event.RequestContext.Authorizer)
+	records := make([]*models.Attendance, 0, len(request.Records))
+	for _, r := range request.Records {
+		records = append(records, &models.Attendance{
+			TeamId:     calendarEvent.TeamId,
+			SeasonId:   seasonId,
+			EventId:    eventId,
+			UserId:     r.UserId,
+			Status:     r.Status,
+			Reason:     strings.TrimSpace(r.Reason),
+			RecordedBy: callerId,
+		})
+	}
+	if err := db.PutAttendance(ctx, records); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	db.InvalidateSeasonStats(ctx, seasonId)
+
+	all, err := db.ListAttendanceByEventId(ctx, eventId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].UserId < all[j].UserId })
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"items": all,
+		"count": len(all),
+	})
+}
+
+// ListSeasonAttendance returns the attendance records of a season, ordered by event start. Team admins and
+// trainers can narrow the list down to one member with userId; members always get only their own records.
+func ListSeasonAttendance(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	if seasonId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	teamId, err := db.GetTeamIdBySeasonId(ctx, seasonId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if teamId == "" {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorSeasonNotFound, nil)
+	}
+
+	records, err := db.ListAttendanceBySeasonId(ctx, seasonId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	records = visibleAttendance(ctx, event.RequestContext.Authorizer, teamId, records)
+	if userId := strings.TrimSpace(event.QueryStringParameters["userId"]); userId != "" {
+		filtered := records[:0]
+		for _, a := range records {
+			if a.UserId == userId {
+				filtered = append(filtered, a)
+			}
+		}
+		records = filtered
+	}
+
+	seasonEvents, err := db.ListAllEventsBySeasonId(ctx, seasonId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	startsAt := make(map[string]time.Time, len(seasonEvents))
+	for _, e := range seasonEvents {
+		startsAt[e.Id] = e.StartsAt
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if a, b := startsAt[records[i].EventId], startsAt[records[j].EventId]; !a.Equal(b) {
+			return a.Before(b)
+		}
+		return records[i].UserId < records[j].UserId
+	})
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"items": records,
+		"count": len(records),
+	})
+}
+
+// visibleAttendance drops the records of other members when the caller is a plain team member.
+func visibleAttendance(ctx context.Context, authorizer map[string]interface{}, teamId string, records []*models.Attendance) []*models.Attendance {
+	if utils.HasPermission(ctx, authorizer, teamId, models.PermissionEventsManage) {
+		return records
+	}
+	callerId := utils.GetCognitoUsername(authorizer)
+	own := make([]*models.Attendance, 0, 1)
+	for _, a := range records {
+		if a.UserId == callerId {
+			own = append(own, a)
+		}
+	}
+	return own
+}
+
+// validateAttendance checks that every record names a distinct active member of the team and a known status.
+func validateAttendance(ctx context.Context, teamId string, records []AttendanceRecordRequest) ([]utils.FieldError, error) {
+	fieldErrors := make([]utils.FieldError, 0)
+	if len(records) == 0 {
+		return append(fieldErrors, utils.FieldError{Field: "records", Message: "at least one record is required"}), nil
+	}
+	members, err := db.GetMembershipsByTeamID(ctx, teamId)
+	if err != nil {
+		return nil, err
+	}
+	activeMembers := make(map[string]struct{}, len(members))
+	for _, m := range members {
+		activeMembers[m.UserId] = struct{}{}
+	}
+
+	seen := make(map[string]int, len(records))
+	for i, r := range records {
+		field := fmt.Sprintf("records[%d]", i)
+		if first, dup := seen[r.UserId]; dup {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field + ".userId", Message: fmt.Sprintf("member is already recorded in records[%d]", first)})
+			continue
+		}
+		seen[r.UserId] = i
+		if _, ok := activeMembers[r.UserId]; !ok {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field + ".userId", Message: "not an active member of the team"})
+		}
+		switch r.Status {
+		case models.AttendanceStatusPresent, models.AttendanceStatusAbsent, models.AttendanceStatusExcused, models.AttendanceStatusLate:
+		default:
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field + ".status", Message: "must be one of present, absent, excused, late"})
+		}
+	}
+	return fieldErrors, nil
+}
+
+// ValidateEventLink checks that a goal or progress report may link to the given event. A nil or empty
+// eventId is always valid since it means no link.
+func ValidateEventLink(ctx context.Context, seasonId string, eventId *string) ([]utils.FieldError, error) {
+	if eventId == nil || *eventId == "" {
+		return nil, nil
+	}
+	calendarEvent, err := db.GetEventById(ctx, *eventId)
+	if err != nil {
+		return nil, err
+	}
+	if calendarEvent == nil || calendarEvent.SeasonId != seasonId {
+		return []utils.FieldError{{Field: "eventId", Message: "event does not exist in this season"}}, nil
+	}
+	return nil, nil
+}
+
+func validateEvent(season *models.Season, e *models.Event) []utils.FieldError {
+	fieldErrors := make([]utils.FieldError, 0)
+	switch e.Type {
+	case models.EventTypeTraining, models.EventTypeMatch, models.EventTypeTournament, models.EventTypeMeeting:
+	default:
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "type", Message: "must be one of training, match, tournament, meeting"})
+	}
+	if e.Title == "" {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "title", Message: "is required"})
+	}
+	if e.StartsAt.IsZero() {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "startsAt", Message: "is required"})
+	} else if !withinSeason(season, e.StartsAt) {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "startsAt", Message: "must be within the season"})
+	}
+	if e.EndsAt != nil && !e.EndsAt.After(e.StartsAt) {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "endsAt", Message: "must be after startsAt"})
+	}
+	if e.Opponent != nil && e.Type != models.EventTypeMatch && e.Type != models.EventTypeTournament {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "opponent", Message: "is only allowed for matches and tournaments"})
+	}
+	return fieldErrors
+}
+
+// withinSeason compares calendar days: an event's day is taken in the offset it was given with, the season's
+// days as stored.
+func withinSeason(season *models.Season, startsAt time.Time) bool {
+	day := func(t time.Time) time.Time {
+		y, m, d := t.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	eventDay := day(startsAt)
+	return !eventDay.Before(day(season.StartDate)) && !eventDay.After(day(season.EndDate))
+}
@@ -0,0 +1,37 @@
+package calendar
+
+import (
+	"time"
+
+	"github.com/fpgschiba/volleygoals/models"
+)
+
+type CreateEventRequest struct {
+	Type     models.EventType `json:"type"`
+	Title    string           `json:"title"`
+	StartsAt time.Time        `json:"startsAt"`
+	EndsAt   *time.Time       `json:"endsAt,omitempty"`
+	Location string           `json:"location"`
+	Opponent *string          `json:"opponent,omitempty"`
+	Notes    string           `json:"notes"`
+}
+
+type UpdateEventRequest struct {
+	Type     *models.EventType `json:"type,omitempty"`
+	Title    *string           `json:"title,omitempty"`
+	StartsAt *time.Time        `json:"startsAt,omitempty"`
+	EndsAt   *time.Time        `json:"endsAt,omitempty"`
+	Location *string           `json:"location,omitempty"`
+	Opponent *string           `json:"opponent,omitempty"`
+	Notes    *string           `json:"notes,omitempty"`
+}
+
+type AttendanceRecordRequest struct {
+	UserId string                  `json:"userId"`
+	Status models.AttendanceStatus `json:"status"`
+	Reason string                  `json:"reason"`
+}
+
+type UpdateAttendanceRequest struct {
+	Records []AttendanceRecordRequest `json:"records"`
+}
@@ -26,7 +26,7 @@ func CreateComment(ctx context.Context, event events.APIGatewayProxyRequest) (*e
 	}
 
 	// Resolve teamId from target
-	teamId, err := resolveTeamIdFromTarget(ctx, request.CommentType, request.TargetId)
+	teamId, err := ResolveTeamIdFromTarget(ctx, request.CommentType, request.TargetId)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
 	}
@@ -34,10 +34,6 @@ func CreateComment(ctx context.Context, event events.APIGatewayProxyRequest) (*e
 		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorNotFound, nil)
 	}
 
-	if !utils.HasTeamAccess(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-
 	// Check TeamSettings for Goal comment types
 	if request.CommentType == models.CommentTypeGoal {
 		goal, err := db.GetGoalById(ctx, request.TargetId)
@@ -104,14 +100,6 @@ func GetComment(ctx context.Context, event events.APIGatewayProxyRequest) (*even
 		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorCommentNotFound, nil)
 	}
 
-	teamId, err := resolveTeamIdFromTarget(ctx, comment.CommentType, comment.TargetId)
-	if err != nil {
-		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
-	}
-	if !utils.HasTeamAccess(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-
 	commentFiles, err := db.GetCommentFilesByCommentId(ctx, commentId)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
@@ -125,10 +113,15 @@ func GetComment(ctx context.Context, event events.APIGatewayProxyRequest) (*even
 			FileUrl:    storage.GetPublicFileURL(f.StorageKey),
 		})
 	}
+	media, err := db.ListMediaReferencesByTargetId(ctx, commentId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
+	}
 
 	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
 		"comment": comment,
 		"files":   files,
+		"media":   media,
 	})
 }
 
@@ -138,22 +131,18 @@ func ListComments(ctx context.Context, event events.APIGatewayProxyRequest) (*ev
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
 	}
 
-	teamId, err := resolveTeamIdFromTarget(ctx, models.CommentType(filter.CommentType), filter.TargetId)
-	if err != nil {
-		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
-	}
-	if teamId == "" {
-		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorNotFound, nil)
-	}
-
-	if !utils.HasTeamAccess(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-
-	items, count, nextCursor, hasMore, err := db.ListComments(ctx, filter)
+	comments, count, nextCursor, hasMore, err := db.ListComments(ctx, filter)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
 	}
+	items := make([]CommentWithMedia, 0, len(comments))
+	for _, c := range comments {
+		media, err := db.ListMediaReferencesByTargetId(ctx, c.Id)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		items = append(items, CommentWithMedia{c, media})
+	}
 
 	nextToken := ""
 	if nextCursor != nil {
@@ -185,19 +174,6 @@ func UpdateComment(ctx context.Context, event events.APIGatewayProxyRequest) (*e
 		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorCommentNotFound, nil)
 	}
 
-	teamId, err := resolveTeamIdFromTarget(ctx, comment.CommentType, comment.TargetId)
-	if err != nil {
-		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
-	}
-	if !utils.HasTeamAccess(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-
-	userId := utils.GetCognitoUsername(event.RequestContext.Authorizer)
-	if comment.AuthorId != userId && !utils.IsTeamAdminOrTrainer(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-
 	var request UpdateCommentRequest
 	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
@@ -227,19 +203,9 @@ func DeleteComment(ctx context.Context, event events.APIGatewayProxyRequest) (*e
 		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorCommentNotFound, nil)
 	}
 
-	teamId, err := resolveTeamIdFromTarget(ctx, comment.CommentType, comment.TargetId)
-	if err != nil {
+	if err := db.DeleteMediaReferencesForTarget(ctx, commentId); err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
 	}
-	if !utils.HasTeamAccess(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-
-	userId := utils.GetCognitoUsername(event.RequestContext.Authorizer)
-	if comment.AuthorId != userId && !utils.IsTeamAdminOrTrainer(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-
 	if err := db.DeleteComment(ctx, commentId); err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
 	}
@@ -270,19 +236,6 @@ func UploadCommentFile(ctx context.Context, event events.APIGatewayProxyRequest)
 		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorCommentNotFound, nil)
 	}
 
-	teamId, err := resolveTeamIdFromTarget(ctx, comment.CommentType, comment.TargetId)
-	if err != nil {
-		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
-	}
-	if !utils.HasTeamAccess(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-
-	userId := utils.GetCognitoUsername(event.RequestContext.Authorizer)
-	if comment.AuthorId != userId && !utils.IsTeamAdminOrTrainer(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-
 	presignedUrl, key, err := storage.GeneratePresignedUploadURLForCommentFile(ctx, commentId, filename, contentType, utils.PresignedURLTimeout)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
@@ -300,8 +253,8 @@ func UploadCommentFile(ctx context.Context, event events.APIGatewayProxyRequest)
 	})
 }
 
-// resolveTeamIdFromTarget looks up the teamId for a comment target (Goal or ProgressReport).
-func resolveTeamIdFromTarget(ctx context.Context, commentType models.CommentType, targetId string) (string, error) {
+// ResolveTeamIdFromTarget looks up the teamId for a comment target (Goal or ProgressReport).
+func ResolveTeamIdFromTarget(ctx context.Context, commentType models.CommentType, targetId string) (string, error) {
 	switch commentType {
 	case models.CommentTypeGoal:
 		goal, err := db.GetGoalById(ctx, targetId)
@@ -9,6 +9,12 @@ type CommentFileResult struct {
 	FileUrl    string `json:"fileUrl"`
 }
 
+// CommentWithMedia is a comment with the media references attached to it.
+type CommentWithMedia struct {
+	*models.Comment
+	Media []*models.MediaReference `json:"media"`
+}
+
 type CreateCommentRequest struct {
 	CommentType models.CommentType `json:"commentType"`
 	TargetId    string             `json:"targetId"`
@@ -0,0 +1,415 @@
+package drills
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpgschiba/volleygoals/db"
+	"github.com/fpgschiba/volleygoals/models"
+	"github.com/fpgschiba/volleygoals/storage"
+	"github.com/fpgschiba/volleygoals/utils"
+)
+
+const (
+	drillPageSize    = 25
+	drillMaxPageSize = 100
+)
+
+func CreateDrill(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	if teamId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	var request CreateDrillRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+
+	drill := &models.Drill{
+		TeamId:          teamId,
+		Name:            strings.TrimSpace(request.Name),
+		Description:     request.Description,
+		DurationMinutes: request.DurationMinutes,
+		FocusSkills:     models.NormalizeTags(request.FocusSkills),
+		GoalIds:         request.GoalIds,
+		EventIds:        request.EventIds,
+		CreatedBy:       utils.GetCognitoUsername(event.RequestContext.Authorizer),
+	}
+	if drill.GoalIds == nil {
+		drill.GoalIds = []string{}
+	}
+	if drill.EventIds == nil {
+		drill.EventIds = []string{}
+	}
+	fieldErrors, err := validateDrill(ctx, drill)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidDrill, fieldErrors)
+	}
+
+	drill, err = db.CreateDrill(ctx, drill)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusCreated, utils.MsgSuccess, map[string]interface{}{
+		"drill": drill,
+	})
+}
+
+// ListDrills returns the drill library of a team ordered by name. It can be narrowed down to the drills with a
+// focus skill (tag), the drills linked to a goal or event, or drills whose name contains name.
+func ListDrills(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	if teamId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+	options, err := db.FilterOptionsFromQuery(event.QueryStringParameters, drillPageSize, drillMaxPageSize)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+
+	tag := strings.ToLower(strings.TrimSpace(event.QueryStringParameters["tag"]))
+	goalId := strings.TrimSpace(event.QueryStringParameters["goalId"])
+	eventId := strings.TrimSpace(event.QueryStringParameters["eventId"])
+	name := strings.ToLower(strings.TrimSpace(event.QueryStringParameters["name"]))
+
+	all, err := db.ListDrillsByTeamId(ctx, teamId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	matching := make([]*models.Drill, 0, len(all))
+	for _, d := range all {
+		if tag != "" && !contains(d.FocusSkills, tag) {
+			continue
+		}
+		if goalId != "" && !contains(d.GoalIds, goalId) {
+			continue
+		}
+		if eventId != "" && !contains(d.EventIds, eventId) {
+			continue
+		}
+		if name != "" && !strings.Contains(strings.ToLower(d.Name), name) {
+			continue
+		}
+		matching = append(matching, d)
+	}
+	sortByName(matching)
+
+	// The cursor holds the ID of the last drill of the previous page.
+	start := 0
+	if options.Cursor != nil && options.Cursor.LastID != "" {
+		start = len(matching)
+		for i, d := range matching {
+			if d.Id == options.Cursor.LastID {
+				start = i + 1
+				break
+			}
+		}
+	}
+	end := start + options.Limit
+	if end > len(matching) {
+		end = len(matching)
+	}
+	items := matching[start:end]
+	hasMore := end < len(matching)
+	nextToken := ""
+	if hasMore {
+		nextToken, err = models.EncodeCursor(&models.Cursor{LastID: items[len(items)-1].Id})
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"items":     items,
+		"count":     len(items),
+		"nextToken": nextToken,
+		"hasMore":   hasMore,
+	})
+}
+
+func GetDrill(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	drillId := event.PathParameters["drillId"]
+	if teamId == "" || drillId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	drill, err := db.GetDrillById(ctx, drillId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if drill == nil || drill.TeamId != teamId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorDrillNotFound, nil)
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"drill": drill,
+	})
+}
+
+// UpdateDrill updates the given fields of a drill. Focus skills, goal and event links are replaced as a whole.
+func UpdateDrill(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	drillId := event.PathParameters["drillId"]
+	if teamId == "" || drillId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	var request UpdateDrillRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+
+	drill, err := db.GetDrillById(ctx, drillId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if drill == nil || drill.TeamId != teamId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorDrillNotFound, nil)
+	}
+
+	if request.Name != nil {
+		drill.Name = strings.TrimSpace(*request.Name)
+	}
+	if request.Description != nil {
+		drill.Description = *request.Description
+	}
+	if request.DurationMinutes != nil {
+		drill.DurationMinutes = *request.DurationMinutes
+	}
+	if request.FocusSkills != nil {
+		drill.FocusSkills = models.NormalizeTags(*request.FocusSkills)
+	}
+	if request.GoalIds != nil {
+		drill.GoalIds = append([]string{}, *request.GoalIds...)
+	}
+	if request.EventIds != nil {
+		drill.EventIds = append([]string{}, *request.EventIds...)
+	}
+	fieldErrors, err := validateDrill(ctx, drill)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidDrill, fieldErrors)
+	}
+
+	if err := db.UpdateDrill(ctx, drill); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"drill": drill,
+	})
+}
+
+func DeleteDrill(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	drillId := event.PathParameters["drillId"]
+	if teamId == "" || drillId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	drill, err := db.GetDrillById(ctx, drillId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if drill == nil || drill.TeamId != teamId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorDrillNotFound, nil)
+	}
+	if err := db.DeleteDrill(ctx, drillId); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusNoContent, utils.MsgSuccess, nil)
+}
+
+func UploadDrillMedia(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	drillId := event.PathParameters["drillId"]
+	if teamId == "" || drillId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	filename, ok := event.QueryStringParameters["filename"]
+	if !ok || filename == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+	contentType, ok := event.QueryStringParameters["contentType"]
+	if !ok || contentType == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	drill, err := db.GetDrillById(ctx, drillId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if drill == nil || drill.TeamId != teamId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorDrillNotFound, nil)
+	}
+
+	presignedUrl, key, err := storage.GeneratePresignedUploadURLForDrillMedia(ctx, drillId, filename, contentType, utils.PresignedURLTimeout)
+	if err != nil {
+		return nil, err
+	}
+	publicUrl := storage.GetPublicFileURL(key)
+
+	if err := db.UpdateDrillMedia(ctx, drillId, publicUrl); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusOK,
+		utils.MsgSuccess,
+		map[string]interface{}{
+			"uploadUrl": presignedUrl,
+			"key":       key,
+			"fileUrl":   publicUrl,
+		})
+}
+
+// SuggestDrillsForGoal returns the drills of the goal's team whose focus skills share a tag with the goal, most
+// matching tags first. Drills already linked to the goal are left out.
+func SuggestDrillsForGoal(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	goalId := event.PathParameters["goalId"]
+	if seasonId == "" || goalId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	teamId, err := db.GetTeamIdBySeasonId(ctx, seasonId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if teamId == "" {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorSeasonNotFound, nil)
+	}
+
+	goal, err := db.GetGoalById(ctx, goalId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if goal == nil || goal.SeasonId != seasonId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorNotFound, nil)
+	}
+
+	suggestions := make([]SuggestedDrill, 0)
+	if len(goal.Tags) > 0 {
+		all, err := db.ListDrillsByTeamId(ctx, teamId)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		sortByName(all)
+		for _, d := range all {
+			if contains(d.GoalIds, goalId) {
+				continue
+			}
+			matchingTags := make([]string, 0)
+			for _, tag := range goal.Tags {
+				if contains(d.FocusSkills, tag) {
+					matchingTags = append(matchingTags, tag)
+				}
+			}
+			if len(matchingTags) > 0 {
+				suggestions = append(suggestions, SuggestedDrill{Drill: d, MatchingTags: matchingTags})
+			}
+		}
+		sort.SliceStable(suggestions, func(i, j int) bool {
+			return len(suggestions[i].MatchingTags) > len(suggestions[j].MatchingTags)
+		})
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"items": suggestions,
+		"count": len(suggestions),
+	})
+}
+
+// validateDrill checks the drill's fields and that it only links to goals of the team's seasons and to the team's
+// training events.
+func validateDrill(ctx context.Context, drill *models.Drill) ([]utils.FieldError, error) {
+	fieldErrors := make([]utils.FieldError, 0)
+	if drill.Name == "" {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "name", Message: "is required"})
+	}
+	if drill.DurationMinutes <= 0 {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "durationMinutes", Message: "must be a positive number of minutes"})
+	}
+
+	seasonTeams := map[string]string{}
+	seenGoals := make(map[string]int, len(drill.GoalIds))
+	for i, goalId := range drill.GoalIds {
+		field := fmt.Sprintf("goalIds[%d]", i)
+		if first, dup := seenGoals[goalId]; dup {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field, Message: fmt.Sprintf("goal is already linked in goalIds[%d]", first)})
+			continue
+		}
+		seenGoals[goalId] = i
+		goal, err := db.GetGoalById(ctx, goalId)
+		if err != nil {
+			return nil, err
+		}
+		if goal == nil {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field, Message: "goal does not exist in this team"})
+			continue
+		}
+		teamId, known := seasonTeams[goal.SeasonId]
+		if !known {
+			if teamId, err = db.GetTeamIdBySeasonId(ctx, goal.SeasonId); err != nil {
+				return nil, err
+			}
+			seasonTeams[goal.SeasonId] = teamId
+		}
+		if teamId != drill.TeamId {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field, Message: "goal does not exist in this team"})
+		}
+	}
+
+	seenEvents := make(map[string]int, len(drill.EventIds))
+	for i, eventId := range drill.EventIds {
+		field := fmt.Sprintf("eventIds[%d]", i)
+		if first, dup := seenEvents[eventId]; dup {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field, Message: fmt.Sprintf("event is already linked in eventIds[%d]", first)})
+			continue
+		}
+		seenEvents[eventId] = i
+		calendarEvent, err := db.GetEventById(ctx, eventId)
+		if err != nil {
+			return nil, err
+		}
+		if calendarEvent == nil || calendarEvent.TeamId != drill.TeamId {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field, Message: "event does not exist in this team"})
+		} else if calendarEvent.Type != models.EventTypeTraining {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field, Message: "must be a training event"})
+		}
+	}
+	return fieldErrors, nil
+}
+
+func sortByName(drills []*models.Drill) {
+	sort.Slice(drills, func(i, j int) bool {
+		a, b := strings.ToLower(drills[i].Name), strings.ToLower(drills[j].Name)
+		if a != b {
+			return a < b
+		}
+		return drills[i].Id < drills[j].Id
+	})
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,27 @@
+package drills
+
+import "github.com/fpgschiba/volleygoals/models"
+
+type CreateDrillRequest struct {
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	DurationMinutes int      `json:"durationMinutes"`
+	FocusSkills     []string `json:"focusSkills"`
+	GoalIds         []string `json:"goalIds"`
+	EventIds        []string `json:"eventIds"`
+}
+
+type UpdateDrillRequest struct {
+	Name            *string   `json:"name,omitempty"`
+	Description     *string   `json:"description,omitempty"`
+	DurationMinutes *int      `json:"durationMinutes,omitempty"`
+	FocusSkills     *[]string `json:"focusSkills,omitempty"`
+	GoalIds         *[]string `json:"goalIds,omitempty"`
+	EventIds        *[]string `json:"eventIds,omitempty"`
+}
+
+// SuggestedDrill is a drill whose focus skills share at least one tag with a goal.
+type SuggestedDrill struct {
+	*models.Drill
+	MatchingTags []string `json:"matchingTags"`
+}
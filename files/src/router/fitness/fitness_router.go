@@ -0,0 +1,676 @@
+package fitness
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpgschiba/volleygoals/db"
+	"github.com/fpgschiba/volleygoals/models"
+	"github.com/fpgschiba/volleygoals/router/activity"
+	"github.com/fpgschiba/volleygoals/utils"
+)
+
+const (
+	resultPageSize    = 25
+	resultMaxPageSize = 100
+)
+
+func CreateFitnessTest(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	if teamId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	var request CreateFitnessTestRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+	test := &models.FitnessTest{
+		TeamId:      teamId,
+		Name:        strings.TrimSpace(request.Name),
+		Description: strings.TrimSpace(request.Description),
+		Unit:        strings.TrimSpace(request.Unit),
+		Scoring:     request.Scoring,
+		CreatedBy:   utils.GetCognitoUsername(event.RequestContext.Authorizer),
+	}
+	if test.Scoring == "" {
+		test.Scoring = models.FitnessTestScoringHigherIsBetter
+	}
+	fieldErrors, err := validateTest(ctx, test)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidFitnessTest, fieldErrors)
+	}
+
+	test, err = db.CreateFitnessTest(ctx, test)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusCreated, utils.MsgSuccess, map[string]interface{}{
+		"test": test,
+	})
+}
+
+// ListFitnessTests returns every fitness test of a team ordered by name.
+func ListFitnessTests(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	if teamId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	tests, err := db.ListFitnessTestsByTeamId(ctx, teamId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	sortTests(tests)
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"items": tests,
+		"count": len(tests),
+	})
+}
+
+// UpdateFitnessTest changes a fitness test. Changing its scoring changes which results are personal bests, so the
+// goals measured by the team's fitness tests are refreshed.
+func UpdateFitnessTest(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	testId := event.PathParameters["testId"]
+	if teamId == "" || testId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	var request UpdateFitnessTestRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+	test, err := db.GetFitnessTestById(ctx, testId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if test == nil || test.TeamId != teamId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorFitnessTestNotFound, nil)
+	}
+
+	scoring := test.Scoring
+	if request.Name != nil {
+		test.Name = strings.TrimSpace(*request.Name)
+	}
+	if request.Description != nil {
+		test.Description = strings.TrimSpace(*request.Description)
+	}
+	if request.Unit != nil {
+		test.Unit = strings.TrimSpace(*request.Unit)
+	}
+	if request.Scoring != nil {
+		test.Scoring = *request.Scoring
+	}
+	fieldErrors, err := validateTest(ctx, test)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidFitnessTest, fieldErrors)
+	}
+
+	if err := db.UpdateFitnessTest(ctx, test); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if test.Scoring != scoring {
+		if err := refreshSeasons(ctx, teamId, nil, utils.GetCognitoUsername(event.RequestContext.Authorizer)); err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"test": test,
+	})
+}
+
+// DeleteFitnessTest removes a fitness test with all of its results. Goals measured by the test keep their metric
+// but no longer have a current value.
+func DeleteFitnessTest(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	testId := event.PathParameters["testId"]
+	if teamId == "" || testId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	test, err := db.GetFitnessTestById(ctx, testId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if test == nil || test.TeamId != teamId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorFitnessTestNotFound, nil)
+	}
+	if err := db.DeleteFitnessTest(ctx, testId); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if err := refreshSeasons(ctx, teamId, nil, utils.GetCognitoUsername(event.RequestContext.Authorizer)); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusNoContent, utils.MsgSuccess, nil)
+}
+
+// CreateFitnessResult records the result of a member in a fitness test. Goals measured by the team's fitness tests
+// in the seasons covering the result's date are refreshed.
+func CreateFitnessResult(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	testId := event.PathParameters["testId"]
+	if teamId == "" || testId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	var request CreateFitnessResultRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+	test, err := db.GetFitnessTestById(ctx, testId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if test == nil || test.TeamId != teamId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorFitnessTestNotFound, nil)
+	}
+	members, err := db.GetMembershipsByTeamID(ctx, teamId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	now := time.Now()
+	measuredAt := now
+	if request.MeasuredAt != nil {
+		measuredAt = *request.MeasuredAt
+	}
+	fieldErrors := validateResult(members, request.UserId, request.Value, measuredAt, now)
+	if len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidFitnessResult, fieldErrors)
+	}
+
+	callerId := utils.GetCognitoUsername(event.RequestContext.Authorizer)
+	result, err := db.CreateFitnessResult(ctx, &models.FitnessResult{
+		TeamId:     teamId,
+		TestId:     testId,
+		UserId:     request.UserId,
+		Value:      *request.Value,
+		MeasuredAt: measuredAt,
+		Notes:      strings.TrimSpace(request.Notes),
+		RecordedBy: callerId,
+	})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if err := refreshSeasons(ctx, teamId, &measuredAt, callerId); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusCreated, utils.MsgSuccess, map[string]interface{}{
+		"result": result,
+	})
+}
+
+// ListFitnessResults returns the results of a fitness test, newest first, optionally narrowed down to one member
+// with userId.
+func ListFitnessResults(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	testId := event.PathParameters["testId"]
+	if teamId == "" || testId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+	options, err := db.FilterOptionsFromQuery(event.QueryStringParameters, resultPageSize, resultMaxPageSize)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+
+	test, err := db.GetFitnessTestById(ctx, testId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if test == nil || test.TeamId != teamId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorFitnessTestNotFound, nil)
+	}
+	all, err := db.ListFitnessResultsByTestId(ctx, testId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	userId := strings.TrimSpace(event.QueryStringParameters["userId"])
+	matching := make([]*models.FitnessResult, 0, len(all))
+	for _, r := range all {
+		if userId == "" || r.UserId == userId {
+			matching = append(matching, r)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		if !matching[i].MeasuredAt.Equal(matching[j].MeasuredAt) {
+			return matching[i].MeasuredAt.After(matching[j].MeasuredAt)
+		}
+		return matching[i].Id < matching[j].Id
+	})
+
+	// The cursor holds the ID of the last result of the previous page.
+	start := 0
+	if options.Cursor != nil && options.Cursor.LastID != "" {
+		start = len(matching)
+		for i, r := range matching {
+			if r.Id == options.Cursor.LastID {
+				start = i + 1
+				break
+			}
+		}
+	}
+	end := start + options.Limit
+	if end > len(matching) {
+		end = len(matching)
+	}
+	items := matching[start:end]
+	hasMore := end < len(matching)
+	nextToken := ""
+	if hasMore {
+		nextToken, err = models.EncodeCursor(&models.Cursor{LastID: items[len(items)-1].Id})
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"items":     items,
+		"count":     len(items),
+		"nextToken": nextToken,
+		"hasMore":   hasMore,
+	})
+}
+
+func DeleteFitnessResult(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	testId := event.PathParameters["testId"]
+	resultId := event.PathParameters["resultId"]
+	if teamId == "" || testId == "" || resultId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	result, err := db.GetFitnessResultById(ctx, resultId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if result == nil || result.TeamId != teamId || result.TestId != testId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorFitnessResultNotFound, nil)
+	}
+	if err := db.DeleteFitnessResult(ctx, resultId); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if err := refreshSeasons(ctx, teamId, &result.MeasuredAt, utils.GetCognitoUsername(event.RequestContext.Authorizer)); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusNoContent, utils.MsgSuccess, nil)
+}
+
+// GetFitnessLeaderboard ranks the active members of a team by their personal best in a fitness test. With seasonId
+// only results measured during that season count.
+func GetFitnessLeaderboard(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	testId := event.PathParameters["testId"]
+	if teamId == "" || testId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	test, err := db.GetFitnessTestById(ctx, testId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if test == nil || test.TeamId != teamId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorFitnessTestNotFound, nil)
+	}
+	var season *models.Season
+	if seasonId := strings.TrimSpace(event.QueryStringParameters["seasonId"]); seasonId != "" {
+		season, err = db.GetSeasonById(ctx, seasonId)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		if season == nil || season.TeamId != teamId {
+			return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorSeasonNotFound, nil)
+		}
+	}
+	members, err := db.GetMembershipsByTeamID(ctx, teamId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	results, err := db.ListFitnessResultsByTestId(ctx, testId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if season != nil {
+		results = resultsWithinSeason(season, results)
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"test":    test,
+		"entries": leaderboard(test, members, results),
+	})
+}
+
+// GetMemberPersonalBests returns the personal best and latest result of one team member in every fitness test of
+// the team the member has results in.
+func GetMemberPersonalBests(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	teamMemberId := event.PathParameters["memberId"]
+	if teamId == "" || teamMemberId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	member, err := db.GetTeamMemberById(ctx, teamMemberId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if member == nil || member.TeamId != teamId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorUserNotFound, nil)
+	}
+	tests, err := db.ListFitnessTestsByTeamId(ctx, teamId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	results, err := db.ListFitnessResultsByTeamId(ctx, teamId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"userId": member.UserId,
+		"bests":  personalBests(tests, member.UserId, results),
+	})
+}
+
+// RefreshFitnessGoals recomputes the current value of every goal of the season measured by a fitness test. Only
+// results measured during the season count: an individual goal takes the owner's personal best, a team goal the
+// average of the members' personal bests, or of the subgroup's members for a goal of a subgroup. Goals that reach
+// their target are completed; userId is recorded as the one who changed their status.
+func RefreshFitnessGoals(ctx context.Context, teamId, seasonId, userId string) error {
+	goals, err := db.ListAllGoalsBySeasonId(ctx, seasonId)
+	if err != nil {
+		return err
+	}
+	measured := make([]*models.Goal, 0)
+	for _, g := range goals {
+		if g.Metric != nil && g.Metric.Source == models.GoalMetricSourceFitnessTests {
+			measured = append(measured, g)
+		}
+	}
+	if len(measured) == 0 {
+		return nil
+	}
+
+	season, err := db.GetSeasonById(ctx, seasonId)
+	if err != nil || season == nil {
+		return err
+	}
+	tests, err := db.ListFitnessTestsByTeamId(ctx, teamId)
+	if err != nil {
+		return err
+	}
+	results, err := db.ListFitnessResultsByTeamId(ctx, teamId)
+	if err != nil {
+		return err
+	}
+	byTest := make(map[string][]*models.FitnessResult)
+	for _, r := range resultsWithinSeason(season, results) {
+		byTest[r.TestId] = append(byTest[r.TestId], r)
+	}
+	bests := make(map[string]map[string]*models.FitnessResult, len(tests))
+	for _, t := range tests {
+		bests[t.Id] = bestByUser(t, byTest[t.Id])
+	}
+
+	now := time.Now()
+	changed := false
+	subgroups := make(map[string]*models.Subgroup)
+	for _, g := range measured {
+		var current *float64
+		if g.GoalType == models.GoalTypeIndividual {
+			if best := bests[g.Metric.Stat][g.OwnerId]; best != nil {
+				value := best.Value
+				current = &value
+			}
+		} else {
+			teamBests := bests[g.Metric.Stat]
+			if g.SubgroupId != "" {
+				subgroup, ok := subgroups[g.SubgroupId]
+				if !ok {
+					subgroup, err = db.GetSubgroupById(ctx, g.SubgroupId)
+					if err != nil {
+						return err
+					}
+					subgroups[g.SubgroupId] = subgroup
+				}
+				if subgroup != nil {
+					teamBests = membersBests(teamBests, subgroup.MemberIds)
+				}
+			}
+			current = averageBest(teamBests)
+		}
+		if sameValue(current, g.Metric.Current) {
+			continue
+		}
+
+		metric := *g.Metric
+		metric.Current = current
+		metric.MeasuredAt = &now
+		var status *models.GoalStatus
+		if metric.Reached() && (g.Status == models.GoalStatusOpen || g.Status == models.GoalStatusInProgress) {
+			completed := models.GoalStatusCompleted
+			status = &completed
+		}
+		if _, err := db.UpdateGoal(ctx, g.Id, nil, nil, nil, status, nil, &metric, nil, nil); err != nil {
+			return err
+		}
+		if status != nil {
+			activity.EmitGoalStatusChanged(ctx, teamId, userId, g.Title, *status, g.Id)
+		}
+		changed = true
+	}
+	if changed {
+		db.InvalidateSeasonStats(ctx, seasonId)
+	}
+	return nil
+}
+
+// refreshSeasons refreshes the fitness goals of the team's seasons covering measuredAt, or of all of the team's
+// seasons if measuredAt is nil.
+func refreshSeasons(ctx context.Context, teamId string, measuredAt *time.Time, userId string) error {
+	seasons, err := db.GetAllSeasonsByTeamId(ctx, teamId)
+	if err != nil {
+		return err
+	}
+	for _, s := range seasons {
+		if measuredAt != nil && !withinSeason(s, *measuredAt) {
+			continue
+		}
+		if err := RefreshFitnessGoals(ctx, teamId, s.Id, userId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bestByUser picks the best result of every member. Of equal results the earlier one is the personal best.
+func bestByUser(test *models.FitnessTest, results []*models.FitnessResult) map[string]*models.FitnessResult {
+	bests := make(map[string]*models.FitnessResult)
+	for _, r := range results {
+		best, ok := bests[r.UserId]
+		if !ok || test.Better(r.Value, best.Value) || (r.Value == best.Value && r.MeasuredAt.Before(best.MeasuredAt)) {
+			bests[r.UserId] = r
+		}
+	}
+	return bests
+}
+
+// membersBests picks the personal bests of the members.
+func membersBests(bests map[string]*models.FitnessResult, memberIds []string) map[string]*models.FitnessResult {
+	picked := make(map[string]*models.FitnessResult, len(memberIds))
+	for _, id := range memberIds {
+		if best, ok := bests[id]; ok {
+			picked[id] = best
+		}
+	}
+	return picked
+}
+
+// averageBest returns the average of the given personal bests rounded to two decimals, or nil if there are none.
+func averageBest(bests map[string]*models.FitnessResult) *float64 {
+	if len(bests) == 0 {
+		return nil
+	}
+	var sum float64
+	for _, b := range bests {
+		sum += b.Value
+	}
+	avg := math.Round(sum/float64(len(bests))*100) / 100
+	return &avg
+}
+
+func leaderboard(test *models.FitnessTest, members []*models.TeamMember, results []*models.FitnessResult) []LeaderboardEntry {
+	bests := bestByUser(test, results)
+	entries := make([]LeaderboardEntry, 0, len(members))
+	for _, m := range members {
+		if best, ok := bests[m.UserId]; ok {
+			entries = append(entries, LeaderboardEntry{UserId: m.UserId, Best: best})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Best.Value != entries[j].Best.Value {
+			return test.Better(entries[i].Best.Value, entries[j].Best.Value)
+		}
+		return entries[i].UserId < entries[j].UserId
+	})
+	for i := range entries {
+		entries[i].Rank = i + 1
+		if i > 0 && entries[i].Best.Value == entries[i-1].Best.Value {
+			entries[i].Rank = entries[i-1].Rank
+		}
+	}
+	return entries
+}
+
+func personalBests(tests []*models.FitnessTest, userId string, results []*models.FitnessResult) []PersonalBest {
+	own := make(map[string][]*models.FitnessResult)
+	for _, r := range results {
+		if r.UserId == userId {
+			own[r.TestId] = append(own[r.TestId], r)
+		}
+	}
+	sortTests(tests)
+	bests := make([]PersonalBest, 0, len(own))
+	for _, t := range tests {
+		testResults := own[t.Id]
+		if len(testResults) == 0 {
+			continue
+		}
+		pb := PersonalBest{Test: t, Best: bestByUser(t, testResults)[userId], Results: len(testResults)}
+		for _, r := range testResults {
+			if pb.Latest == nil || r.MeasuredAt.After(pb.Latest.MeasuredAt) {
+				pb.Latest = r
+			}
+		}
+		bests = append(bests, pb)
+	}
+	return bests
+}
+
+func resultsWithinSeason(season *models.Season, results []*models.FitnessResult) []*models.FitnessResult {
+	within := make([]*models.FitnessResult, 0, len(results))
+	for _, r := range results {
+		if withinSeason(season, r.MeasuredAt) {
+			within = append(within, r)
+		}
+	}
+	return within
+}
+
+// withinSeason compares calendar days: a result's day is taken in the offset it was given with, the season's
+// days as stored.
+func withinSeason(season *models.Season, measuredAt time.Time) bool {
+	day := func(t time.Time) time.Time {
+		y, m, d := t.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	measuredDay := day(measuredAt)
+	return !measuredDay.Before(day(season.StartDate)) && !measuredDay.After(day(season.EndDate))
+}
+
+func sameValue(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func sortTests(tests []*models.FitnessTest) {
+	sort.Slice(tests, func(i, j int) bool {
+		a, b := strings.ToLower(tests[i].Name), strings.ToLower(tests[j].Name)
+		if a != b {
+			return a < b
+		}
+		return tests[i].Id < tests[j].Id
+	})
+}
+
+// validateTest checks a fitness test's fields. Test names must be unique within the team.
+func validateTest(ctx context.Context, test *models.FitnessTest) ([]utils.FieldError, error) {
+	fieldErrors := make([]utils.FieldError, 0)
+	if test.Name == "" {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "name", Message: "is required"})
+	} else {
+		existing, err := db.ListFitnessTestsByTeamId(ctx, test.TeamId)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range existing {
+			if t.Id != test.Id && strings.EqualFold(t.Name, test.Name) {
+				fieldErrors = append(fieldErrors, utils.FieldError{Field: "name", Message: "a test with this name already exists"})
+				break
+			}
+		}
+	}
+	if test.Unit == "" {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "unit", Message: "is required"})
+	}
+	if test.Scoring != models.FitnessTestScoringHigherIsBetter && test.Scoring != models.FitnessTestScoringLowerIsBetter {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "scoring", Message: fmt.Sprintf("must be %s or %s", models.FitnessTestScoringHigherIsBetter, models.FitnessTestScoringLowerIsBetter)})
+	}
+	return fieldErrors, nil
+}
+
+// validateResult checks that a result belongs to an active member, has a non-negative value and is not dated in
+// the future.
+func validateResult(members []*models.TeamMember, userId string, value *float64, measuredAt, now time.Time) []utils.FieldError {
+	fieldErrors := make([]utils.FieldError, 0)
+	isMember := false
+	for _, m := range members {
+		if m.UserId == userId {
+			isMember = true
+			break
+		}
+	}
+	if !isMember {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "userId", Message: "not an active member of the team"})
+	}
+	if value == nil {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "value", Message: "is required"})
+	} else if *value < 0 {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "value", Message: "must not be negative"})
+	}
+	if measuredAt.After(now) {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "measuredAt", Message: "must not be in the future"})
+	}
+	return fieldErrors
+}
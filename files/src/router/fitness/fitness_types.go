@@ -0,0 +1,43 @@
+package fitness
+
+import (
+	"time"
+
+	"github.com/fpgschiba/volleygoals/models"
+)
+
+type CreateFitnessTestRequest struct {
+	Name        string                    `json:"name"`
+	Description string                    `json:"description"`
+	Unit        string                    `json:"unit"`
+	Scoring     models.FitnessTestScoring `json:"scoring"`
+}
+
+type UpdateFitnessTestRequest struct {
+	Name        *string                    `json:"name"`
+	Description *string                    `json:"description"`
+	Unit        *string                    `json:"unit"`
+	Scoring     *models.FitnessTestScoring `json:"scoring"`
+}
+
+type CreateFitnessResultRequest struct {
+	UserId     string     `json:"userId"`
+	Value      *float64   `json:"value"`
+	MeasuredAt *time.Time `json:"measuredAt"`
+	Notes      string     `json:"notes"`
+}
+
+// LeaderboardEntry is the personal best of one member in a fitness test. Members with the same best share a rank.
+type LeaderboardEntry struct {
+	Rank   int                   `json:"rank"`
+	UserId string                `json:"userId"`
+	Best   *models.FitnessResult `json:"best"`
+}
+
+// PersonalBest sums up the results of one member in one fitness test.
+type PersonalBest struct {
+	Test    *models.FitnessTest   `json:"test"`
+	Best    *models.FitnessResult `json:"best"`
+	Latest  *models.FitnessResult `json:"latest"`
+	Results int                   `json:"results"`
+}
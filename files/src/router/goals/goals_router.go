@@ -3,6 +3,7 @@ package goals
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"math"
 	"net/http"
 
@@ -10,9 +11,14 @@ import (
 	"github.com/fpgschiba/volleygoals/db"
 	"github.com/fpgschiba/volleygoals/models"
 	"github.com/fpgschiba/volleygoals/router/activity"
+	"github.com/fpgschiba/volleygoals/router/calendar"
+	"github.com/fpgschiba/volleygoals/router/fitness"
+	"github.com/fpgschiba/volleygoals/router/guardians"
+	match_stats "github.com/fpgschiba/volleygoals/router/match-stats"
 	"github.com/fpgschiba/volleygoals/storage"
 	"github.com/fpgschiba/volleygoals/users"
 	"github.com/fpgschiba/volleygoals/utils"
+	log "github.com/sirupsen/logrus"
 )
 
 func CreateGoal(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
@@ -27,28 +33,69 @@ func CreateGoal(ctx context.Context, event events.APIGatewayProxyRequest) (*even
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
 	}
 
-	// Authentication and Authorization
-	if request.Type == models.GoalTypeTeam {
-		// Verify if the requester is a team admin or trainer
-		if !utils.IsTeamAdminOrTrainer(ctx, event.RequestContext.Authorizer, teamId) {
-			return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	callerId := utils.GetCognitoUsername(event.RequestContext.Authorizer)
+	var subgroup *models.Subgroup
+	if request.SubgroupId != "" {
+		if subgroup, err = db.GetSubgroupById(ctx, request.SubgroupId); err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
 		}
-	} else {
-		// For individual goals, verify the user has team access
-		if !utils.HasTeamAccess(ctx, event.RequestContext.Authorizer, teamId) {
-			return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+		if subgroup == nil || subgroup.TeamId != teamId {
+			return utils.ValidationErrorResponse(utils.MsgErrorInvalidSubgroup, []utils.FieldError{{Field: "subgroupId", Message: "subgroup does not exist in this team"}})
 		}
 	}
 
-	callerId := utils.GetCognitoUsername(event.RequestContext.Authorizer)
+	// Everyone with team access may set individual goals; team goals need the goals.create permission or, within
+	// a subgroup, being one of its trainers
+	mayAssign := utils.HasPermission(ctx, event.RequestContext.Authorizer, teamId, models.PermissionGoalsCreate) ||
+		(subgroup != nil && subgroup.HasTrainer(callerId))
+	if request.Type == models.GoalTypeTeam && !mayAssign {
+		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	}
+
 	ownerId := callerId
-	if request.OwnerId != nil && utils.IsTeamAdminOrTrainer(ctx, event.RequestContext.Authorizer, teamId) {
+	if request.OwnerId != nil && mayAssign {
 		ownerId = *request.OwnerId
 	}
-	goal, err := db.CreateGoal(ctx, seasonId, ownerId, request.Type, request.Title, request.Description)
+	if fieldErrors := validateSubgroupOwner(subgroup, request.Type, ownerId); len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidSubgroup, fieldErrors)
+	}
+	fieldErrors, err := calendar.ValidateEventLink(ctx, seasonId, request.EventId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidEventLink, fieldErrors)
+	}
+	if request.EventId != nil && *request.EventId == "" {
+		request.EventId = nil
+	}
+	metric, fieldErrors, err := buildMetric(ctx, teamId, request.Metric)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidGoalMetric, fieldErrors)
+	}
+	if metric != nil && metric.Stat == "" {
+		metric = nil
+	}
+	var tags []string
+	if normalized := models.NormalizeTags(request.Tags); len(normalized) > 0 {
+		tags = normalized
+	}
+	goal, err := db.CreateGoal(ctx, seasonId, ownerId, request.Type, request.Title, request.Description, request.EventId, metric, tags, request.SubgroupId)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
 	}
+	db.InvalidateSeasonStats(ctx, seasonId)
+	if metric != nil {
+		if err := refreshMeasurableGoals(ctx, teamId, seasonId, callerId, metric.Source); err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		if goal, err = db.GetGoalById(ctx, goal.Id); err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+	}
 	return utils.SuccessResponse(http.StatusCreated, utils.MsgSuccess, map[string]interface{}{
 		"goal": goal,
 	})
@@ -57,16 +104,10 @@ func CreateGoal(ctx context.Context, event events.APIGatewayProxyRequest) (*even
 func GetGoal(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
 	seasonId := event.PathParameters["seasonId"]
 	goalId := event.PathParameters["goalId"]
-	teamId, err := db.GetTeamIdBySeasonId(ctx, seasonId)
-	if seasonId == "" || goalId == "" || err != nil {
+	if seasonId == "" || goalId == "" {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
 	}
 
-	// Authentication and Authorization
-	if !utils.HasTeamAccess(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-
 	goal, err := db.GetGoalById(ctx, goalId)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
@@ -74,9 +115,14 @@ func GetGoal(ctx context.Context, event events.APIGatewayProxyRequest) (*events.
 	if goal == nil || goal.SeasonId != seasonId {
 		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorNotFound, nil)
 	}
+	media, err := db.ListMediaReferencesByTargetId(ctx, goalId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
 
 	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
-		"goal": goal,
+		"goal":  goal,
+		"media": media,
 	})
 }
 
@@ -92,18 +138,6 @@ func ListGoals(ctx context.Context, event events.APIGatewayProxyRequest) (*event
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
 	}
 
-	// Authorization: ensure team access via seasonId
-	teamId, err := db.GetTeamIdBySeasonId(ctx, seasonId)
-	if err != nil {
-		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
-	}
-	if teamId == "" {
-		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorNotFound, nil)
-	}
-	if !utils.HasTeamAccess(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-
 	items, count, nextCursor, hasMore, err := db.ListGoals(ctx, filter)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
@@ -173,9 +207,6 @@ func UpdateGoal(ctx context.Context, event events.APIGatewayProxyRequest) (*even
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
 	}
 
-	if !utils.HasTeamAccess(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
 	goal, err := db.GetGoalById(ctx, goalId)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
@@ -185,18 +216,80 @@ func UpdateGoal(ctx context.Context, event events.APIGatewayProxyRequest) (*even
 	}
 
 	userId := utils.GetCognitoUsername(event.RequestContext.Authorizer)
-	// Only the owner or team admin/trainer can update the goal
-	if goal.OwnerId != userId && !utils.IsTeamAdminOrTrainer(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	fieldErrors, err := calendar.ValidateEventLink(ctx, seasonId, request.EventId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidEventLink, fieldErrors)
 	}
 
-	updatedGoal, err := db.UpdateGoal(ctx, goalId, request.OwnerId, request.Title, request.Description, request.Status)
+	metric, fieldErrors, err := buildMetric(ctx, teamId, request.Metric)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidGoalMetric, fieldErrors)
+	}
+
+	var tags *[]string
+	if request.Tags != nil {
+		normalized := models.NormalizeTags(*request.Tags)
+		tags = &normalized
+	}
+
+	if request.SubgroupId != nil || (request.OwnerId != nil && goal.SubgroupId != "") {
+		subgroupId := goal.SubgroupId
+		if request.SubgroupId != nil {
+			subgroupId = *request.SubgroupId
+		}
+		var subgroup *models.Subgroup
+		if subgroupId != "" {
+			if subgroup, err = db.GetSubgroupById(ctx, subgroupId); err != nil {
+				return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+			}
+			if subgroup == nil || subgroup.TeamId != teamId {
+				return utils.ValidationErrorResponse(utils.MsgErrorInvalidSubgroup, []utils.FieldError{{Field: "subgroupId", Message: "subgroup does not exist in this team"}})
+			}
+		}
+		if subgroupId != goal.SubgroupId {
+			allowed, err := mayMoveToSubgroup(ctx, event.RequestContext.Authorizer, teamId, goal, subgroup)
+			if err != nil {
+				return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+			}
+			if !allowed {
+				return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+			}
+		}
+		ownerId := goal.OwnerId
+		if request.OwnerId != nil {
+			ownerId = *request.OwnerId
+		}
+		if fieldErrors := validateSubgroupOwner(subgroup, goal.GoalType, ownerId); len(fieldErrors) > 0 {
+			return utils.ValidationErrorResponse(utils.MsgErrorInvalidSubgroup, fieldErrors)
+		}
+	}
+
+	updatedGoal, err := db.UpdateGoal(ctx, goalId, request.OwnerId, request.Title, request.Description, request.Status, request.EventId, metric, tags, request.SubgroupId)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
 	}
+	db.InvalidateSeasonStats(ctx, seasonId)
+	// A new metric or owner changes what the goal is measured against
+	if (metric != nil && metric.Stat != "") || (request.OwnerId != nil && updatedGoal.Metric != nil) {
+		if err := refreshMeasurableGoals(ctx, teamId, seasonId, userId, updatedGoal.Metric.Source); err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		if updatedGoal, err = db.GetGoalById(ctx, goalId); err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+	}
 
 	if request.Status != nil {
 		activity.EmitGoalStatusChanged(ctx, teamId, userId, updatedGoal.Title, *request.Status, goalId)
+		if *request.Status == models.GoalStatusCompleted && goal.Status != models.GoalStatusCompleted && updatedGoal.GoalType == models.GoalTypeIndividual {
+			notifyGuardiansOfCompletedGoal(ctx, teamId, updatedGoal)
+		}
 	}
 
 	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
@@ -204,6 +297,16 @@ func UpdateGoal(ctx context.Context, event events.APIGatewayProxyRequest) (*even
 	})
 }
 
+// notifyGuardiansOfCompletedGoal tells the guardians of the goal's owner that they completed it.
+func notifyGuardiansOfCompletedGoal(ctx context.Context, teamId string, goal *models.Goal) {
+	team, err := db.GetTeamById(ctx, teamId)
+	if err != nil || team == nil {
+		log.WithError(err).WithField("teamId", teamId).Warn("failed to load team for guardian notification")
+		return
+	}
+	guardians.NotifyGuardians(ctx, goal.OwnerId, team, fmt.Sprintf("Completed the goal \"%s\".", goal.Title))
+}
+
 func DeleteGoal(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
 	seasonId := event.PathParameters["seasonId"]
 	goalId := event.PathParameters["goalId"]
@@ -212,10 +315,6 @@ func DeleteGoal(ctx context.Context, event events.APIGatewayProxyRequest) (*even
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
 	}
 
-	if !utils.HasTeamAccess(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-
 	goal, err := db.GetGoalById(ctx, goalId)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
@@ -224,20 +323,110 @@ func DeleteGoal(ctx context.Context, event events.APIGatewayProxyRequest) (*even
 		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorNotFound, nil)
 	}
 
-	userId := utils.GetCognitoUsername(event.RequestContext.Authorizer)
-	// Only the owner or team admin/trainer can delete the goal
-	if goal.OwnerId != userId && !utils.IsTeamAdminOrTrainer(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	if err := db.UnlinkGoalFromDrills(ctx, teamId, goalId); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if err := db.DeleteMediaReferencesForTarget(ctx, goalId); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
 	}
-
 	err = db.DeleteGoal(ctx, goalId)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
 	}
+	db.InvalidateSeasonStats(ctx, seasonId)
 
 	return utils.SuccessResponse(http.StatusNoContent, utils.MsgSuccess, nil)
 }
 
+// buildMetric validates a requested goal metric. A metric without stat is returned as is; on create it means no
+// metric, on update it removes the goal's metric. Fitness test metrics name a test of the team as stat and compare
+// like the test scores unless a comparison is given.
+func buildMetric(ctx context.Context, teamId string, request *GoalMetricRequest) (*models.GoalMetric, []utils.FieldError, error) {
+	if request == nil {
+		return nil, nil, nil
+	}
+	if request.Stat == "" {
+		return &models.GoalMetric{}, nil, nil
+	}
+	fieldErrors := make([]utils.FieldError, 0)
+	metric := &models.GoalMetric{
+		Source:     request.Source,
+		Stat:       request.Stat,
+		Comparison: request.Comparison,
+	}
+	if metric.Source == "" {
+		metric.Source = models.GoalMetricSourceMatchStats
+	}
+	switch metric.Source {
+	case models.GoalMetricSourceMatchStats:
+		if !match_stats.IsMatchStat(metric.Stat) {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: "metric.stat", Message: "unknown match statistic"})
+		}
+	case models.GoalMetricSourceFitnessTests:
+		test, err := db.GetFitnessTestById(ctx, metric.Stat)
+		if err != nil {
+			return nil, nil, err
+		}
+		if test == nil || test.TeamId != teamId {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: "metric.stat", Message: "unknown fitness test"})
+		} else if metric.Comparison == "" && test.Scoring == models.FitnessTestScoringLowerIsBetter {
+			metric.Comparison = models.GoalMetricComparisonAtMost
+		}
+	default:
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "metric.source", Message: "must be match_stats or fitness_tests"})
+	}
+	if metric.Comparison == "" {
+		metric.Comparison = models.GoalMetricComparisonAtLeast
+	}
+	if metric.Comparison != models.GoalMetricComparisonAtLeast && metric.Comparison != models.GoalMetricComparisonAtMost {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "metric.comparison", Message: "must be at_least or at_most"})
+	}
+	if request.Target == nil {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "metric.target", Message: "is required"})
+	} else {
+		metric.Target = *request.Target
+	}
+	return metric, fieldErrors, nil
+}
+
+// validateSubgroupOwner checks that an individual goal assigned to a subgroup belongs to one of its members. Team
+// goals and goals without subgroup are not checked.
+func validateSubgroupOwner(subgroup *models.Subgroup, goalType models.GoalType, ownerId string) []utils.FieldError {
+	if subgroup == nil || goalType != models.GoalTypeIndividual || subgroup.HasMember(ownerId) {
+		return nil
+	}
+	return []utils.FieldError{{Field: "subgroupId", Message: "goal owner is not a member of the subgroup"}}
+}
+
+// mayMoveToSubgroup reports whether the caller may move the goal to the subgroup, or out of its subgroup if nil.
+// Members with goals.manage may move any goal and owners their own individual goals. Subgroup trainers may move
+// goals out of the subgroups they train and into them.
+func mayMoveToSubgroup(ctx context.Context, authorizer map[string]interface{}, teamId string, goal *models.Goal, subgroup *models.Subgroup) (bool, error) {
+	if utils.HasPermission(ctx, authorizer, teamId, models.PermissionGoalsManage) {
+		return true, nil
+	}
+	callerId := utils.GetCognitoUsername(authorizer)
+	if goal.GoalType == models.GoalTypeIndividual && goal.OwnerId == callerId {
+		return true, nil
+	}
+	if subgroup != nil {
+		return subgroup.HasTrainer(callerId), nil
+	}
+	current, err := db.GetSubgroupById(ctx, goal.SubgroupId)
+	if err != nil {
+		return false, err
+	}
+	return current != nil && current.HasTrainer(callerId), nil
+}
+
+// refreshMeasurableGoals refreshes the season's goals measured by the given source.
+func refreshMeasurableGoals(ctx context.Context, teamId, seasonId, userId string, source models.GoalMetricSource) error {
+	if source == models.GoalMetricSourceFitnessTests {
+		return fitness.RefreshFitnessGoals(ctx, teamId, seasonId, userId)
+	}
+	return match_stats.RefreshMeasurableGoals(ctx, teamId, seasonId, userId)
+}
+
 func computeCompletionPercentage(entries []*models.Progress) int {
 	if len(entries) == 0 {
 		return 0
@@ -253,8 +442,7 @@ func computeCompletionPercentage(entries []*models.Progress) int {
 func UploadGoalFile(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
 	seasonId := event.PathParameters["seasonId"]
 	goalId := event.PathParameters["goalId"]
-	teamId, err := db.GetTeamIdBySeasonId(ctx, seasonId)
-	if seasonId == "" || goalId == "" || err != nil {
+	if seasonId == "" || goalId == "" {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
 	}
 
@@ -267,11 +455,6 @@ func UploadGoalFile(ctx context.Context, event events.APIGatewayProxyRequest) (*
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
 	}
 
-	// Authentication and Authorization
-	if !utils.HasTeamAccess(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-
 	goal, err := db.GetGoalById(ctx, goalId)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
@@ -280,12 +463,6 @@ func UploadGoalFile(ctx context.Context, event events.APIGatewayProxyRequest) (*
 		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorNotFound, nil)
 	}
 
-	userId := utils.GetCognitoUsername(event.RequestContext.Authorizer)
-	// Only the owner or team admin/trainer can upload files to the goal
-	if goal.OwnerId != userId && !utils.IsTeamAdminOrTrainer(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-
 	presignedUrl, key, err := storage.GeneratePresignedUploadURLForGoalPicture(ctx, goalId, filename, contentType, utils.PresignedURLTimeout)
 	if err != nil {
 		return nil, err
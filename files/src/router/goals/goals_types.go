@@ -3,10 +3,14 @@ package goals
 import "github.com/fpgschiba/volleygoals/models"
 
 type CreateGoalRequest struct {
-	Type        models.GoalType `json:"type"`
-	Title       string          `json:"title"`
-	Description string          `json:"description"`
-	OwnerId     *string         `json:"ownerId,omitempty"`
+	Type        models.GoalType    `json:"type"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	OwnerId     *string            `json:"ownerId,omitempty"`
+	EventId     *string            `json:"eventId,omitempty"`
+	Metric      *GoalMetricRequest `json:"metric,omitempty"`
+	Tags        []string           `json:"tags,omitempty"`
+	SubgroupId  string             `json:"subgroupId,omitempty"`
 }
 
 type UpdateGoalRequest struct {
@@ -14,6 +18,17 @@ type UpdateGoalRequest struct {
 	Title       *string            `json:"title,omitempty"`
 	Description *string            `json:"description,omitempty"`
 	Status      *models.GoalStatus `json:"status,omitempty"`
+	EventId     *string            `json:"eventId,omitempty"`
+	Metric      *GoalMetricRequest `json:"metric,omitempty"`
+	Tags        *[]string          `json:"tags,omitempty"`
+	SubgroupId  *string            `json:"subgroupId,omitempty"`
+}
+
+type GoalMetricRequest struct {
+	Source     models.GoalMetricSource     `json:"source"`
+	Stat       string                      `json:"stat"`
+	Comparison models.GoalMetricComparison `json:"comparison"`
+	Target     *float64                    `json:"target"`
 }
 
 type GoalOwner struct {
@@ -0,0 +1,467 @@
+package guardians
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpgschiba/volleygoals/db"
+	"github.com/fpgschiba/volleygoals/mail"
+	"github.com/fpgschiba/volleygoals/models"
+	"github.com/fpgschiba/volleygoals/router/activity"
+	"github.com/fpgschiba/volleygoals/users"
+	"github.com/fpgschiba/volleygoals/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+// CreateGuardianship links an existing user as guardian of the player. Platform admins and members with
+// members.manage on one of the player's teams may link guardians, and so may those with members.manage on a team
+// the player has a pending invite to, so a minor without a team can get the consent the invite needs.
+func CreateGuardianship(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	playerId := event.PathParameters["userSub"]
+	if playerId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+	var request CreateGuardianshipRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+
+	authorizer := event.RequestContext.Authorizer
+	allowed, err := managesPlayer(ctx, authorizer, playerId, models.PermissionMembersManage)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	player, err := users.GetUserBySub(ctx, playerId)
+	if err != nil && !errors.Is(err, users.ErrUserNotFound) {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if !allowed && player != nil {
+		if allowed, err = invitesPlayer(ctx, authorizer, player.Email, models.PermissionMembersManage); err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+	}
+	if !allowed {
+		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	}
+	if player == nil {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorUserNotFound, nil)
+	}
+
+	fieldErrors := make([]utils.FieldError, 0)
+	if player.Birthdate != nil && !player.IsMinor(time.Now()) {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "userSub", Message: "player is not a minor"})
+	}
+	guardianEmail := strings.TrimSpace(request.GuardianEmail)
+	var guardian *models.User
+	if guardianEmail == "" {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "guardianEmail", Message: "is required"})
+	} else {
+		guardian, err = users.GetUserByEmail(ctx, guardianEmail)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		switch {
+		case guardian == nil:
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: "guardianEmail", Message: "no user with this email"})
+		case guardian.Id == playerId:
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: "guardianEmail", Message: "must be another user"})
+		default:
+			isGuardian, err := db.IsGuardianOf(ctx, guardian.Id, playerId)
+			if err != nil {
+				return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+			}
+			if isGuardian {
+				fieldErrors = append(fieldErrors, utils.FieldError{Field: "guardianEmail", Message: "is already a guardian of the player"})
+			}
+		}
+	}
+	if len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidGuardianship, fieldErrors)
+	}
+
+	notify := true
+	if request.Notify != nil {
+		notify = *request.Notify
+	}
+	guardianship, err := db.CreateGuardianship(ctx, &models.Guardianship{
+		GuardianId: guardian.Id,
+		PlayerId:   playerId,
+		Notify:     notify,
+		CreatedBy:  utils.GetCognitoUsername(authorizer),
+	})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusCreated, utils.MsgSuccess, map[string]interface{}{
+		"guardianship": guardianship,
+	})
+}
+
+// ListGuardians returns the guardians of the player with their names. The player, their guardians, platform admins
+// and members with members.view on one of the player's teams may list them.
+func ListGuardians(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	playerId := event.PathParameters["userSub"]
+	if playerId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+	guardianships, err := db.ListGuardiansByPlayerId(ctx, playerId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	authorizer := event.RequestContext.Authorizer
+	callerId := utils.GetCognitoUsername(authorizer)
+	allowed := callerId == playerId
+	for _, g := range guardianships {
+		if g.GuardianId == callerId {
+			allowed = true
+		}
+	}
+	if !allowed {
+		if allowed, err = managesPlayer(ctx, authorizer, playerId, models.PermissionMembersView); err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+	}
+	if !allowed {
+		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	}
+
+	items := make([]map[string]interface{}, 0, len(guardianships))
+	for _, g := range guardianships {
+		item := map[string]interface{}{
+			"guardianship": g,
+		}
+		if u, err := users.GetUserBySub(ctx, g.GuardianId); err == nil {
+			name, picture := activity.ResolveActorInfo(u)
+			item["guardianName"] = name
+			item["guardianEmail"] = u.Email
+			item["guardianPicture"] = picture
+		} else if !errors.Is(err, users.ErrUserNotFound) {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		items = append(items, item)
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"items": items,
+		"count": len(items),
+	})
+}
+
+// DeleteGuardianship unlinks a guardian from the player. Guardians may step back themselves; otherwise platform
+// admins and members with members.manage on one of the player's teams may unlink them.
+func DeleteGuardianship(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	playerId := event.PathParameters["userSub"]
+	guardianshipId := event.PathParameters["guardianshipId"]
+	if playerId == "" || guardianshipId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+	guardianship, err := db.GetGuardianshipById(ctx, guardianshipId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if guardianship == nil || guardianship.PlayerId != playerId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorGuardianshipNotFound, nil)
+	}
+
+	authorizer := event.RequestContext.Authorizer
+	if guardianship.GuardianId != utils.GetCognitoUsername(authorizer) {
+		allowed, err := managesPlayer(ctx, authorizer, playerId, models.PermissionMembersManage)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		if !allowed {
+			return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+		}
+	}
+
+	if err := db.DeleteGuardianshipById(ctx, guardianshipId); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, nil)
+}
+
+// ListWards returns the players the caller is guardian of, with their active team assignments.
+func ListWards(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	callerId := utils.GetCognitoUsername(event.RequestContext.Authorizer)
+	guardianships, err := db.ListWardsByGuardianId(ctx, callerId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	items := make([]map[string]interface{}, 0, len(guardianships))
+	for _, g := range guardianships {
+		player, err := users.GetUserBySub(ctx, g.PlayerId)
+		if errors.Is(err, users.ErrUserNotFound) {
+			continue
+		}
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		assignments, err := db.GetTeamAssignmentsByUserID(ctx, g.PlayerId, false)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		if assignments == nil {
+			assignments = []*models.TeamAssignment{}
+		}
+		name, picture := activity.ResolveActorInfo(player)
+		items = append(items, map[string]interface{}{
+			"guardianship":  g,
+			"playerName":    name,
+			"playerPicture": picture,
+			"birthdate":     player.Birthdate,
+			"assignments":   assignments,
+		})
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"items": items,
+		"count": len(items),
+	})
+}
+
+// GetWardOverview returns the ward's individual goals, the progress reports they wrote and their attendance, per
+// season. The seasonId query parameter picks one season of the ward's teams; without it the active seasons of all
+// the ward's teams are returned.
+func GetWardOverview(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	playerId := event.PathParameters["playerId"]
+	if playerId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+	seasons, resp, err := wardSeasons(ctx, playerId, strings.TrimSpace(event.QueryStringParameters["seasonId"]))
+	if resp != nil {
+		return resp, err
+	}
+
+	items := make([]map[string]interface{}, 0, len(seasons))
+	for _, season := range seasons {
+		allGoals, err := db.ListAllGoalsBySeasonId(ctx, season.Id)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		goals := make([]*models.Goal, 0)
+		for _, g := range allGoals {
+			if g.GoalType == models.GoalTypeIndividual && g.OwnerId == playerId {
+				goals = append(goals, g)
+			}
+		}
+		allReports, err := db.ListAllProgressReportsBySeasonId(ctx, season.Id)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		reports := make([]*models.ProgressReport, 0)
+		for _, r := range allReports {
+			if r.AuthorId == playerId {
+				reports = append(reports, r)
+			}
+		}
+		allAttendance, err := db.ListAttendanceBySeasonId(ctx, season.Id)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		attendance := make([]*models.Attendance, 0)
+		for _, a := range allAttendance {
+			if a.UserId == playerId {
+				attendance = append(attendance, a)
+			}
+		}
+		items = append(items, map[string]interface{}{
+			"season":          season,
+			"goals":           goals,
+			"progressReports": reports,
+			"attendance":      attendance,
+		})
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"items": items,
+		"count": len(items),
+	})
+}
+
+// GiveGuardianConsent records a guardian's consent to their ward accepting a pending invite. Consent is given once
+// and cannot be changed afterwards. The invite token is not returned, the ward accepts the invite themselves.
+func GiveGuardianConsent(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	inviteId := event.PathParameters["inviteId"]
+	if inviteId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+	invite, err := db.GetInviteById(ctx, inviteId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if invite == nil {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorNotFound, nil)
+	}
+
+	callerId := utils.GetCognitoUsername(event.RequestContext.Authorizer)
+	player, err := users.GetUserByEmail(ctx, invite.Email)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	isGuardian := false
+	if player != nil {
+		if isGuardian, err = db.IsGuardianOf(ctx, callerId, player.Id); err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+	}
+	if !isGuardian {
+		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	}
+	if invite.Status != models.InviteStatusPending {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgErrorInviteAlreadyCompleted, nil)
+	}
+	if invite.ExpiresAt.Before(time.Now()) {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgErrorInviteExpired, nil)
+	}
+	if invite.GuardianConsentBy != nil {
+		return utils.ErrorResponse(http.StatusConflict, utils.MsgErrorGuardianConsentGiven, nil)
+	}
+
+	invite, err = db.GiveGuardianConsent(ctx, inviteId, callerId)
+	if errors.Is(err, db.ErrGuardianConsentClosed) {
+		return utils.ErrorResponse(http.StatusConflict, utils.MsgErrorGuardianConsentGiven, nil)
+	}
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	invite.Token = ""
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"invite": invite,
+	})
+}
+
+// NotifyGuardians emails the guardians of the player who asked to be notified. Failures are logged, the action that
+// triggered the notification has already happened.
+func NotifyGuardians(ctx context.Context, playerId string, team *models.Team, message string) {
+	emailGuardians(ctx, playerId, team, message, true)
+}
+
+// RequestGuardianConsent asks every guardian of the player to consent to the invite, whether or not they asked to
+// be notified otherwise.
+func RequestGuardianConsent(ctx context.Context, player *models.User, team *models.Team, invite *models.Invite) {
+	name, _ := activity.ResolveActorInfo(player)
+	message := fmt.Sprintf("%s has been invited to join %s and needs your consent to accept. Invite ID: %s", name, team.Name, invite.Id)
+	emailGuardians(ctx, player.Id, team, message, false)
+}
+
+func emailGuardians(ctx context.Context, playerId string, team *models.Team, message string, onlyNotified bool) {
+	guardianships, err := db.ListGuardiansByPlayerId(ctx, playerId)
+	if err != nil {
+		log.WithError(err).WithField("playerId", playerId).Warn("failed to list guardians for notification")
+		return
+	}
+	if len(guardianships) == 0 {
+		return
+	}
+	player, err := users.GetUserBySub(ctx, playerId)
+	if err != nil {
+		log.WithError(err).WithField("playerId", playerId).Warn("failed to load player for guardian notification")
+		return
+	}
+	playerName, _ := activity.ResolveActorInfo(player)
+	for _, g := range guardianships {
+		if onlyNotified && !g.Notify {
+			continue
+		}
+		u, err := users.GetUserBySub(ctx, g.GuardianId)
+		if err != nil || u == nil {
+			log.WithError(err).WithField("userId", g.GuardianId).Warn("failed to load guardian for notification")
+			continue
+		}
+		name, _ := activity.ResolveActorInfo(u)
+		if err := mail.SendGuardianEmail(ctx, u.Email, name, playerName, team, message); err != nil {
+			log.WithError(err).WithField("userId", g.GuardianId).Warn("failed to send guardian email")
+		}
+	}
+}
+
+// managesPlayer reports whether the caller is a platform admin or holds the permission on one of the player's teams.
+func managesPlayer(ctx context.Context, authorizer map[string]interface{}, playerId string, permission models.Permission) (bool, error) {
+	if utils.IsAdmin(authorizer) {
+		return true, nil
+	}
+	memberships, err := db.GetMembershipsByUserID(ctx, playerId)
+	if err != nil {
+		return false, err
+	}
+	for _, m := range memberships {
+		if utils.HasPermission(ctx, authorizer, m.TeamId, permission) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// invitesPlayer reports whether the caller holds the permission on a team that has a pending invite for the email.
+func invitesPlayer(ctx context.Context, authorizer map[string]interface{}, email string, permission models.Permission) (bool, error) {
+	invites, err := db.GetPendingInvitesByEmail(ctx, email)
+	if err != nil {
+		return false, err
+	}
+	for _, invite := range invites {
+		if utils.HasPermission(ctx, authorizer, invite.TeamId, permission) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// wardSeasons returns the season named by seasonId if it belongs to one of the ward's teams, or the active seasons
+// of all the ward's teams, newest first.
+func wardSeasons(ctx context.Context, playerId, seasonId string) ([]*models.Season, *events.APIGatewayProxyResponse, error) {
+	memberships, err := db.GetMembershipsByUserID(ctx, playerId)
+	if err != nil {
+		resp, e := utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		return nil, resp, e
+	}
+	teamIds := make(map[string]struct{}, len(memberships))
+	for _, m := range memberships {
+		teamIds[m.TeamId] = struct{}{}
+	}
+
+	if seasonId != "" {
+		season, err := db.GetSeasonById(ctx, seasonId)
+		if err != nil {
+			resp, e := utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+			return nil, resp, e
+		}
+		if season == nil {
+			resp, e := utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorSeasonNotFound, nil)
+			return nil, resp, e
+		}
+		if _, ok := teamIds[season.TeamId]; !ok {
+			resp, e := utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorSeasonNotFound, nil)
+			return nil, resp, e
+		}
+		return []*models.Season{season}, nil, nil
+	}
+
+	seasons := make([]*models.Season, 0)
+	for teamId := range teamIds {
+		teamSeasons, err := db.GetAllSeasonsByTeamId(ctx, teamId)
+		if err != nil {
+			resp, e := utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+			return nil, resp, e
+		}
+		for _, s := range teamSeasons {
+			if s.Status == models.SeasonStatusActive {
+				seasons = append(seasons, s)
+			}
+		}
+	}
+	sort.Slice(seasons, func(i, j int) bool {
+		return seasons[i].StartDate.After(seasons[j].StartDate)
+	})
+	return seasons, nil, nil
+}
@@ -0,0 +1,6 @@
+package guardians
+
+type CreateGuardianshipRequest struct {
+	GuardianEmail string `json:"guardianEmail"`
+	Notify        *bool  `json:"notify"`
+}
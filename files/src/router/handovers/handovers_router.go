@@ -0,0 +1,204 @@
+package handovers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpgschiba/volleygoals/db"
+	"github.com/fpgschiba/volleygoals/models"
+	"github.com/fpgschiba/volleygoals/router/activity"
+	"github.com/fpgschiba/volleygoals/utils"
+)
+
+// CreateHandover lets an active admin or trainer nominate another active member of the team as their successor.
+// Each nominator has at most one pending handover per team.
+func CreateHandover(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	if teamId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+	var request CreateHandoverRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+
+	callerId := utils.GetCognitoUsername(event.RequestContext.Authorizer)
+	nominator, err := db.GetTeamMemberByUserIDAndTeamID(ctx, callerId, teamId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if nominator == nil || nominator.Status != models.TeamMemberStatusActive || !nominator.Role.IsAdminOrTrainer() {
+		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	}
+
+	fieldErrors := make([]utils.FieldError, 0)
+	successorMemberId := strings.TrimSpace(request.SuccessorMemberId)
+	var successor *models.TeamMember
+	if successorMemberId == "" {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "successorMemberId", Message: "is required"})
+	} else {
+		successor, err = db.GetTeamMemberById(ctx, successorMemberId)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		switch {
+		case successor == nil || successor.TeamId != teamId || successor.Status != models.TeamMemberStatusActive:
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: "successorMemberId", Message: "is not an active member of the team"})
+		case successor.Id == nominator.Id:
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: "successorMemberId", Message: "must be another member"})
+		case successor.Role == nominator.Role:
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: "successorMemberId", Message: "already has the role"})
+		}
+	}
+	if len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidHandover, fieldErrors)
+	}
+
+	handover, err := db.CreateHandover(ctx, &models.Handover{
+		TeamId:       teamId,
+		FromUserId:   nominator.UserId,
+		FromMemberId: nominator.Id,
+		ToUserId:     successor.UserId,
+		ToMemberId:   successor.Id,
+		Role:         nominator.Role,
+	})
+	if errors.Is(err, db.ErrHandoverPending) {
+		return utils.ErrorResponse(http.StatusConflict, utils.MsgErrorHandoverPending, nil)
+	}
+	if errors.Is(err, db.ErrMembershipNotActive) {
+		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	}
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusCreated, utils.MsgSuccess, map[string]interface{}{
+		"handover": handover,
+	})
+}
+
+// ListHandovers returns the handovers of a team, newest first. The status query parameter filters by status.
+func ListHandovers(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	if teamId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+	status := models.HandoverStatus(strings.TrimSpace(event.QueryStringParameters["status"]))
+
+	all, err := db.ListHandoversByTeamId(ctx, teamId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	items := make([]*models.Handover, 0, len(all))
+	for _, h := range all {
+		if status != "" && h.Status != status {
+			continue
+		}
+		items = append(items, h)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].CreatedAt.After(items[j].CreatedAt)
+	})
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"items": items,
+		"count": len(items),
+	})
+}
+
+// RespondToHandover lets the nominated successor accept or decline a pending handover. Accepting swaps the roles of
+// the nominator and the successor in one transaction.
+func RespondToHandover(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	handover, resp, err := loadPendingHandover(ctx, event)
+	if resp != nil {
+		return resp, err
+	}
+	var request RespondToHandoverRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+	callerId := utils.GetCognitoUsername(event.RequestContext.Authorizer)
+	if handover.ToUserId != callerId {
+		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	}
+
+	if !request.Accepted {
+		err = db.CloseHandover(ctx, handover, models.HandoverStatusDeclined)
+	} else {
+		var successor *models.TeamMember
+		successor, err = db.GetTeamMemberById(ctx, handover.ToMemberId)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		if successor == nil || successor.Status != models.TeamMemberStatusActive {
+			return utils.ErrorResponse(http.StatusConflict, utils.MsgErrorHandoverChanged, nil)
+		}
+		err = db.CompleteHandover(ctx, handover, successor.Role)
+	}
+	if errors.Is(err, db.ErrHandoverChanged) {
+		return utils.ErrorResponse(http.StatusConflict, utils.MsgErrorHandoverChanged, nil)
+	}
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if request.Accepted {
+		activity.EmitHandoverAccepted(ctx, handover.TeamId, callerId, handover.Role, handover.Id)
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"handover": handover,
+	})
+}
+
+// CancelHandover withdraws a pending handover. The nominator and members with members.manage may cancel it.
+func CancelHandover(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	handover, resp, err := loadPendingHandover(ctx, event)
+	if resp != nil {
+		return resp, err
+	}
+	authorizer := event.RequestContext.Authorizer
+	if handover.FromUserId != utils.GetCognitoUsername(authorizer) && !utils.HasPermission(ctx, authorizer, handover.TeamId, models.PermissionMembersManage) {
+		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	}
+
+	err = db.CloseHandover(ctx, handover, models.HandoverStatusCancelled)
+	if errors.Is(err, db.ErrHandoverChanged) {
+		return utils.ErrorResponse(http.StatusConflict, utils.MsgErrorHandoverChanged, nil)
+	}
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"handover": handover,
+	})
+}
+
+// loadPendingHandover returns the handover named by the path if it belongs to the team and is still pending.
+func loadPendingHandover(ctx context.Context, event events.APIGatewayProxyRequest) (*models.Handover, *events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	handoverId := event.PathParameters["handoverId"]
+	if teamId == "" || handoverId == "" {
+		resp, e := utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+		return nil, resp, e
+	}
+	handover, err := db.GetHandoverById(ctx, handoverId)
+	if err != nil {
+		resp, e := utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		return nil, resp, e
+	}
+	if handover == nil || handover.TeamId != teamId {
+		resp, e := utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorHandoverNotFound, nil)
+		return nil, resp, e
+	}
+	if handover.Status != models.HandoverStatusPending {
+		resp, e := utils.ErrorResponse(http.StatusConflict, utils.MsgErrorHandoverChanged, nil)
+		return nil, resp, e
+	}
+	return handover, nil, nil
+}
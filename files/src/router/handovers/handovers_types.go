@@ -0,0 +1,9 @@
+package handovers
+
+type CreateHandoverRequest struct {
+	SuccessorMemberId string `json:"successorMemberId"`
+}
+
+type RespondToHandoverRequest struct {
+	Accepted bool `json:"accepted"`
+}
@@ -3,6 +3,7 @@ package invites
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/fpgschiba/volleygoals/mail"
 	"github.com/fpgschiba/volleygoals/models"
 	"github.com/fpgschiba/volleygoals/router/activity"
+	"github.com/fpgschiba/volleygoals/router/guardians"
 	"github.com/fpgschiba/volleygoals/users"
 	"github.com/fpgschiba/volleygoals/utils"
 )
@@ -32,6 +34,22 @@ func CompleteInvite(ctx context.Context, event events.APIGatewayProxyRequest) (*
 	if invite.ExpiresAt.Before(time.Now()) {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgErrorInviteExpired, nil)
 	}
+	var team *models.Team
+	if req.Accepted {
+		// Archived teams take no new members, the invite stays open until the team is unarchived or it expires
+		team, err = db.GetTeamById(ctx, invite.TeamId)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		if team != nil && team.Status == models.TeamStatusInactive {
+			return utils.ErrorResponse(http.StatusConflict, utils.MsgErrorTeamArchived, nil)
+		}
+		if team != nil {
+			if resp, err := checkGuardianConsent(ctx, invite, team); resp != nil {
+				return resp, err
+			}
+		}
+	}
 
 	userSub, tempPassword, created, resp, err := getOrCreateUserForInvite(ctx, req, invite)
 	if resp != nil {
@@ -45,6 +63,9 @@ func CompleteInvite(ctx context.Context, event events.APIGatewayProxyRequest) (*
 
 	if req.Accepted && member != nil {
 		activity.EmitMemberJoined(ctx, invite.TeamId, userSub)
+		if team != nil {
+			guardians.NotifyGuardians(ctx, userSub, team, fmt.Sprintf("Joined the team as %s.", member.Role))
+		}
 	}
 
 	result := map[string]interface{}{
@@ -67,16 +88,16 @@ func CreateInvite(ctx context.Context, event events.APIGatewayProxyRequest) (*ev
 	}
 	request = req
 
-	// Authorization
-	if resp, err := authorizeCreateInvite(ctx, event.RequestContext.Authorizer, request.TeamId); resp != nil {
-		return resp, err
+	// The role has to exist on the team, and nobody can invite with more permissions than they hold
+	role, err := utils.GetTeamRole(ctx, request.TeamId, request.Role)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
 	}
-
-	// Trainers cannot invite with admin role
-	if request.Role == models.TeamMemberRoleAdmin {
-		if !utils.IsAdmin(event.RequestContext.Authorizer) && !utils.IsTeamAdmin(ctx, event.RequestContext.Authorizer, request.TeamId) {
-			return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-		}
+	if role == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgErrorTeamRoleNotFound, nil)
+	}
+	if !utils.CanGrantRole(ctx, event.RequestContext.Authorizer, request.TeamId, role) {
+		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
 	}
 
 	// Token and existence check
@@ -107,10 +128,6 @@ func GetTeamInvites(ctx context.Context, event events.APIGatewayProxyRequest) (*
 	if !ok || teamId == "" {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
 	}
-	// Authorization
-	if !utils.IsAdmin(event.RequestContext.Authorizer) && !utils.HasOneRoleOnTeam(ctx, event.RequestContext.Authorizer, teamId, []models.TeamMemberRole{models.TeamMemberRoleAdmin, models.TeamMemberRoleTrainer}) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
 
 	filter, err := db.TeamInviteFilterFromQuery(event.QueryStringParameters)
 	if err != nil {
@@ -157,9 +174,6 @@ func RevokeInvite(ctx context.Context, event events.APIGatewayProxyRequest) (*ev
 	if invite == nil {
 		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorNotFound, nil)
 	}
-	if !utils.IsAdmin(event.RequestContext.Authorizer) && !utils.IsTeamAdminOrTrainer(ctx, event.RequestContext.Authorizer, invite.TeamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
 	username := utils.GetCognitoUsername(event.RequestContext.Authorizer)
 	invite, err = db.RevokeInviteById(ctx, inviteId, username)
 	if err != nil {
@@ -182,9 +196,6 @@ func ResendInvite(ctx context.Context, event events.APIGatewayProxyRequest) (*ev
 	if invite == nil {
 		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorNotFound, nil)
 	}
-	if !utils.IsAdmin(event.RequestContext.Authorizer) && !utils.IsTeamAdminOrTrainer(ctx, event.RequestContext.Authorizer, invite.TeamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
 	inviter, err := users.GetUserBySub(ctx, invite.InvitedBy)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
@@ -225,7 +236,7 @@ func GetInviteByToken(ctx context.Context, event events.APIGatewayProxyRequest)
 		}
 		return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
 			"invite": invite,
-			"member": teamMember,
+			"member": teamMember.WithoutTrainerNotes(),
 		})
 	}
 	return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorNotFound, nil)
@@ -240,13 +251,6 @@ func parseCreateInviteRequest(body string) (CreateInviteRequest, *events.APIGate
 	return req, nil, nil
 }
 
-func authorizeCreateInvite(ctx context.Context, authorizer map[string]interface{}, teamId string) (*events.APIGatewayProxyResponse, error) {
-	if !utils.IsAdmin(authorizer) && !utils.HasOneRoleOnTeam(ctx, authorizer, teamId, []models.TeamMemberRole{models.TeamMemberRoleAdmin, models.TeamMemberRoleTrainer}) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-	return nil, nil
-}
-
 func ensureInviteTokenAvailable(ctx context.Context, token string) (*events.APIGatewayProxyResponse, error) {
 	exists, err := db.DoesInviteExistByToken(ctx, token)
 	if err != nil {
@@ -388,6 +392,39 @@ func getOrCreateUserForInvite(ctx context.Context, req CompleteInviteRequest, in
 	return createdUser.Id, tempPassword, true, nil, nil
 }
 
+// checkGuardianConsent stops a minor from accepting an invite to a team that requires guardian consent until one of
+// their guardians consented. The first attempt without consent asks the guardians for it, later ones do not.
+func checkGuardianConsent(ctx context.Context, invite *models.Invite, team *models.Team) (*events.APIGatewayProxyResponse, error) {
+	if invite.GuardianConsentBy != nil {
+		return nil, nil
+	}
+	settings, err := db.GetTeamSettingsByTeamID(ctx, team.Id)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if settings == nil || !settings.RequireGuardianConsent {
+		return nil, nil
+	}
+	// Accounts created by the invite itself have no birthdate yet, so only existing users can be minors here
+	player, err := users.GetUserByEmail(ctx, invite.Email)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if player == nil || !player.IsMinor(time.Now()) {
+		return nil, nil
+	}
+	if invite.GuardianConsentRequestedAt == nil {
+		first, err := db.MarkGuardianConsentRequested(ctx, invite.Id)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		if first {
+			guardians.RequestGuardianConsent(ctx, player, team, invite)
+		}
+	}
+	return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorGuardianConsentRequired, nil)
+}
+
 func finalizeInvite(ctx context.Context, invite *models.Invite, userSub string, accepted bool, created bool) (*models.Invite, *models.TeamMember, *events.APIGatewayProxyResponse, error) {
 	invite, err := db.CompleteInvite(ctx, invite.Id, userSub, accepted)
 	if err != nil {
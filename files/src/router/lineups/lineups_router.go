@@ -0,0 +1,351 @@
+package lineups
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpgschiba/volleygoals/db"
+	"github.com/fpgschiba/volleygoals/models"
+	"github.com/fpgschiba/volleygoals/utils"
+)
+
+const rotationPositions = 6
+
+func GetLineup(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	eventId := event.PathParameters["eventId"]
+	if seasonId == "" || eventId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	match, err := db.GetEventById(ctx, eventId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if match == nil || match.SeasonId != seasonId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorEventNotFound, nil)
+	}
+
+	lineup, err := db.GetLineupByEventId(ctx, eventId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if lineup == nil {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorLineupNotFound, nil)
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"lineup": lineup,
+	})
+}
+
+// UpdateLineup creates or replaces the lineup of a match event. Lineups breaking the rules, e.g. with a player
+// named twice or the libero in the front row, are rejected with the violations as field errors.
+func UpdateLineup(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	eventId := event.PathParameters["eventId"]
+	if seasonId == "" || eventId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	match, err := db.GetEventById(ctx, eventId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if match == nil || match.SeasonId != seasonId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorEventNotFound, nil)
+	}
+	if match.Type != models.EventTypeMatch {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidLineup, []utils.FieldError{
+			{Field: "eventId", Message: "lineups can only be planned for match events"},
+		})
+	}
+
+	var request UpdateLineupRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+	if request.LiberoId != nil && *request.LiberoId == "" {
+		request.LiberoId = nil
+	}
+	if request.Substitutes == nil {
+		request.Substitutes = []string{}
+	}
+
+	members, err := db.GetMembershipsByTeamID(ctx, match.TeamId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if fieldErrors := validateLineup(members, &request); len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidLineup, fieldErrors)
+	}
+
+	starters := append([]models.LineupSlot{}, request.Starters...)
+	sort.Slice(starters, func(i, j int) bool { return starters[i].Position < starters[j].Position })
+	lineup := &models.Lineup{
+		EventId:     eventId,
+		TeamId:      match.TeamId,
+		SeasonId:    seasonId,
+		Starters:    starters,
+		LiberoId:    request.LiberoId,
+		Substitutes: request.Substitutes,
+		Notes:       strings.TrimSpace(request.Notes),
+		RecordedBy:  utils.GetCognitoUsername(event.RequestContext.Authorizer),
+	}
+	if err := db.PutLineup(ctx, lineup); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"lineup": lineup,
+	})
+}
+
+func DeleteLineup(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	eventId := event.PathParameters["eventId"]
+	if seasonId == "" || eventId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	match, err := db.GetEventById(ctx, eventId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if match == nil || match.SeasonId != seasonId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorEventNotFound, nil)
+	}
+
+	lineup, err := db.GetLineupByEventId(ctx, eventId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if lineup == nil {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorLineupNotFound, nil)
+	}
+	if err := db.DeleteLineup(ctx, eventId); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusNoContent, utils.MsgSuccess, nil)
+}
+
+// GetSeasonLineupHistory counts per player how often they were in a lineup of the season, how often they started
+// on which rotation position, and how often they were named libero or substitute.
+func GetSeasonLineupHistory(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	if seasonId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	lineups, err := db.ListLineupsBySeasonId(ctx, seasonId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	summaries := make(map[string]*PlayerLineupSummary)
+	for _, l := range lineups {
+		for _, userId := range lineupPlayers(l) {
+			if _, ok := summaries[userId]; !ok {
+				summaries[userId] = newSummary(userId)
+			}
+			addToSummary(summaries[userId], l)
+		}
+	}
+	items := make([]*PlayerLineupSummary, 0, len(summaries))
+	for _, s := range summaries {
+		items = append(items, s)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].UserId < items[j].UserId })
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"lineups": len(lineups),
+		"players": items,
+	})
+}
+
+// GetPlayerLineupHistory returns the lineups of one player in a season, ordered by match start, together with the
+// player's season summary.
+func GetPlayerLineupHistory(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	userId := event.PathParameters["userId"]
+	if seasonId == "" || userId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	lineups, err := db.ListLineupsBySeasonId(ctx, seasonId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	seasonEvents, err := db.ListAllEventsBySeasonId(ctx, seasonId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	eventsById := make(map[string]*models.Event, len(seasonEvents))
+	for _, e := range seasonEvents {
+		eventsById[e.Id] = e
+	}
+
+	summary := newSummary(userId)
+	entries := make([]PlayerLineupEntry, 0)
+	for _, l := range lineups {
+		entry := PlayerLineupEntry{EventId: l.EventId}
+		for _, slot := range l.Starters {
+			if slot.UserId == userId {
+				position := slot.Position
+				entry.Position = &position
+			}
+		}
+		entry.Libero = l.LiberoId != nil && *l.LiberoId == userId
+		entry.Substitute = contains(l.Substitutes, userId)
+		if entry.Position == nil && !entry.Libero && !entry.Substitute {
+			continue
+		}
+		if e, ok := eventsById[l.EventId]; ok {
+			entry.Title = e.Title
+			entry.StartsAt = e.StartsAt
+			entry.Opponent = e.Opponent
+		}
+		entries = append(entries, entry)
+		addToSummary(summary, l)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].StartsAt.Equal(entries[j].StartsAt) {
+			return entries[i].StartsAt.Before(entries[j].StartsAt)
+		}
+		return entries[i].EventId < entries[j].EventId
+	})
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"userId":  userId,
+		"summary": summary,
+		"matches": entries,
+	})
+}
+
+func newSummary(userId string) *PlayerLineupSummary {
+	summary := &PlayerLineupSummary{UserId: userId, StartsByPosition: make(map[int]int, rotationPositions)}
+	for position := 1; position <= rotationPositions; position++ {
+		summary.StartsByPosition[position] = 0
+	}
+	return summary
+}
+
+// addToSummary counts the player's role in the lineup. A libero placed on a back row position counts both as a
+// start and as libero.
+func addToSummary(summary *PlayerLineupSummary, lineup *models.Lineup) {
+	summary.Matches++
+	for _, slot := range lineup.Starters {
+		if slot.UserId == summary.UserId {
+			summary.Starts++
+			summary.StartsByPosition[slot.Position]++
+		}
+	}
+	if lineup.LiberoId != nil && *lineup.LiberoId == summary.UserId {
+		summary.Libero++
+	}
+	if contains(lineup.Substitutes, summary.UserId) {
+		summary.Substitute++
+	}
+}
+
+// lineupPlayers returns every player named in a lineup once.
+func lineupPlayers(lineup *models.Lineup) []string {
+	players := make([]string, 0, len(lineup.Starters)+len(lineup.Substitutes)+1)
+	for _, slot := range lineup.Starters {
+		players = append(players, slot.UserId)
+	}
+	if lineup.LiberoId != nil && !contains(players, *lineup.LiberoId) {
+		players = append(players, *lineup.LiberoId)
+	}
+	for _, userId := range lineup.Substitutes {
+		if !contains(players, userId) {
+			players = append(players, userId)
+		}
+	}
+	return players
+}
+
+// validateLineup checks that the starters fill each rotation position exactly once, that every player is an active
+// member of the team and named only once, and that the libero does not start in the front row. The libero may
+// start on a back row position.
+func validateLineup(members []*models.TeamMember, request *UpdateLineupRequest) []utils.FieldError {
+	fieldErrors := make([]utils.FieldError, 0)
+	activeMembers := make(map[string]struct{}, len(members))
+	for _, m := range members {
+		activeMembers[m.UserId] = struct{}{}
+	}
+
+	if len(request.Starters) != rotationPositions {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "starters", Message: "must name exactly six players, one per rotation position"})
+	}
+	positions := make(map[int]int, rotationPositions)
+	starters := make(map[string]int, rotationPositions)
+	for i, slot := range request.Starters {
+		field := fmt.Sprintf("starters[%d]", i)
+		if slot.Position < 1 || slot.Position > rotationPositions {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field + ".position", Message: "must be between 1 and 6"})
+		} else if first, dup := positions[slot.Position]; dup {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field + ".position", Message: fmt.Sprintf("position is already taken by starters[%d]", first)})
+		} else {
+			positions[slot.Position] = i
+		}
+		if first, dup := starters[slot.UserId]; dup {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field + ".userId", Message: fmt.Sprintf("player is already in starters[%d]", first)})
+			continue
+		}
+		starters[slot.UserId] = i
+		if _, ok := activeMembers[slot.UserId]; !ok {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field + ".userId", Message: "not an active member of the team"})
+		}
+	}
+
+	if request.LiberoId != nil {
+		liberoId := *request.LiberoId
+		if _, ok := activeMembers[liberoId]; !ok {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: "liberoId", Message: "not an active member of the team"})
+		}
+		if i, starting := starters[liberoId]; starting && isFrontRow(request.Starters[i].Position) {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: "liberoId", Message: fmt.Sprintf("libero must not start in the front row (positions 2, 3, 4), see starters[%d]", i)})
+		}
+	}
+
+	substitutes := make(map[string]int, len(request.Substitutes))
+	for i, userId := range request.Substitutes {
+		field := fmt.Sprintf("substitutes[%d]", i)
+		if first, dup := substitutes[userId]; dup {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field, Message: fmt.Sprintf("player is already in substitutes[%d]", first)})
+			continue
+		}
+		substitutes[userId] = i
+		if first, starting := starters[userId]; starting {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field, Message: fmt.Sprintf("player is already in starters[%d]", first)})
+			continue
+		}
+		if request.LiberoId != nil && *request.LiberoId == userId {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field, Message: "player is already the libero"})
+			continue
+		}
+		if _, ok := activeMembers[userId]; !ok {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field, Message: "not an active member of the team"})
+		}
+	}
+	return fieldErrors
+}
+
+func isFrontRow(position int) bool {
+	return position >= 2 && position <= 4
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package lineups
+
+import (
+	"time"
+
+	"github.com/fpgschiba/volleygoals/models"
+)
+
+type UpdateLineupRequest struct {
+	Starters    []models.LineupSlot `json:"starters"`
+	LiberoId    *string             `json:"liberoId,omitempty"`
+	Substitutes []string            `json:"substitutes"`
+	Notes       string              `json:"notes"`
+}
+
+// PlayerLineupSummary counts how often a player was part of a lineup in a season and in which role.
+type PlayerLineupSummary struct {
+	UserId           string      `json:"userId"`
+	Matches          int         `json:"matches"`
+	Starts           int         `json:"starts"`
+	StartsByPosition map[int]int `json:"startsByPosition"`
+	Libero           int         `json:"libero"`
+	Substitute       int         `json:"substitute"`
+}
+
+// PlayerLineupEntry is one match of a player's season lineups, with the match's event details. Position is nil
+// when the player did not start.
+type PlayerLineupEntry struct {
+	EventId    string    `json:"eventId"`
+	Title      string    `json:"title"`
+	StartsAt   time.Time `json:"startsAt"`
+	Opponent   *string   `json:"opponent,omitempty"`
+	Position   *int      `json:"position"`
+	Libero     bool      `json:"libero"`
+	Substitute bool      `json:"substitute"`
+}
@@ -0,0 +1,481 @@
+package match_stats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpgschiba/volleygoals/db"
+	"github.com/fpgschiba/volleygoals/models"
+	"github.com/fpgschiba/volleygoals/router/activity"
+	"github.com/fpgschiba/volleygoals/utils"
+)
+
+func GetMatchStats(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	eventId := event.PathParameters["eventId"]
+	if seasonId == "" || eventId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	match, err := db.GetEventById(ctx, eventId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if match == nil || match.SeasonId != seasonId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorEventNotFound, nil)
+	}
+
+	stats, err := db.GetMatchStatsByEventId(ctx, eventId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if stats == nil {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorMatchStatsNotFound, nil)
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"matchStats": stats,
+		"totals":     sumLines(stats.Players),
+	})
+}
+
+// UpdateMatchStats creates or replaces the box score of a match event and refreshes the measurable goals of the
+// season that are based on match statistics.
+func UpdateMatchStats(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	eventId := event.PathParameters["eventId"]
+	if seasonId == "" || eventId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	match, err := db.GetEventById(ctx, eventId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if match == nil || match.SeasonId != seasonId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorEventNotFound, nil)
+	}
+	if match.Type != models.EventTypeMatch {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidMatchStats, []utils.FieldError{
+			{Field: "eventId", Message: "box scores can only be recorded for match events"},
+		})
+	}
+
+	var request UpdateMatchStatsRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+
+	fieldErrors, err := validatePlayers(ctx, match.TeamId, request.Players)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidMatchStats, fieldErrors)
+	}
+
+	callerId := utils.GetCognitoUsername(event.RequestContext.Authorizer)
+	stats := &models.MatchStats{
+		EventId:    eventId,
+		TeamId:     match.TeamId,
+		SeasonId:   seasonId,
+		Players:    request.Players,
+		RecordedBy: callerId,
+	}
+	if err := db.PutMatchStats(ctx, stats); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if err := RefreshMeasurableGoals(ctx, match.TeamId, seasonId, callerId); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"matchStats": stats,
+		"totals":     sumLines(stats.Players),
+	})
+}
+
+func DeleteMatchStats(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	eventId := event.PathParameters["eventId"]
+	if seasonId == "" || eventId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	match, err := db.GetEventById(ctx, eventId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if match == nil || match.SeasonId != seasonId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorEventNotFound, nil)
+	}
+
+	stats, err := db.GetMatchStatsByEventId(ctx, eventId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if stats == nil {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorMatchStatsNotFound, nil)
+	}
+
+	if err := db.DeleteMatchStats(ctx, eventId); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if err := RefreshMeasurableGoals(ctx, match.TeamId, seasonId, utils.GetCognitoUsername(event.RequestContext.Authorizer)); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusNoContent, utils.MsgSuccess, nil)
+}
+
+// GetSeasonMatchStats returns the match statistics of a season summed up per player and for the whole team.
+func GetSeasonMatchStats(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	if seasonId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	matches, err := db.ListMatchStatsBySeasonId(ctx, seasonId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	team, players := seasonTotals(matches)
+
+	items := make([]PlayerSeasonTotals, 0, len(players))
+	for userId, totals := range players {
+		items = append(items, PlayerSeasonTotals{UserId: userId, Totals: totals})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].UserId < items[j].UserId })
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"team":    team,
+		"players": items,
+	})
+}
+
+// GetPlayerMatchStats returns the box score lines of one player in a season, ordered by match start, together
+// with the player's season totals.
+func GetPlayerMatchStats(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	userId := event.PathParameters["userId"]
+	if seasonId == "" || userId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	matches, err := db.ListMatchStatsBySeasonId(ctx, seasonId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	seasonEvents, err := db.ListAllEventsBySeasonId(ctx, seasonId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	eventsById := make(map[string]*models.Event, len(seasonEvents))
+	for _, e := range seasonEvents {
+		eventsById[e.Id] = e
+	}
+
+	lines := make([]PlayerMatchLine, 0)
+	own := make([]models.PlayerMatchStats, 0)
+	for _, m := range matches {
+		for _, p := range m.Players {
+			if p.UserId != userId {
+				continue
+			}
+			line := PlayerMatchLine{EventId: m.EventId, Stats: p, Totals: sumLines([]models.PlayerMatchStats{p})}
+			if e, ok := eventsById[m.EventId]; ok {
+				line.Title = e.Title
+				line.StartsAt = e.StartsAt
+				line.Opponent = e.Opponent
+			}
+			lines = append(lines, line)
+			own = append(own, p)
+		}
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		if !lines[i].StartsAt.Equal(lines[j].StartsAt) {
+			return lines[i].StartsAt.Before(lines[j].StartsAt)
+		}
+		return lines[i].EventId < lines[j].EventId
+	})
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"userId":  userId,
+		"totals":  sumLines(own),
+		"matches": lines,
+	})
+}
+
+// RefreshMeasurableGoals recomputes the current value of every goal of the season measured by match statistics.
+// Team goals of a subgroup are measured by the subgroup's members only. Goals that reach their target are
+// completed; userId is recorded as the one who changed their status.
+func RefreshMeasurableGoals(ctx context.Context, teamId, seasonId, userId string) error {
+	goals, err := db.ListAllGoalsBySeasonId(ctx, seasonId)
+	if err != nil {
+		return err
+	}
+	measured := make([]*models.Goal, 0)
+	for _, g := range goals {
+		if g.Metric != nil && g.Metric.Source == models.GoalMetricSourceMatchStats {
+			measured = append(measured, g)
+		}
+	}
+	if len(measured) == 0 {
+		return nil
+	}
+
+	matches, err := db.ListMatchStatsBySeasonId(ctx, seasonId)
+	if err != nil {
+		return err
+	}
+	team, players := seasonTotals(matches)
+
+	now := time.Now()
+	changed := false
+	subgroups := make(map[string]*models.MatchStatTotals)
+	for _, g := range measured {
+		totals := team
+		if g.GoalType == models.GoalTypeIndividual {
+			totals = players[g.OwnerId]
+		} else if g.SubgroupId != "" {
+			subgroupTotals, ok := subgroups[g.SubgroupId]
+			if !ok {
+				subgroup, err := db.GetSubgroupById(ctx, g.SubgroupId)
+				if err != nil {
+					return err
+				}
+				subgroupTotals = team
+				if subgroup != nil {
+					subgroupTotals = membersTotals(matches, subgroup.MemberIds)
+				}
+				subgroups[g.SubgroupId] = subgroupTotals
+			}
+			totals = subgroupTotals
+		}
+		current := StatValue(totals, models.MatchStat(g.Metric.Stat))
+		if sameValue(current, g.Metric.Current) {
+			continue
+		}
+
+		metric := *g.Metric
+		metric.Current = current
+		metric.MeasuredAt = &now
+		var status *models.GoalStatus
+		if metric.Reached() && (g.Status == models.GoalStatusOpen || g.Status == models.GoalStatusInProgress) {
+			completed := models.GoalStatusCompleted
+			status = &completed
+		}
+		if _, err := db.UpdateGoal(ctx, g.Id, nil, nil, nil, status, nil, &metric, nil, nil); err != nil {
+			return err
+		}
+		if status != nil {
+			activity.EmitGoalStatusChanged(ctx, teamId, userId, g.Title, *status, g.Id)
+		}
+		changed = true
+	}
+	if changed {
+		db.InvalidateSeasonStats(ctx, seasonId)
+	}
+	return nil
+}
+
+// IsMatchStat reports whether stat names a box score counter or rate a goal can be measured by.
+func IsMatchStat(stat string) bool {
+	switch models.MatchStat(stat) {
+	case models.MatchStatServeAces, models.MatchStatServeErrors,
+		models.MatchStatReceptionPerfect, models.MatchStatReceptionGood, models.MatchStatReceptionErrors,
+		models.MatchStatAttackKills, models.MatchStatAttackErrors, models.MatchStatAttackAttempts,
+		models.MatchStatBlocks, models.MatchStatDigs,
+		models.MatchStatReceptionEfficiency, models.MatchStatAttackEfficiency, models.MatchStatKillRate:
+		return true
+	}
+	return false
+}
+
+// StatValue returns the value of stat in totals. Counters are season sums; nil means nothing was recorded yet.
+func StatValue(totals *models.MatchStatTotals, stat models.MatchStat) *float64 {
+	if totals == nil || totals.Matches == 0 {
+		return nil
+	}
+	count := func(n int) *float64 {
+		v := float64(n)
+		return &v
+	}
+	switch stat {
+	case models.MatchStatServeAces:
+		return count(totals.ServeAces)
+	case models.MatchStatServeErrors:
+		return count(totals.ServeErrors)
+	case models.MatchStatReceptionPerfect:
+		return count(totals.ReceptionPerfect)
+	case models.MatchStatReceptionGood:
+		return count(totals.ReceptionGood)
+	case models.MatchStatReceptionErrors:
+		return count(totals.ReceptionErrors)
+	case models.MatchStatAttackKills:
+		return count(totals.AttackKills)
+	case models.MatchStatAttackErrors:
+		return count(totals.AttackErrors)
+	case models.MatchStatAttackAttempts:
+		return count(totals.AttackAttempts)
+	case models.MatchStatBlocks:
+		return count(totals.Blocks)
+	case models.MatchStatDigs:
+		return count(totals.Digs)
+	case models.MatchStatReceptionEfficiency:
+		return totals.ReceptionEfficiency
+	case models.MatchStatAttackEfficiency:
+		return totals.AttackEfficiency
+	case models.MatchStatKillRate:
+		return totals.KillRate
+	}
+	return nil
+}
+
+// seasonTotals sums the box scores of a season for the whole team and per player.
+func seasonTotals(matches []*models.MatchStats) (*models.MatchStatTotals, map[string]*models.MatchStatTotals) {
+	team := &models.MatchStatTotals{}
+	players := make(map[string]*models.MatchStatTotals)
+	for _, m := range matches {
+		team.Matches++
+		for _, p := range m.Players {
+			addLine(team, p)
+			t, ok := players[p.UserId]
+			if !ok {
+				t = &models.MatchStatTotals{}
+				players[p.UserId] = t
+			}
+			t.Matches++
+			addLine(t, p)
+		}
+	}
+	setRates(team)
+	for _, t := range players {
+		setRates(t)
+	}
+	return team, players
+}
+
+// membersTotals sums the lines of the members over the matches. Only matches at least one of them played count.
+func membersTotals(matches []*models.MatchStats, memberIds []string) *models.MatchStatTotals {
+	members := make(map[string]bool, len(memberIds))
+	for _, id := range memberIds {
+		members[id] = true
+	}
+	totals := &models.MatchStatTotals{}
+	for _, m := range matches {
+		played := false
+		for _, p := range m.Players {
+			if members[p.UserId] {
+				addLine(totals, p)
+				played = true
+			}
+		}
+		if played {
+			totals.Matches++
+		}
+	}
+	setRates(totals)
+	return totals
+}
+
+// sumLines sums box score lines and counts each line as one match.
+func sumLines(lines []models.PlayerMatchStats) *models.MatchStatTotals {
+	totals := &models.MatchStatTotals{Matches: len(lines)}
+	for _, p := range lines {
+		addLine(totals, p)
+	}
+	setRates(totals)
+	return totals
+}
+
+func addLine(t *models.MatchStatTotals, p models.PlayerMatchStats) {
+	t.ServeAces += p.ServeAces
+	t.ServeErrors += p.ServeErrors
+	t.ReceptionPerfect += p.ReceptionPerfect
+	t.ReceptionGood += p.ReceptionGood
+	t.ReceptionErrors += p.ReceptionErrors
+	t.AttackKills += p.AttackKills
+	t.AttackErrors += p.AttackErrors
+	t.AttackAttempts += p.AttackAttempts
+	t.Blocks += p.Blocks
+	t.Digs += p.Digs
+}
+
+// setRates derives the rates from the counters:
+// receptionEfficiency = (perfect - errors) / receptions, attackEfficiency = (kills - errors) / attempts and
+// killRate = kills / attempts.
+func setRates(t *models.MatchStatTotals) {
+	receptions := t.ReceptionPerfect + t.ReceptionGood + t.ReceptionErrors
+	t.ReceptionEfficiency = rate(t.ReceptionPerfect-t.ReceptionErrors, receptions)
+	t.AttackEfficiency = rate(t.AttackKills-t.AttackErrors, t.AttackAttempts)
+	t.KillRate = rate(t.AttackKills, t.AttackAttempts)
+}
+
+func rate(part, total int) *float64 {
+	if total == 0 {
+		return nil
+	}
+	r := math.Round(float64(part)/float64(total)*100) / 100
+	return &r
+}
+
+func sameValue(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// validatePlayers checks that every line names a distinct active member of the team and holds plausible counters.
+func validatePlayers(ctx context.Context, teamId string, players []models.PlayerMatchStats) ([]utils.FieldError, error) {
+	fieldErrors := make([]utils.FieldError, 0)
+	if len(players) == 0 {
+		return append(fieldErrors, utils.FieldError{Field: "players", Message: "at least one player is required"}), nil
+	}
+	members, err := db.GetMembershipsByTeamID(ctx, teamId)
+	if err != nil {
+		return nil, err
+	}
+	activeMembers := make(map[string]struct{}, len(members))
+	for _, m := range members {
+		activeMembers[m.UserId] = struct{}{}
+	}
+
+	seen := make(map[string]int, len(players))
+	for i, p := range players {
+		field := fmt.Sprintf("players[%d]", i)
+		if first, dup := seen[p.UserId]; dup {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field + ".userId", Message: fmt.Sprintf("player is already listed in players[%d]", first)})
+			continue
+		}
+		seen[p.UserId] = i
+		if _, ok := activeMembers[p.UserId]; !ok {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field + ".userId", Message: "not an active member of the team"})
+		}
+		counters := []struct {
+			name  string
+			value int
+		}{
+			{"serveAces", p.ServeAces}, {"serveErrors", p.ServeErrors},
+			{"receptionPerfect", p.ReceptionPerfect}, {"receptionGood", p.ReceptionGood}, {"receptionErrors", p.ReceptionErrors},
+			{"attackKills", p.AttackKills}, {"attackErrors", p.AttackErrors}, {"attackAttempts", p.AttackAttempts},
+			{"blocks", p.Blocks}, {"digs", p.Digs},
+		}
+		for _, c := range counters {
+			if c.value < 0 {
+				fieldErrors = append(fieldErrors, utils.FieldError{Field: field + "." + c.name, Message: "must not be negative"})
+			}
+		}
+		if p.AttackKills+p.AttackErrors > p.AttackAttempts {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field + ".attackAttempts", Message: "must be at least attackKills + attackErrors"})
+		}
+	}
+	return fieldErrors, nil
+}
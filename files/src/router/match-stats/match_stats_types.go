@@ -0,0 +1,26 @@
+package match_stats
+
+import (
+	"time"
+
+	"github.com/fpgschiba/volleygoals/models"
+)
+
+type UpdateMatchStatsRequest struct {
+	Players []models.PlayerMatchStats `json:"players"`
+}
+
+type PlayerSeasonTotals struct {
+	UserId string                  `json:"userId"`
+	Totals *models.MatchStatTotals `json:"totals"`
+}
+
+// PlayerMatchLine is one match of a player's season, with the match's event details.
+type PlayerMatchLine struct {
+	EventId  string                  `json:"eventId"`
+	Title    string                  `json:"title"`
+	StartsAt time.Time               `json:"startsAt"`
+	Opponent *string                 `json:"opponent,omitempty"`
+	Stats    models.PlayerMatchStats `json:"stats"`
+	Totals   *models.MatchStatTotals `json:"totals"`
+}
@@ -0,0 +1,255 @@
+package media
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpgschiba/volleygoals/db"
+	"github.com/fpgschiba/volleygoals/models"
+	"github.com/fpgschiba/volleygoals/router/comments"
+	"github.com/fpgschiba/volleygoals/storage"
+	"github.com/fpgschiba/volleygoals/utils"
+)
+
+// CreateMediaReference attaches a video clip to a goal, progress entry or comment. The parent is taken from the
+// path. Uploads get a presigned URL to put the file to; external clips only store the URL.
+func CreateMediaReference(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	var request CreateMediaReferenceRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+
+	t, err := resolveTarget(ctx, event.PathParameters)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if t == nil {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorNotFound, nil)
+	}
+	callerId := utils.GetCognitoUsername(event.RequestContext.Authorizer)
+
+	reference := &models.MediaReference{
+		TeamId:       t.teamId,
+		TargetType:   t.targetType,
+		TargetId:     t.targetId,
+		Source:       request.Source,
+		StartSeconds: request.StartSeconds,
+		EndSeconds:   request.EndSeconds,
+		Caption:      strings.TrimSpace(request.Caption),
+		CreatedBy:    callerId,
+	}
+	fieldErrors := validateSource(&request)
+	fieldErrors = append(fieldErrors, validateTimestamps(reference.StartSeconds, reference.EndSeconds)...)
+	if len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidMediaReference, fieldErrors)
+	}
+
+	uploadUrl := ""
+	if reference.Source == models.MediaSourceUpload {
+		var key string
+		uploadUrl, key, err = storage.GeneratePresignedUploadURLForMedia(ctx, t.teamId, request.Filename, request.ContentType, utils.PresignedURLTimeout)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		reference.StorageKey = key
+		reference.ContentType = strings.ToLower(request.ContentType)
+		reference.Url = storage.GetPublicFileURL(key)
+	} else {
+		reference.Url = strings.TrimSpace(request.Url)
+	}
+
+	reference, err = db.CreateMediaReference(ctx, reference)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	body := map[string]interface{}{
+		"mediaReference": reference,
+	}
+	if uploadUrl != "" {
+		body["uploadUrl"] = uploadUrl
+	}
+	return utils.SuccessResponse(http.StatusCreated, utils.MsgSuccess, body)
+}
+
+// UpdateMediaReference changes the caption or timestamps of a media reference. The clip itself cannot be replaced.
+func UpdateMediaReference(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	var request UpdateMediaReferenceRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+
+	reference, resp, err := LoadReference(ctx, event)
+	if resp != nil {
+		return resp, err
+	}
+
+	if request.StartSeconds != nil {
+		reference.StartSeconds = request.StartSeconds
+	}
+	if request.EndSeconds != nil {
+		reference.EndSeconds = request.EndSeconds
+	}
+	if request.Caption != nil {
+		reference.Caption = strings.TrimSpace(*request.Caption)
+	}
+	if fieldErrors := validateTimestamps(reference.StartSeconds, reference.EndSeconds); len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidMediaReference, fieldErrors)
+	}
+	if err := db.UpdateMediaReference(ctx, reference); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"mediaReference": reference,
+	})
+}
+
+func DeleteMediaReference(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	reference, resp, err := LoadReference(ctx, event)
+	if resp != nil {
+		return resp, err
+	}
+	if err := db.DeleteMediaReference(ctx, reference.Id); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusNoContent, utils.MsgSuccess, nil)
+}
+
+// LoadReference fetches the media reference addressed by the request. The reference has to belong to the parent
+// named in the path.
+func LoadReference(ctx context.Context, event events.APIGatewayProxyRequest) (*models.MediaReference, *events.APIGatewayProxyResponse, error) {
+	mediaId := event.PathParameters["mediaId"]
+	if mediaId == "" {
+		resp, e := utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+		return nil, resp, e
+	}
+
+	t, err := resolveTarget(ctx, event.PathParameters)
+	if err != nil {
+		resp, e := utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		return nil, resp, e
+	}
+	if t == nil {
+		resp, e := utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorNotFound, nil)
+		return nil, resp, e
+	}
+
+	reference, err := db.GetMediaReferenceById(ctx, mediaId)
+	if err != nil {
+		resp, e := utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		return nil, resp, e
+	}
+	if reference == nil || reference.TargetId != t.targetId {
+		resp, e := utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorMediaReferenceNotFound, nil)
+		return nil, resp, e
+	}
+	return reference, nil, nil
+}
+
+// resolveTarget finds the parent of the media references addressed by the path: a comment, a progress entry of a
+// report or a goal of a season. It returns nil if the parent does not exist.
+func resolveTarget(ctx context.Context, params map[string]string) (*target, error) {
+	if commentId := params["commentId"]; commentId != "" {
+		comment, err := db.GetCommentById(ctx, commentId)
+		if err != nil || comment == nil {
+			return nil, err
+		}
+		teamId, err := comments.ResolveTeamIdFromTarget(ctx, comment.CommentType, comment.TargetId)
+		if err != nil || teamId == "" {
+			return nil, err
+		}
+		return &target{targetType: models.MediaTargetTypeComment, targetId: comment.Id, teamId: teamId, ownerId: comment.AuthorId}, nil
+	}
+
+	seasonId := params["seasonId"]
+	if seasonId == "" {
+		return nil, nil
+	}
+	if progressId := params["progressId"]; progressId != "" {
+		report, err := db.GetProgressReportById(ctx, params["reportId"])
+		if err != nil || report == nil || report.SeasonId != seasonId {
+			return nil, err
+		}
+		entry, err := db.GetProgressById(ctx, progressId)
+		if err != nil || entry == nil || entry.ProgressReportId != report.Id {
+			return nil, err
+		}
+		teamId, err := db.GetTeamIdBySeasonId(ctx, seasonId)
+		if err != nil || teamId == "" {
+			return nil, err
+		}
+		return &target{targetType: models.MediaTargetTypeProgressEntry, targetId: entry.Id, teamId: teamId, ownerId: report.AuthorId}, nil
+	}
+	if goalId := params["goalId"]; goalId != "" {
+		goal, err := db.GetGoalById(ctx, goalId)
+		if err != nil || goal == nil || goal.SeasonId != seasonId {
+			return nil, err
+		}
+		teamId, err := db.GetTeamIdBySeasonId(ctx, seasonId)
+		if err != nil || teamId == "" {
+			return nil, err
+		}
+		return &target{targetType: models.MediaTargetTypeGoal, targetId: goal.Id, teamId: teamId, ownerId: goal.OwnerId}, nil
+	}
+	return nil, nil
+}
+
+// ResolveParent returns the team and the owner of the goal, progress entry or comment addressed by the path. Both
+// are empty if the parent does not exist.
+func ResolveParent(ctx context.Context, params map[string]string) (string, string, error) {
+	t, err := resolveTarget(ctx, params)
+	if err != nil || t == nil {
+		return "", "", err
+	}
+	return t.teamId, t.ownerId, nil
+}
+
+// validateSource checks that an upload names a supported video file and an external clip a http(s) URL.
+func validateSource(request *CreateMediaReferenceRequest) []utils.FieldError {
+	fieldErrors := make([]utils.FieldError, 0)
+	switch request.Source {
+	case models.MediaSourceUpload:
+		if strings.TrimSpace(request.Filename) == "" {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: "filename", Message: "is required"})
+		}
+		if request.ContentType == "" {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: "contentType", Message: "is required"})
+		} else if !storage.IsSupportedMediaFile(request.Filename, request.ContentType) {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: "contentType", Message: "must be video/mp4, video/quicktime or video/webm and match the file extension"})
+		}
+	case models.MediaSourceExternal:
+		u, err := url.Parse(strings.TrimSpace(request.Url))
+		if request.Url == "" {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: "url", Message: "is required"})
+		} else if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: "url", Message: "must be an http or https URL"})
+		}
+	default:
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "source", Message: "must be upload or external"})
+	}
+	return fieldErrors
+}
+
+// validateTimestamps checks that the clip's timestamps are not negative and that it ends after it starts.
+func validateTimestamps(startSeconds, endSeconds *float64) []utils.FieldError {
+	fieldErrors := make([]utils.FieldError, 0)
+	if startSeconds != nil && *startSeconds < 0 {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "startSeconds", Message: "must not be negative"})
+	}
+	if endSeconds != nil {
+		start := 0.0
+		if startSeconds != nil {
+			start = *startSeconds
+		}
+		if *endSeconds <= start {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: "endSeconds", Message: "must be after startSeconds"})
+		}
+	}
+	return fieldErrors
+}
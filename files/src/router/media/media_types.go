@@ -0,0 +1,28 @@
+package media
+
+import "github.com/fpgschiba/volleygoals/models"
+
+type CreateMediaReferenceRequest struct {
+	Source       models.MediaSource `json:"source"`
+	Url          string             `json:"url"`
+	Filename     string             `json:"filename"`
+	ContentType  string             `json:"contentType"`
+	StartSeconds *float64           `json:"startSeconds"`
+	EndSeconds   *float64           `json:"endSeconds"`
+	Caption      string             `json:"caption"`
+}
+
+type UpdateMediaReferenceRequest struct {
+	StartSeconds *float64 `json:"startSeconds"`
+	EndSeconds   *float64 `json:"endSeconds"`
+	Caption      *string  `json:"caption"`
+}
+
+// target is the goal, progress entry or comment a request's media references belong to.
+type target struct {
+	targetType models.MediaTargetType
+	targetId   string
+	teamId     string
+	// ownerId may manage the target's media besides members with media.manage.
+	ownerId string
+}
@@ -0,0 +1,360 @@
+package organizations
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpgschiba/volleygoals/db"
+	"github.com/fpgschiba/volleygoals/models"
+	"github.com/fpgschiba/volleygoals/users"
+	"github.com/fpgschiba/volleygoals/utils"
+)
+
+// CreateOrganization creates a club or association teams can be assigned to. Only platform admins create
+// organizations; they may name the first owner right away.
+func CreateOrganization(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	var request CreateOrganizationRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		return utils.ValidationErrorResponse(utils.MsgBadRequest, []utils.FieldError{{Field: "name", Message: "is required"}})
+	}
+	if request.OwnerId != "" {
+		user, err := users.GetUserBySub(ctx, request.OwnerId)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		if user == nil {
+			return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorUserNotFound, nil)
+		}
+	}
+
+	organization, err := db.CreateOrganization(ctx, &models.Organization{
+		Name:      name,
+		CreatedBy: utils.GetCognitoUsername(event.RequestContext.Authorizer),
+	})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	members := make([]*models.OrganizationMember, 0, 1)
+	if request.OwnerId != "" {
+		owner, err := db.AddOrganizationMember(ctx, &models.OrganizationMember{
+			OrganizationId: organization.Id,
+			UserId:         request.OwnerId,
+			Role:           models.OrganizationRoleOwner,
+		})
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		members = append(members, owner)
+	}
+
+	return utils.SuccessResponse(http.StatusCreated, utils.MsgSuccess, map[string]interface{}{
+		"organization": organization,
+		"members":      members,
+	})
+}
+
+// ListOrganizations returns all organizations to platform admins and the caller's organizations with their role
+// to everyone else.
+func ListOrganizations(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	items := make([]models.OrganizationAssignment, 0)
+	if utils.IsAdmin(event.RequestContext.Authorizer) {
+		organizations, err := db.ListOrganizations(ctx)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		for _, o := range organizations {
+			items = append(items, models.OrganizationAssignment{Organization: *o})
+		}
+	} else {
+		memberships, err := db.GetOrganizationMembershipsByUserId(ctx, utils.GetCognitoUsername(event.RequestContext.Authorizer))
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		for _, m := range memberships {
+			organization, err := db.GetOrganizationById(ctx, m.OrganizationId)
+			if err != nil {
+				return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+			}
+			if organization == nil {
+				continue
+			}
+			items = append(items, models.OrganizationAssignment{Organization: *organization, Role: m.Role})
+		}
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"items": items,
+		"count": len(items),
+	})
+}
+
+func GetOrganization(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	organization, resp, err := loadOrganization(ctx, event)
+	if resp != nil {
+		return resp, err
+	}
+	teams, err := db.ListTeamsByOrganizationId(ctx, organization.Id)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	role, err := utils.GetUserRoleOnOrganization(ctx, event.RequestContext.Authorizer, organization.Id)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	body := map[string]interface{}{
+		"organization": organization,
+		"teams":        teams,
+	}
+	if role != nil {
+		body["role"] = *role
+	}
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, body)
+}
+
+func UpdateOrganization(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	var request UpdateOrganizationRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+	organization, resp, err := loadOrganization(ctx, event)
+	if resp != nil {
+		return resp, err
+	}
+	if request.Name != nil {
+		name := strings.TrimSpace(*request.Name)
+		if name == "" {
+			return utils.ValidationErrorResponse(utils.MsgBadRequest, []utils.FieldError{{Field: "name", Message: "must not be empty"}})
+		}
+		organization.Name = name
+	}
+	if err := db.UpdateOrganization(ctx, organization); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"organization": organization,
+	})
+}
+
+// DeleteOrganization removes an organization and its memberships. Its teams have to be deleted or moved out first.
+func DeleteOrganization(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	organization, resp, err := loadOrganization(ctx, event)
+	if resp != nil {
+		return resp, err
+	}
+	teams, err := db.ListTeamsByOrganizationId(ctx, organization.Id)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if len(teams) > 0 {
+		return utils.ErrorResponse(http.StatusConflict, utils.MsgErrorOrganizationHasTeams, nil)
+	}
+	if err := db.DeleteOrganization(ctx, organization.Id); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusNoContent, utils.MsgSuccess, nil)
+}
+
+func ListOrganizationMembers(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	organization, resp, err := loadOrganization(ctx, event)
+	if resp != nil {
+		return resp, err
+	}
+	members, err := db.ListOrganizationMembers(ctx, organization.Id)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"items": members,
+		"count": len(members),
+	})
+}
+
+// AddOrganizationMember gives a user a role in the organization. Owners can only be added by owners and
+// platform admins.
+func AddOrganizationMember(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	var request AddOrganizationMemberRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+	organization, resp, err := loadOrganization(ctx, event)
+	if resp != nil {
+		return resp, err
+	}
+	if !isValidRole(request.Role) {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgErrorInvalidOrganizationRole, nil)
+	}
+	if request.Role == models.OrganizationRoleOwner && !canManageOwners(ctx, event.RequestContext.Authorizer, organization.Id) {
+		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	}
+	user, err := users.GetUserBySub(ctx, request.UserId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if user == nil {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorUserNotFound, nil)
+	}
+	existing, err := db.GetOrganizationMember(ctx, organization.Id, request.UserId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if existing != nil {
+		return utils.ErrorResponse(http.StatusConflict, utils.MsgErrorOrganizationMemberExists, nil)
+	}
+
+	member, err := db.AddOrganizationMember(ctx, &models.OrganizationMember{
+		OrganizationId: organization.Id,
+		UserId:         request.UserId,
+		Role:           request.Role,
+	})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusCreated, utils.MsgSuccess, map[string]interface{}{
+		"member": member,
+	})
+}
+
+// UpdateOrganizationMember changes a member's role. Granting or taking away the owner role is reserved for owners
+// and platform admins, and the last owner cannot be demoted.
+func UpdateOrganizationMember(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	var request UpdateOrganizationMemberRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+	organization, resp, err := loadOrganization(ctx, event)
+	if resp != nil {
+		return resp, err
+	}
+	member, resp, err := loadMember(ctx, event, organization.Id)
+	if resp != nil {
+		return resp, err
+	}
+	if !isValidRole(request.Role) {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgErrorInvalidOrganizationRole, nil)
+	}
+	if request.Role == member.Role {
+		return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+			"member": member,
+		})
+	}
+	if (request.Role == models.OrganizationRoleOwner || member.Role == models.OrganizationRoleOwner) &&
+		!canManageOwners(ctx, event.RequestContext.Authorizer, organization.Id) {
+		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	}
+	if member.Role == models.OrganizationRoleOwner {
+		if resp, err := ensureOtherOwner(ctx, organization.Id, member.Id); resp != nil {
+			return resp, err
+		}
+	}
+	member.Role = request.Role
+	if err := db.UpdateOrganizationMember(ctx, member); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"member": member,
+	})
+}
+
+// RemoveOrganizationMember takes a user out of the organization. Owners can only be removed by owners and
+// platform admins, and the last owner cannot be removed.
+func RemoveOrganizationMember(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	organization, resp, err := loadOrganization(ctx, event)
+	if resp != nil {
+		return resp, err
+	}
+	member, resp, err := loadMember(ctx, event, organization.Id)
+	if resp != nil {
+		return resp, err
+	}
+	if member.Role == models.OrganizationRoleOwner {
+		if !canManageOwners(ctx, event.RequestContext.Authorizer, organization.Id) {
+			return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+		}
+		if resp, err := ensureOtherOwner(ctx, organization.Id, member.Id); resp != nil {
+			return resp, err
+		}
+	}
+	if err := db.DeleteOrganizationMember(ctx, member.Id); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusNoContent, utils.MsgSuccess, nil)
+}
+
+// loadOrganization fetches the organization addressed by the request. Who may address it is decided by the
+// policy before the handler runs.
+func loadOrganization(ctx context.Context, event events.APIGatewayProxyRequest) (*models.Organization, *events.APIGatewayProxyResponse, error) {
+	organizationId := event.PathParameters["organizationId"]
+	if organizationId == "" {
+		resp, e := utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+		return nil, resp, e
+	}
+	organization, err := db.GetOrganizationById(ctx, organizationId)
+	if err != nil {
+		resp, e := utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		return nil, resp, e
+	}
+	if organization == nil {
+		resp, e := utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorOrganizationNotFound, nil)
+		return nil, resp, e
+	}
+	return organization, nil, nil
+}
+
+// loadMember fetches the organization membership addressed by the request.
+func loadMember(ctx context.Context, event events.APIGatewayProxyRequest, organizationId string) (*models.OrganizationMember, *events.APIGatewayProxyResponse, error) {
+	memberId := event.PathParameters["memberId"]
+	if memberId == "" {
+		resp, e := utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+		return nil, resp, e
+	}
+	member, err := db.GetOrganizationMemberById(ctx, memberId)
+	if err != nil {
+		resp, e := utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		return nil, resp, e
+	}
+	if member == nil || member.OrganizationId != organizationId {
+		resp, e := utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorOrganizationMemberNotFound, nil)
+		return nil, resp, e
+	}
+	return member, nil, nil
+}
+
+// ensureOtherOwner rejects the change if the member is the organization's only owner.
+func ensureOtherOwner(ctx context.Context, organizationId, memberId string) (*events.APIGatewayProxyResponse, error) {
+	members, err := db.ListOrganizationMembers(ctx, organizationId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	for _, m := range members {
+		if m.Id != memberId && m.Role == models.OrganizationRoleOwner {
+			return nil, nil
+		}
+	}
+	return utils.ErrorResponse(http.StatusConflict, utils.MsgErrorOrganizationLastOwner, nil)
+}
+
+func canManageOwners(ctx context.Context, authorizer map[string]interface{}, organizationId string) bool {
+	return utils.IsAdmin(authorizer) || utils.IsOrganizationOwner(ctx, authorizer, organizationId)
+}
+
+func isValidRole(role models.OrganizationRole) bool {
+	switch role {
+	case models.OrganizationRoleOwner, models.OrganizationRoleAdmin, models.OrganizationRoleViewer:
+		return true
+	}
+	return false
+}
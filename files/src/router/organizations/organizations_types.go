@@ -0,0 +1,22 @@
+package organizations
+
+import "github.com/fpgschiba/volleygoals/models"
+
+type CreateOrganizationRequest struct {
+	Name string `json:"name"`
+	// OwnerId optionally makes a user the first owner of the new organization.
+	OwnerId string `json:"ownerId"`
+}
+
+type UpdateOrganizationRequest struct {
+	Name *string `json:"name"`
+}
+
+type AddOrganizationMemberRequest struct {
+	UserId string                  `json:"userId"`
+	Role   models.OrganizationRole `json:"role"`
+}
+
+type UpdateOrganizationMemberRequest struct {
+	Role models.OrganizationRole `json:"role"`
+}
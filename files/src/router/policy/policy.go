@@ -0,0 +1,181 @@
+package policy
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/fpgschiba/volleygoals/db"
+	"github.com/fpgschiba/volleygoals/models"
+	"github.com/fpgschiba/volleygoals/utils"
+)
+
+// The reads the policy makes itself. Tests replace them together with utils.Lookups.
+var (
+	getTeamById  = db.GetTeamById
+	isGuardianOf = db.IsGuardianOf
+)
+
+// Resource is what a request acts on, as far as authorization is concerned: the team or organization it belongs
+// to and, for things members create themselves such as goals or comments, the user who owns it. Resources scoped
+// to a subgroup also name the subgroup's trainers.
+type Resource struct {
+	TeamId         string
+	OrganizationId string
+	OwnerId        string
+	Trainers       []string
+}
+
+// Resolver finds the resource a request acts on. It returns an error response if the request does not name a
+// resource or the resource does not exist.
+type Resolver func(ctx context.Context, event events.APIGatewayProxyRequest) (*Resource, *events.APIGatewayProxyResponse, error)
+
+// Requirement decides whether the caller may act on the resolved resource.
+type Requirement func(ctx context.Context, authorizer map[string]interface{}, resource *Resource) bool
+
+// Rule declares who may call a handler on which resource. Checks that depend on the request body, such as which
+// role may be granted, stay with the handler.
+type Rule struct {
+	Resource Resolver
+	Allow    Requirement
+	// Writes marks handlers called with GET that still change the team, such as presigned uploads. Handlers called
+	// with any other method always count as writes.
+	Writes bool
+	// WhileArchived lets a writing handler run on an archived team. Only the handlers managing the team itself set it.
+	WhileArchived bool
+}
+
+// Authorize evaluates the rule of the handler before it runs. It returns nil if the caller may proceed and the
+// error response to send otherwise. Handlers without a rule are denied.
+func Authorize(ctx context.Context, handler string, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	rule, ok := rules[handler]
+	if !ok {
+		log.WithField("handler", handler).Warn("no authorization rule for handler")
+		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	}
+	resource := &Resource{}
+	if rule.Resource != nil {
+		var resp *events.APIGatewayProxyResponse
+		var err error
+		resource, resp, err = rule.Resource(ctx, event)
+		if resp != nil {
+			return resp, err
+		}
+	}
+	if !rule.Allow(ctx, event.RequestContext.Authorizer, resource) {
+		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	}
+	if resource.TeamId != "" && !rule.WhileArchived && (rule.Writes || isWrite(event.HTTPMethod)) {
+		// Archived teams are read-only
+		team, err := getTeamById(ctx, resource.TeamId)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		if team != nil && team.Status == models.TeamStatusInactive {
+			return utils.ErrorResponse(http.StatusConflict, utils.MsgErrorTeamArchived, nil)
+		}
+	}
+	return nil, nil
+}
+
+// isWrite reports whether a request with the HTTP method changes data.
+func isWrite(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+// anyone lets every request through, signed in or not.
+func anyone(context.Context, map[string]interface{}, *Resource) bool {
+	return true
+}
+
+// signedIn requires a platform admin or a regular user.
+func signedIn(_ context.Context, authorizer map[string]interface{}, _ *Resource) bool {
+	return utils.IsAdmin(authorizer) || utils.IsUser(authorizer)
+}
+
+// platformAdmin requires a member of the ADMINS group.
+func platformAdmin(_ context.Context, authorizer map[string]interface{}, _ *Resource) bool {
+	return utils.IsAdmin(authorizer)
+}
+
+// teamAccess requires a platform admin, an active member of the team or a member of the organization owning it.
+func teamAccess(ctx context.Context, authorizer map[string]interface{}, resource *Resource) bool {
+	return utils.IsAdmin(authorizer) || utils.HasTeamAccess(ctx, authorizer, resource.TeamId)
+}
+
+// can requires the permission on the team.
+func can(permission models.Permission) Requirement {
+	return func(ctx context.Context, authorizer map[string]interface{}, resource *Resource) bool {
+		return utils.HasPermission(ctx, authorizer, resource.TeamId, permission)
+	}
+}
+
+// ownerOr requires team access and either ownership of the resource, being one of its subgroup's trainers or the
+// permission on the team.
+func ownerOr(permission models.Permission) Requirement {
+	return func(ctx context.Context, authorizer map[string]interface{}, resource *Resource) bool {
+		if !teamAccess(ctx, authorizer, resource) {
+			return false
+		}
+		callerId := utils.GetCognitoUsername(authorizer)
+		if resource.OwnerId != "" && resource.OwnerId == callerId {
+			return true
+		}
+		for _, trainerId := range resource.Trainers {
+			if trainerId == callerId {
+				return true
+			}
+		}
+		return utils.HasPermission(ctx, authorizer, resource.TeamId, permission)
+	}
+}
+
+// memberOr requires ownership of the resource or the permission on the team. Unlike ownerOr it does not require
+// team access, so members keep seeing their own history after leaving the team.
+func memberOr(permission models.Permission) Requirement {
+	return func(ctx context.Context, authorizer map[string]interface{}, resource *Resource) bool {
+		if resource.OwnerId != "" && resource.OwnerId == utils.GetCognitoUsername(authorizer) {
+			return true
+		}
+		return utils.HasPermission(ctx, authorizer, resource.TeamId, permission)
+	}
+}
+
+// guardian requires a platform admin or a guardian of the player owning the resource.
+func guardian(ctx context.Context, authorizer map[string]interface{}, resource *Resource) bool {
+	if utils.IsAdmin(authorizer) {
+		return true
+	}
+	if !utils.IsUser(authorizer) || resource.OwnerId == "" {
+		return false
+	}
+	isGuardian, err := isGuardianOf(ctx, utils.GetCognitoUsername(authorizer), resource.OwnerId)
+	return err == nil && isGuardian
+}
+
+// organizationRole requires a platform admin or one of the roles in the organization.
+func organizationRole(roles ...models.OrganizationRole) Requirement {
+	return func(ctx context.Context, authorizer map[string]interface{}, resource *Resource) bool {
+		return utils.IsAdmin(authorizer) || utils.HasOrganizationRole(ctx, authorizer, resource.OrganizationId, roles)
+	}
+}
+
+// anyOrganizationRole requires a platform admin or one of the roles in at least one organization.
+func anyOrganizationRole(roles ...models.OrganizationRole) Requirement {
+	return func(ctx context.Context, authorizer map[string]interface{}, _ *Resource) bool {
+		return utils.IsAdmin(authorizer) || utils.HasAnyOrganizationRole(ctx, authorizer, roles)
+	}
+}
+
+// organizationRoleOnTeam requires a platform admin or one of the roles in the organization owning the team.
+func organizationRoleOnTeam(roles ...models.OrganizationRole) Requirement {
+	return func(ctx context.Context, authorizer map[string]interface{}, resource *Resource) bool {
+		return utils.IsAdmin(authorizer) || utils.HasOrganizationRoleOnTeam(ctx, authorizer, resource.TeamId, roles)
+	}
+}
@@ -0,0 +1,486 @@
+package policy
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/fpgschiba/volleygoals/models"
+	"github.com/fpgschiba/volleygoals/utils"
+)
+
+const (
+	testTeamId         = "team-1"
+	testOrganizationId = "org-1"
+)
+
+// Callers, named by the user id they sign in with.
+const (
+	callerPlatformAdmin   = "platform-admin"
+	callerTeamAdmin       = "team-admin"
+	callerTeamTrainer     = "team-trainer"
+	callerCustomWith      = "custom-with-permission"
+	callerCustomWithout   = "custom-without-permission"
+	callerOwner           = "owner"
+	callerSubgroupTrainer = "subgroup-trainer"
+	callerOrgOwner        = "org-owner"
+	callerOrgAdmin        = "org-admin"
+	callerOrgViewer       = "org-viewer"
+	callerGuardian        = "guardian"
+	callerOutsider        = "outsider"
+	callerAnonymous       = "anonymous"
+)
+
+var allCallers = []string{
+	callerPlatformAdmin, callerTeamAdmin, callerTeamTrainer, callerCustomWith, callerCustomWithout, callerOwner,
+	callerSubgroupTrainer, callerOrgOwner, callerOrgAdmin, callerOrgViewer, callerGuardian, callerOutsider,
+	callerAnonymous,
+}
+
+// teamRoles are the roles of the callers that are members of the test team. The owner of the resource and the
+// trainer of its subgroup are plain members.
+var teamRoles = map[string]models.TeamMemberRole{
+	callerTeamAdmin:       models.TeamMemberRoleAdmin,
+	callerTeamTrainer:     models.TeamMemberRoleTrainer,
+	callerCustomWith:      "scout",
+	callerCustomWithout:   "guest",
+	callerOwner:           models.TeamMemberRoleMember,
+	callerSubgroupTrainer: models.TeamMemberRoleMember,
+}
+
+var organizationRoles = map[string]models.OrganizationRole{
+	callerOrgOwner:  models.OrganizationRoleOwner,
+	callerOrgAdmin:  models.OrganizationRoleAdmin,
+	callerOrgViewer: models.OrganizationRoleViewer,
+}
+
+// testResource is what every resolver resolves to: a goal-like resource of the owner in a subgroup of the test team.
+var testResource = Resource{
+	TeamId:         testTeamId,
+	OrganizationId: testOrganizationId,
+	OwnerId:        callerOwner,
+	Trainers:       []string{callerSubgroupTrainer},
+}
+
+// want is the expected outcome of a handler: who passes, and which permission the custom role of
+// callerCustomWith grants.
+type want struct {
+	permission models.Permission
+	allowed    []string
+}
+
+func everyone() want {
+	return want{allowed: allCallers}
+}
+
+func signedInCallers() want {
+	return want{allowed: allCallers[:len(allCallers)-1]}
+}
+
+func platformAdmins() want {
+	return want{allowed: []string{callerPlatformAdmin}}
+}
+
+func teamMembers() want {
+	return want{allowed: []string{
+		callerPlatformAdmin, callerTeamAdmin, callerTeamTrainer, callerCustomWith, callerCustomWithout, callerOwner,
+		callerSubgroupTrainer, callerOrgOwner, callerOrgAdmin, callerOrgViewer,
+	}}
+}
+
+// permitted expects the callers holding the permission. Trainers hold every permission except managing roles.
+func permitted(permission models.Permission) want {
+	allowed := []string{callerPlatformAdmin, callerTeamAdmin, callerCustomWith, callerOrgOwner, callerOrgAdmin}
+	if permission != models.PermissionRolesManage {
+		allowed = append(allowed, callerTeamTrainer)
+	}
+	return want{permission: permission, allowed: allowed}
+}
+
+func ownerOrPermitted(permission models.Permission) want {
+	w := permitted(permission)
+	w.allowed = append(w.allowed, callerOwner, callerSubgroupTrainer)
+	return w
+}
+
+func memberOrPermitted(permission models.Permission) want {
+	w := permitted(permission)
+	w.allowed = append(w.allowed, callerOwner)
+	return w
+}
+
+func organizationManagerCallers() want {
+	return want{allowed: []string{callerPlatformAdmin, callerOrgOwner, callerOrgAdmin}}
+}
+
+func organizationMemberCallers() want {
+	return want{allowed: []string{callerPlatformAdmin, callerOrgOwner, callerOrgAdmin, callerOrgViewer}}
+}
+
+func guardians() want {
+	return want{allowed: []string{callerPlatformAdmin, callerGuardian}}
+}
+
+// wants holds the expected outcome of every handler in rules.
+var wants = map[string]want{
+	"HealthCheck": everyone(),
+
+	// Teams handlers
+	"GetTeam":           teamMembers(),
+	"ListTeams":         signedInCallers(),
+	"CreateTeam":        organizationManagerCallers(),
+	"UpdateTeam":        organizationManagerCallers(),
+	"DeleteTeam":        organizationManagerCallers(),
+	"UploadTeamPicture": permitted(models.PermissionTeamUpdate),
+
+	// Organization handlers
+	"CreateOrganization":       platformAdmins(),
+	"ListOrganizations":        signedInCallers(),
+	"GetOrganization":          organizationMemberCallers(),
+	"UpdateOrganization":       organizationManagerCallers(),
+	"DeleteOrganization":       platformAdmins(),
+	"ListOrganizationMembers":  organizationMemberCallers(),
+	"AddOrganizationMember":    organizationManagerCallers(),
+	"UpdateOrganizationMember": organizationManagerCallers(),
+	"RemoveOrganizationMember": organizationManagerCallers(),
+
+	// Team settings handlers
+	"UpdateTeamSettings": platformAdmins(),
+
+	// Team role handlers
+	"ListTeamRoles":  teamMembers(),
+	"CreateTeamRole": permitted(models.PermissionRolesManage),
+	"UpdateTeamRole": permitted(models.PermissionRolesManage),
+	"DeleteTeamRole": permitted(models.PermissionRolesManage),
+
+	// Self handlers answer unauthenticated callers themselves
+	"GetSelf":           everyone(),
+	"UpdateSelf":        everyone(),
+	"UploadSelfPicture": everyone(),
+
+	// Team members handlers
+	"ListTeamMembers":      teamMembers(),
+	"AddTeamMember":        permitted(models.PermissionMembersManage),
+	"UpdateTeamMember":     permitted(models.PermissionMembersManage),
+	"RemoveTeamMember":     permitted(models.PermissionMembersManage),
+	"TransferTeamMember":   permitted(models.PermissionMembersManage),
+	"LeaveTeam":            signedInCallers(),
+	"GetMemberDevelopment": memberOrPermitted(models.PermissionMembersView),
+
+	// Handover handlers check who nominates, answers and cancels themselves
+	"CreateHandover":    teamMembers(),
+	"ListHandovers":     teamMembers(),
+	"RespondToHandover": teamMembers(),
+	"CancelHandover":    teamMembers(),
+
+	// Invites handlers
+	"CreateInvite":     permitted(models.PermissionMembersInvite),
+	"CompleteInvite":   everyone(),
+	"RevokeInvite":     permitted(models.PermissionMembersInvite),
+	"ResendInvite":     permitted(models.PermissionMembersInvite),
+	"GetInviteByToken": everyone(),
+	"GetTeamInvites":   permitted(models.PermissionMembersInvite),
+
+	// Users handlers
+	"ListUsers":  platformAdmins(),
+	"GetUser":    platformAdmins(),
+	"DeleteUser": platformAdmins(),
+	"UpdateUser": platformAdmins(),
+
+	// Club owners and admins see the memberships in their club's teams, the handler filters them
+	"ListUserMemberships": organizationManagerCallers(),
+
+	// Guardian handlers check who manages the player and who consents themselves
+	"CreateGuardianship":  signedInCallers(),
+	"ListGuardians":       signedInCallers(),
+	"DeleteGuardianship":  signedInCallers(),
+	"ListWards":           signedInCallers(),
+	"GetWardOverview":     guardians(),
+	"GiveGuardianConsent": signedInCallers(),
+
+	// Seasons handlers
+	"CreateSeason":             permitted(models.PermissionSeasonsManage),
+	"GetSeason":                teamMembers(),
+	"ListSeasons":              teamMembers(),
+	"UpdateSeason":             permitted(models.PermissionSeasonsManage),
+	"DeleteSeason":             permitted(models.PermissionSeasonsManage),
+	"GetSeasonStats":           teamMembers(),
+	"CloneSeason":              permitted(models.PermissionSeasonsManage),
+	"TransitionSeasonStatuses": everyone(),
+
+	// Questionnaire handlers
+	"GetSeasonQuestionnaire":    teamMembers(),
+	"UpdateSeasonQuestionnaire": permitted(models.PermissionQuestionnairesManage),
+	"DeleteSeasonQuestionnaire": permitted(models.PermissionQuestionnairesManage),
+
+	// Calendar handlers
+	"CreateEvent":           permitted(models.PermissionEventsManage),
+	"ListEvents":            teamMembers(),
+	"GetEvent":              teamMembers(),
+	"UpdateEvent":           permitted(models.PermissionEventsManage),
+	"DeleteEvent":           permitted(models.PermissionEventsManage),
+	"GetEventAttendance":    teamMembers(),
+	"UpdateEventAttendance": permitted(models.PermissionEventsManage),
+	"ListSeasonAttendance":  teamMembers(),
+
+	// Match statistics handlers
+	"GetMatchStats":       teamMembers(),
+	"UpdateMatchStats":    permitted(models.PermissionMatchesManage),
+	"DeleteMatchStats":    permitted(models.PermissionMatchesManage),
+	"GetSeasonMatchStats": teamMembers(),
+	"GetPlayerMatchStats": teamMembers(),
+
+	// Lineup handlers
+	"GetLineup":              teamMembers(),
+	"UpdateLineup":           permitted(models.PermissionMatchesManage),
+	"DeleteLineup":           permitted(models.PermissionMatchesManage),
+	"GetSeasonLineupHistory": teamMembers(),
+	"GetPlayerLineupHistory": teamMembers(),
+
+	// Fitness handlers
+	"CreateFitnessTest":      permitted(models.PermissionFitnessManage),
+	"ListFitnessTests":       teamMembers(),
+	"UpdateFitnessTest":      permitted(models.PermissionFitnessManage),
+	"DeleteFitnessTest":      permitted(models.PermissionFitnessManage),
+	"CreateFitnessResult":    permitted(models.PermissionFitnessManage),
+	"ListFitnessResults":     teamMembers(),
+	"DeleteFitnessResult":    permitted(models.PermissionFitnessManage),
+	"GetFitnessLeaderboard":  teamMembers(),
+	"GetMemberPersonalBests": teamMembers(),
+
+	// Skill handlers
+	"GetSkillCatalogue":     teamMembers(),
+	"UpdateSkillCatalogue":  permitted(models.PermissionSkillsAssess),
+	"CreateSkillAssessment": permitted(models.PermissionSkillsAssess),
+	"ListSkillAssessments":  teamMembers(),
+	"DeleteSkillAssessment": permitted(models.PermissionSkillsAssess),
+	"GetSkillMatrix":        teamMembers(),
+	"GetMemberSkillRadar":   ownerOrPermitted(models.PermissionSkillsAssess),
+
+	// Drill handlers
+	"CreateDrill":          permitted(models.PermissionDrillsManage),
+	"ListDrills":           teamMembers(),
+	"GetDrill":             teamMembers(),
+	"UpdateDrill":          permitted(models.PermissionDrillsManage),
+	"DeleteDrill":          permitted(models.PermissionDrillsManage),
+	"UploadDrillMedia":     permitted(models.PermissionDrillsManage),
+	"SuggestDrillsForGoal": teamMembers(),
+
+	// Subgroup handlers
+	"CreateSubgroup": permitted(models.PermissionSubgroupsManage),
+	"ListSubgroups":  teamMembers(),
+	"GetSubgroup":    teamMembers(),
+	"UpdateSubgroup": ownerOrPermitted(models.PermissionSubgroupsManage),
+	"DeleteSubgroup": permitted(models.PermissionSubgroupsManage),
+
+	// Goals handlers
+	"CreateGoal":     teamMembers(),
+	"GetGoal":        teamMembers(),
+	"ListGoals":      teamMembers(),
+	"UpdateGoal":     ownerOrPermitted(models.PermissionGoalsManage),
+	"DeleteGoal":     ownerOrPermitted(models.PermissionGoalsManage),
+	"UploadGoalFile": ownerOrPermitted(models.PermissionGoalsManage),
+
+	// Progress Report handlers
+	"CreateProgressReport":        teamMembers(),
+	"GetProgressReport":           teamMembers(),
+	"ListProgressReports":         teamMembers(),
+	"UpdateProgressReport":        ownerOrPermitted(models.PermissionReportsReview),
+	"DeleteProgressReport":        ownerOrPermitted(models.PermissionReportsReview),
+	"ExportProgressReport":        teamMembers(),
+	"ExportMemberProgressReports": teamMembers(),
+
+	// Comments handlers
+	"CreateComment":     teamMembers(),
+	"GetComment":        teamMembers(),
+	"ListComments":      teamMembers(),
+	"UpdateComment":     ownerOrPermitted(models.PermissionCommentsModerate),
+	"DeleteComment":     ownerOrPermitted(models.PermissionCommentsModerate),
+	"UploadCommentFile": ownerOrPermitted(models.PermissionCommentsModerate),
+
+	// Media reference handlers
+	"CreateMediaReference": ownerOrPermitted(models.PermissionMediaManage),
+	"UpdateMediaReference": ownerOrPermitted(models.PermissionMediaManage),
+	"DeleteMediaReference": ownerOrPermitted(models.PermissionMediaManage),
+
+	// Search handlers
+	"GlobalSearch": teamMembers(),
+
+	// Activity handlers
+	"GetTeamActivity": teamMembers(),
+}
+
+func TestEveryRuleHasExpectation(t *testing.T) {
+	for handler := range rules {
+		if _, ok := wants[handler]; !ok {
+			t.Errorf("no expectation for handler %s", handler)
+		}
+	}
+	for handler := range wants {
+		if _, ok := rules[handler]; !ok {
+			t.Errorf("expectation for handler %s without a rule", handler)
+		}
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	handlers := make([]string, 0, len(rules))
+	for handler := range rules {
+		handlers = append(handlers, handler)
+	}
+	sort.Strings(handlers)
+
+	for _, handler := range handlers {
+		w, ok := wants[handler]
+		if !ok {
+			continue
+		}
+		allowed := make(map[string]bool, len(w.allowed))
+		for _, c := range w.allowed {
+			allowed[c] = true
+		}
+		for _, caller := range allCallers {
+			t.Run(handler+"/"+caller, func(t *testing.T) {
+				stubLookups(t, w.permission, models.TeamStatusActive)
+				stubResource(t, handler)
+
+				resp, err := Authorize(context.Background(), handler, request(caller, http.MethodPost))
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if allowed[caller] && resp != nil {
+					t.Errorf("expected %s to pass, got %d", caller, resp.StatusCode)
+				}
+				if !allowed[caller] && (resp == nil || resp.StatusCode != http.StatusForbidden) {
+					t.Errorf("expected %s to be denied, got %v", caller, resp)
+				}
+			})
+		}
+	}
+}
+
+func TestAuthorizeArchivedTeam(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler string
+		method  string
+		status  int
+	}{
+		{name: "write", handler: "UpdateGoal", method: http.MethodPut, status: http.StatusConflict},
+		{name: "read", handler: "GetGoal", method: http.MethodGet},
+		{name: "write marked on GET", handler: "UploadTeamPicture", method: http.MethodGet, status: http.StatusConflict},
+		{name: "write allowed while archived", handler: "UpdateTeam", method: http.MethodPut},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubLookups(t, "", models.TeamStatusInactive)
+			stubResource(t, tt.handler)
+
+			resp, err := Authorize(context.Background(), tt.handler, request(callerPlatformAdmin, tt.method))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.status == 0 && resp != nil {
+				t.Errorf("expected to pass, got %d", resp.StatusCode)
+			}
+			if tt.status != 0 && (resp == nil || resp.StatusCode != tt.status) {
+				t.Errorf("expected %d, got %v", tt.status, resp)
+			}
+		})
+	}
+}
+
+func TestAuthorizeHandlerWithoutRule(t *testing.T) {
+	stubLookups(t, "", models.TeamStatusActive)
+
+	resp, err := Authorize(context.Background(), "NoSuchHandler", request(callerPlatformAdmin, http.MethodGet))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected %d, got %v", http.StatusForbidden, resp)
+	}
+}
+
+// request builds a request of the caller as the Cognito authorizer passes it on.
+func request(caller string, method string) events.APIGatewayProxyRequest {
+	event := events.APIGatewayProxyRequest{HTTPMethod: method}
+	if caller == callerAnonymous {
+		return event
+	}
+	group := models.UserTypeUser
+	if caller == callerPlatformAdmin {
+		group = models.UserTypeAdmin
+	}
+	event.RequestContext.Authorizer = map[string]interface{}{
+		"claims": map[string]interface{}{
+			"cognito:groups":   string(group),
+			"cognito:username": caller,
+		},
+	}
+	return event
+}
+
+// stubResource makes the handler's resolver, if it has one, resolve to testResource.
+func stubResource(t *testing.T, handler string) {
+	t.Helper()
+	rule := rules[handler]
+	if rule.Resource == nil {
+		return
+	}
+	t.Cleanup(func() { rules[handler] = rule })
+	stubbed := rule
+	stubbed.Resource = func(context.Context, events.APIGatewayProxyRequest) (*Resource, *events.APIGatewayProxyResponse, error) {
+		resource := testResource
+		return &resource, nil, nil
+	}
+	rules[handler] = stubbed
+}
+
+// stubLookups replaces the DynamoDB reads with the test team and its callers. The custom role of callerCustomWith
+// grants the permission, the one of callerCustomWithout grants nothing.
+func stubLookups(t *testing.T, permission models.Permission, status models.TeamStatus) {
+	t.Helper()
+	lookups, team, guardianOf := utils.Lookups, getTeamById, isGuardianOf
+	t.Cleanup(func() {
+		utils.Lookups, getTeamById, isGuardianOf = lookups, team, guardianOf
+	})
+
+	getTeamById = func(_ context.Context, teamId string) (*models.Team, error) {
+		if teamId != testTeamId {
+			return nil, nil
+		}
+		return &models.Team{Id: testTeamId, OrganizationId: testOrganizationId, Status: status}, nil
+	}
+	isGuardianOf = func(_ context.Context, guardianId, playerId string) (bool, error) {
+		return guardianId == callerGuardian && playerId == callerOwner, nil
+	}
+	utils.Lookups.Team = getTeamById
+	utils.Lookups.TeamMember = func(_ context.Context, userId, teamId string) (*models.TeamMember, error) {
+		role, ok := teamRoles[userId]
+		if !ok || teamId != testTeamId {
+			return nil, nil
+		}
+		return &models.TeamMember{UserId: userId, TeamId: teamId, Role: role, Status: models.TeamMemberStatusActive}, nil
+	}
+	utils.Lookups.TeamRoles = func(context.Context, string) ([]*models.TeamRole, error) {
+		granted := []models.Permission{}
+		if permission != "" {
+			granted = append(granted, permission)
+		}
+		return []*models.TeamRole{
+			{TeamId: testTeamId, Name: "scout", Permissions: granted},
+			{TeamId: testTeamId, Name: "guest", Permissions: []models.Permission{}},
+		}, nil
+	}
+	utils.Lookups.OrganizationMemberships = func(_ context.Context, userId string) ([]*models.OrganizationMember, error) {
+		role, ok := organizationRoles[userId]
+		if !ok {
+			return []*models.OrganizationMember{}, nil
+		}
+		return []*models.OrganizationMember{{OrganizationId: testOrganizationId, UserId: userId, Role: role}}, nil
+	}
+}
@@ -0,0 +1,325 @@
+package policy
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/fpgschiba/volleygoals/db"
+	"github.com/fpgschiba/volleygoals/models"
+	"github.com/fpgschiba/volleygoals/router/comments"
+	"github.com/fpgschiba/volleygoals/router/media"
+	"github.com/fpgschiba/volleygoals/utils"
+)
+
+// team resolves the team named by the teamId path parameter.
+func team(_ context.Context, event events.APIGatewayProxyRequest) (*Resource, *events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	if teamId == "" {
+		return badRequest()
+	}
+	return &Resource{TeamId: teamId}, nil, nil
+}
+
+// teamInQuery resolves the team named by the teamId query parameter.
+func teamInQuery(_ context.Context, event events.APIGatewayProxyRequest) (*Resource, *events.APIGatewayProxyResponse, error) {
+	teamId := strings.TrimSpace(event.QueryStringParameters["teamId"])
+	if teamId == "" {
+		return badRequest()
+	}
+	return &Resource{TeamId: teamId}, nil, nil
+}
+
+// teamInBody resolves the team named by the teamId field of the request body.
+func teamInBody(_ context.Context, event events.APIGatewayProxyRequest) (*Resource, *events.APIGatewayProxyResponse, error) {
+	var body struct {
+		TeamId string `json:"teamId"`
+	}
+	if err := json.Unmarshal([]byte(event.Body), &body); err != nil {
+		resp, e := utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+		return nil, resp, e
+	}
+	if body.TeamId == "" {
+		return badRequest()
+	}
+	return &Resource{TeamId: body.TeamId}, nil, nil
+}
+
+// organization resolves the organization named by the organizationId path parameter.
+func organization(_ context.Context, event events.APIGatewayProxyRequest) (*Resource, *events.APIGatewayProxyResponse, error) {
+	organizationId := event.PathParameters["organizationId"]
+	if organizationId == "" {
+		return badRequest()
+	}
+	return &Resource{OrganizationId: organizationId}, nil, nil
+}
+
+// organizationInBody resolves the organization a new team is created in. Teams created without an organization
+// resolve to an empty organization only platform admins pass.
+func organizationInBody(_ context.Context, event events.APIGatewayProxyRequest) (*Resource, *events.APIGatewayProxyResponse, error) {
+	body := event.Body
+	if event.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(event.Body)
+		if err != nil {
+			resp, e := utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+			return nil, resp, e
+		}
+		body = string(decoded)
+	}
+	var request struct {
+		OrganizationId string `json:"organizationId"`
+	}
+	if err := json.Unmarshal([]byte(body), &request); err != nil {
+		resp, e := utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+		return nil, resp, e
+	}
+	return &Resource{OrganizationId: request.OrganizationId}, nil, nil
+}
+
+// season resolves the team of the season named by the seasonId path parameter.
+func season(ctx context.Context, event events.APIGatewayProxyRequest) (*Resource, *events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	if seasonId == "" {
+		return badRequest()
+	}
+	teamId, err := db.GetTeamIdBySeasonId(ctx, seasonId)
+	if err != nil {
+		return internalError(err)
+	}
+	if teamId == "" {
+		resp, e := utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorSeasonNotFound, nil)
+		return nil, resp, e
+	}
+	return &Resource{TeamId: teamId}, nil, nil
+}
+
+// goal resolves the goal named by the path, owned by the member the goal is for and trained by the trainers of its
+// subgroup.
+func goal(ctx context.Context, event events.APIGatewayProxyRequest) (*Resource, *events.APIGatewayProxyResponse, error) {
+	resource, resp, err := season(ctx, event)
+	if resp != nil {
+		return nil, resp, err
+	}
+	goalId := event.PathParameters["goalId"]
+	if goalId == "" {
+		return badRequest()
+	}
+	goal, err := db.GetGoalById(ctx, goalId)
+	if err != nil {
+		return internalError(err)
+	}
+	if goal == nil || goal.SeasonId != event.PathParameters["seasonId"] {
+		resp, e := utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorNotFound, nil)
+		return nil, resp, e
+	}
+	resource.OwnerId = goal.OwnerId
+	if goal.SubgroupId != "" {
+		subgroup, err := db.GetSubgroupById(ctx, goal.SubgroupId)
+		if err != nil {
+			return internalError(err)
+		}
+		if subgroup != nil {
+			resource.Trainers = subgroup.TrainerIds
+		}
+	}
+	return resource, nil, nil
+}
+
+// progressReport resolves the progress report named by the path, owned by its author.
+func progressReport(ctx context.Context, event events.APIGatewayProxyRequest) (*Resource, *events.APIGatewayProxyResponse, error) {
+	resource, resp, err := season(ctx, event)
+	if resp != nil {
+		return nil, resp, err
+	}
+	reportId := event.PathParameters["reportId"]
+	if reportId == "" {
+		return badRequest()
+	}
+	report, err := db.GetProgressReportById(ctx, reportId)
+	if err != nil {
+		return internalError(err)
+	}
+	if report == nil || report.SeasonId != event.PathParameters["seasonId"] {
+		resp, e := utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorProgressReportNotFound, nil)
+		return nil, resp, e
+	}
+	resource.OwnerId = report.AuthorId
+	return resource, nil, nil
+}
+
+// calendarEvent resolves the team of the event named by the path.
+func calendarEvent(ctx context.Context, event events.APIGatewayProxyRequest) (*Resource, *events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	eventId := event.PathParameters["eventId"]
+	if seasonId == "" || eventId == "" {
+		return badRequest()
+	}
+	calendarEvent, err := db.GetEventById(ctx, eventId)
+	if err != nil {
+		return internalError(err)
+	}
+	if calendarEvent == nil || calendarEvent.SeasonId != seasonId {
+		resp, e := utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorEventNotFound, nil)
+		return nil, resp, e
+	}
+	return &Resource{TeamId: calendarEvent.TeamId}, nil, nil
+}
+
+// invite resolves the team of the invite named by the inviteId path parameter.
+func invite(ctx context.Context, event events.APIGatewayProxyRequest) (*Resource, *events.APIGatewayProxyResponse, error) {
+	inviteId := event.PathParameters["inviteId"]
+	if inviteId == "" {
+		return badRequest()
+	}
+	invite, err := db.GetInviteById(ctx, inviteId)
+	if err != nil {
+		return internalError(err)
+	}
+	if invite == nil {
+		resp, e := utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorNotFound, nil)
+		return nil, resp, e
+	}
+	return &Resource{TeamId: invite.TeamId}, nil, nil
+}
+
+// teamMember resolves the membership named by the path, owned by the member's user. Memberships that were
+// removed or left the team still resolve.
+func teamMember(ctx context.Context, event events.APIGatewayProxyRequest) (*Resource, *events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	teamMemberId := event.PathParameters["memberId"]
+	if teamId == "" || teamMemberId == "" {
+		return badRequest()
+	}
+	member, err := db.GetTeamMemberById(ctx, teamMemberId)
+	if err != nil {
+		return internalError(err)
+	}
+	if member == nil || member.TeamId != teamId {
+		resp, e := utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorUserNotFound, nil)
+		return nil, resp, e
+	}
+	return &Resource{TeamId: teamId, OwnerId: member.UserId}, nil, nil
+}
+
+// ward resolves the player named by the playerId path parameter, as owner of their own data.
+func ward(_ context.Context, event events.APIGatewayProxyRequest) (*Resource, *events.APIGatewayProxyResponse, error) {
+	playerId := event.PathParameters["playerId"]
+	if playerId == "" {
+		return badRequest()
+	}
+	return &Resource{OwnerId: playerId}, nil, nil
+}
+
+// subgroup resolves the subgroup named by the path, trained by its assistant trainers.
+func subgroup(ctx context.Context, event events.APIGatewayProxyRequest) (*Resource, *events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	subgroupId := event.PathParameters["subgroupId"]
+	if teamId == "" || subgroupId == "" {
+		return badRequest()
+	}
+	subgroup, err := db.GetSubgroupById(ctx, subgroupId)
+	if err != nil {
+		return internalError(err)
+	}
+	if subgroup == nil || subgroup.TeamId != teamId {
+		resp, e := utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorSubgroupNotFound, nil)
+		return nil, resp, e
+	}
+	return &Resource{TeamId: teamId, Trainers: subgroup.TrainerIds}, nil, nil
+}
+
+// comment resolves the comment named by the commentId path parameter, owned by its author.
+func comment(ctx context.Context, event events.APIGatewayProxyRequest) (*Resource, *events.APIGatewayProxyResponse, error) {
+	commentId := event.PathParameters["commentId"]
+	if commentId == "" {
+		return badRequest()
+	}
+	comment, err := db.GetCommentById(ctx, commentId)
+	if err != nil {
+		return internalError(err)
+	}
+	if comment == nil {
+		resp, e := utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorCommentNotFound, nil)
+		return nil, resp, e
+	}
+	resource, resp, err := commentTarget(ctx, comment.CommentType, comment.TargetId)
+	if resp != nil {
+		return nil, resp, err
+	}
+	resource.OwnerId = comment.AuthorId
+	return resource, nil, nil
+}
+
+// commentTargetInBody resolves the team of the goal or report a new comment is written on.
+func commentTargetInBody(ctx context.Context, event events.APIGatewayProxyRequest) (*Resource, *events.APIGatewayProxyResponse, error) {
+	var body struct {
+		CommentType models.CommentType `json:"commentType"`
+		TargetId    string             `json:"targetId"`
+	}
+	if err := json.Unmarshal([]byte(event.Body), &body); err != nil {
+		return badRequest()
+	}
+	if body.CommentType == "" || body.TargetId == "" {
+		return badRequest()
+	}
+	return commentTarget(ctx, body.CommentType, body.TargetId)
+}
+
+// commentTargetInQuery resolves the team of the goal or report whose comments are listed.
+func commentTargetInQuery(ctx context.Context, event events.APIGatewayProxyRequest) (*Resource, *events.APIGatewayProxyResponse, error) {
+	commentType := strings.TrimSpace(event.QueryStringParameters["commentType"])
+	targetId := strings.TrimSpace(event.QueryStringParameters["targetId"])
+	if commentType == "" || targetId == "" {
+		return badRequest()
+	}
+	return commentTarget(ctx, models.CommentType(commentType), targetId)
+}
+
+func commentTarget(ctx context.Context, commentType models.CommentType, targetId string) (*Resource, *events.APIGatewayProxyResponse, error) {
+	teamId, err := comments.ResolveTeamIdFromTarget(ctx, commentType, targetId)
+	if err != nil {
+		return internalError(err)
+	}
+	if teamId == "" {
+		resp, e := utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorNotFound, nil)
+		return nil, resp, e
+	}
+	return &Resource{TeamId: teamId}, nil, nil
+}
+
+// mediaParent resolves the goal, progress entry or comment a new media reference is attached to, owned by the
+// member owning the parent.
+func mediaParent(ctx context.Context, event events.APIGatewayProxyRequest) (*Resource, *events.APIGatewayProxyResponse, error) {
+	teamId, ownerId, err := media.ResolveParent(ctx, event.PathParameters)
+	if err != nil {
+		return internalError(err)
+	}
+	if teamId == "" {
+		resp, e := utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorNotFound, nil)
+		return nil, resp, e
+	}
+	return &Resource{TeamId: teamId, OwnerId: ownerId}, nil, nil
+}
+
+// mediaReference resolves the media reference named by the path, owned by whoever added it.
+func mediaReference(ctx context.Context, event events.APIGatewayProxyRequest) (*Resource, *events.APIGatewayProxyResponse, error) {
+	reference, resp, err := media.LoadReference(ctx, event)
+	if resp != nil {
+		return nil, resp, err
+	}
+	return &Resource{TeamId: reference.TeamId, OwnerId: reference.CreatedBy}, nil, nil
+}
+
+func badRequest() (*Resource, *events.APIGatewayProxyResponse, error) {
+	resp, e := utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	return nil, resp, e
+}
+
+func internalError(err error) (*Resource, *events.APIGatewayProxyResponse, error) {
+	resp, e := utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	return nil, resp, e
+}
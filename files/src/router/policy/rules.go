@@ -0,0 +1,198 @@
+package policy
+
+import (
+	"github.com/fpgschiba/volleygoals/models"
+)
+
+var (
+	organizationManagers = []models.OrganizationRole{models.OrganizationRoleOwner, models.OrganizationRoleAdmin}
+	organizationMembers  = []models.OrganizationRole{models.OrganizationRoleOwner, models.OrganizationRoleAdmin, models.OrganizationRoleViewer}
+)
+
+// rules holds the authorization rule of every handler the router dispatches to, keyed by handler name.
+var rules = map[string]Rule{
+	"HealthCheck": {Allow: anyone},
+
+	// Teams handlers
+	"GetTeam":           {Resource: team, Allow: teamAccess},
+	"ListTeams":         {Allow: signedIn},
+	"CreateTeam":        {Resource: organizationInBody, Allow: organizationRole(organizationManagers...)},
+	"UpdateTeam":        {Resource: team, Allow: organizationRoleOnTeam(organizationManagers...), WhileArchived: true},
+	"DeleteTeam":        {Resource: team, Allow: organizationRoleOnTeam(organizationManagers...), WhileArchived: true},
+	"UploadTeamPicture": {Resource: team, Allow: can(models.PermissionTeamUpdate), Writes: true},
+
+	// Organization handlers
+	"CreateOrganization":       {Allow: platformAdmin},
+	"ListOrganizations":        {Allow: signedIn},
+	"GetOrganization":          {Resource: organization, Allow: organizationRole(organizationMembers...)},
+	"UpdateOrganization":       {Resource: organization, Allow: organizationRole(organizationManagers...)},
+	"DeleteOrganization":       {Resource: organization, Allow: platformAdmin},
+	"ListOrganizationMembers":  {Resource: organization, Allow: organizationRole(organizationMembers...)},
+	"AddOrganizationMember":    {Resource: organization, Allow: organizationRole(organizationManagers...)},
+	"UpdateOrganizationMember": {Resource: organization, Allow: organizationRole(organizationManagers...)},
+	"RemoveOrganizationMember": {Resource: organization, Allow: organizationRole(organizationManagers...)},
+
+	// Team settings handlers
+	"UpdateTeamSettings": {Resource: team, Allow: platformAdmin},
+
+	// Team role handlers
+	"ListTeamRoles":  {Resource: team, Allow: teamAccess},
+	"CreateTeamRole": {Resource: team, Allow: can(models.PermissionRolesManage)},
+	"UpdateTeamRole": {Resource: team, Allow: can(models.PermissionRolesManage)},
+	"DeleteTeamRole": {Resource: team, Allow: can(models.PermissionRolesManage)},
+
+	// Self handlers answer unauthenticated callers themselves
+	"GetSelf":           {Allow: anyone},
+	"UpdateSelf":        {Allow: anyone},
+	"UploadSelfPicture": {Allow: anyone},
+
+	// Team members handlers
+	"ListTeamMembers":      {Resource: team, Allow: teamAccess},
+	"AddTeamMember":        {Resource: team, Allow: can(models.PermissionMembersManage)},
+	"UpdateTeamMember":     {Resource: team, Allow: can(models.PermissionMembersManage)},
+	"RemoveTeamMember":     {Resource: team, Allow: can(models.PermissionMembersManage)},
+	"TransferTeamMember":   {Resource: team, Allow: can(models.PermissionMembersManage)},
+	"LeaveTeam":            {Resource: team, Allow: signedIn},
+	"GetMemberDevelopment": {Resource: teamMember, Allow: memberOr(models.PermissionMembersView)},
+
+	// Handover handlers check who nominates, answers and cancels themselves
+	"CreateHandover":    {Resource: team, Allow: teamAccess},
+	"ListHandovers":     {Resource: team, Allow: teamAccess},
+	"RespondToHandover": {Resource: team, Allow: teamAccess},
+	"CancelHandover":    {Resource: team, Allow: teamAccess},
+
+	// Invites handlers
+	"CreateInvite":     {Resource: teamInBody, Allow: can(models.PermissionMembersInvite)},
+	"CompleteInvite":   {Allow: anyone},
+	"RevokeInvite":     {Resource: invite, Allow: can(models.PermissionMembersInvite)},
+	"ResendInvite":     {Resource: invite, Allow: can(models.PermissionMembersInvite)},
+	"GetInviteByToken": {Allow: anyone},
+	"GetTeamInvites":   {Resource: team, Allow: can(models.PermissionMembersInvite)},
+
+	// Users handlers
+	"ListUsers":  {Allow: platformAdmin},
+	"GetUser":    {Allow: platformAdmin},
+	"DeleteUser": {Allow: platformAdmin},
+	"UpdateUser": {Allow: platformAdmin},
+	// Club owners and admins see the memberships in their club's teams, the handler filters them
+	"ListUserMemberships": {Allow: anyOrganizationRole(organizationManagers...)},
+
+	// Guardian handlers check who manages the player and who consents themselves
+	"CreateGuardianship":  {Allow: signedIn},
+	"ListGuardians":       {Allow: signedIn},
+	"DeleteGuardianship":  {Allow: signedIn},
+	"ListWards":           {Allow: signedIn},
+	"GetWardOverview":     {Resource: ward, Allow: guardian},
+	"GiveGuardianConsent": {Allow: signedIn},
+
+	// Seasons handlers
+	"CreateSeason":             {Resource: teamInBody, Allow: can(models.PermissionSeasonsManage)},
+	"GetSeason":                {Resource: season, Allow: teamAccess},
+	"ListSeasons":              {Resource: teamInQuery, Allow: teamAccess},
+	"UpdateSeason":             {Resource: season, Allow: can(models.PermissionSeasonsManage)},
+	"DeleteSeason":             {Resource: season, Allow: can(models.PermissionSeasonsManage)},
+	"GetSeasonStats":           {Resource: season, Allow: teamAccess},
+	"CloneSeason":              {Resource: season, Allow: can(models.PermissionSeasonsManage)},
+	"TransitionSeasonStatuses": {Allow: anyone},
+
+	// Questionnaire handlers
+	"GetSeasonQuestionnaire":    {Resource: season, Allow: teamAccess},
+	"UpdateSeasonQuestionnaire": {Resource: season, Allow: can(models.PermissionQuestionnairesManage)},
+	"DeleteSeasonQuestionnaire": {Resource: season, Allow: can(models.PermissionQuestionnairesManage)},
+
+	// Calendar handlers
+	"CreateEvent":           {Resource: season, Allow: can(models.PermissionEventsManage)},
+	"ListEvents":            {Resource: season, Allow: teamAccess},
+	"GetEvent":              {Resource: calendarEvent, Allow: teamAccess},
+	"UpdateEvent":           {Resource: calendarEvent, Allow: can(models.PermissionEventsManage)},
+	"DeleteEvent":           {Resource: calendarEvent, Allow: can(models.PermissionEventsManage)},
+	"GetEventAttendance":    {Resource: calendarEvent, Allow: teamAccess},
+	"UpdateEventAttendance": {Resource: calendarEvent, Allow: can(models.PermissionEventsManage)},
+	"ListSeasonAttendance":  {Resource: season, Allow: teamAccess},
+
+	// Match statistics handlers
+	"GetMatchStats":       {Resource: calendarEvent, Allow: teamAccess},
+	"UpdateMatchStats":    {Resource: calendarEvent, Allow: can(models.PermissionMatchesManage)},
+	"DeleteMatchStats":    {Resource: calendarEvent, Allow: can(models.PermissionMatchesManage)},
+	"GetSeasonMatchStats": {Resource: season, Allow: teamAccess},
+	"GetPlayerMatchStats": {Resource: season, Allow: teamAccess},
+
+	// Lineup handlers
+	"GetLineup":              {Resource: calendarEvent, Allow: teamAccess},
+	"UpdateLineup":           {Resource: calendarEvent, Allow: can(models.PermissionMatchesManage)},
+	"DeleteLineup":           {Resource: calendarEvent, Allow: can(models.PermissionMatchesManage)},
+	"GetSeasonLineupHistory": {Resource: season, Allow: teamAccess},
+	"GetPlayerLineupHistory": {Resource: season, Allow: teamAccess},
+
+	// Fitness handlers
+	"CreateFitnessTest":      {Resource: team, Allow: can(models.PermissionFitnessManage)},
+	"ListFitnessTests":       {Resource: team, Allow: teamAccess},
+	"UpdateFitnessTest":      {Resource: team, Allow: can(models.PermissionFitnessManage)},
+	"DeleteFitnessTest":      {Resource: team, Allow: can(models.PermissionFitnessManage)},
+	"CreateFitnessResult":    {Resource: team, Allow: can(models.PermissionFitnessManage)},
+	"ListFitnessResults":     {Resource: team, Allow: teamAccess},
+	"DeleteFitnessResult":    {Resource: team, Allow: can(models.PermissionFitnessManage)},
+	"GetFitnessLeaderboard":  {Resource: team, Allow: teamAccess},
+	"GetMemberPersonalBests": {Resource: team, Allow: teamAccess},
+
+	// Skill handlers
+	"GetSkillCatalogue":     {Resource: team, Allow: teamAccess},
+	"UpdateSkillCatalogue":  {Resource: team, Allow: can(models.PermissionSkillsAssess)},
+	"CreateSkillAssessment": {Resource: team, Allow: can(models.PermissionSkillsAssess)},
+	"ListSkillAssessments":  {Resource: team, Allow: teamAccess},
+	"DeleteSkillAssessment": {Resource: team, Allow: can(models.PermissionSkillsAssess)},
+	"GetSkillMatrix":        {Resource: team, Allow: teamAccess},
+	"GetMemberSkillRadar":   {Resource: teamMember, Allow: ownerOr(models.PermissionSkillsAssess)},
+
+	// Drill handlers
+	"CreateDrill":          {Resource: team, Allow: can(models.PermissionDrillsManage)},
+	"ListDrills":           {Resource: team, Allow: teamAccess},
+	"GetDrill":             {Resource: team, Allow: teamAccess},
+	"UpdateDrill":          {Resource: team, Allow: can(models.PermissionDrillsManage)},
+	"DeleteDrill":          {Resource: team, Allow: can(models.PermissionDrillsManage)},
+	"UploadDrillMedia":     {Resource: team, Allow: can(models.PermissionDrillsManage), Writes: true},
+	"SuggestDrillsForGoal": {Resource: season, Allow: teamAccess},
+
+	// Subgroup handlers
+	"CreateSubgroup": {Resource: team, Allow: can(models.PermissionSubgroupsManage)},
+	"ListSubgroups":  {Resource: team, Allow: teamAccess},
+	"GetSubgroup":    {Resource: subgroup, Allow: teamAccess},
+	"UpdateSubgroup": {Resource: subgroup, Allow: ownerOr(models.PermissionSubgroupsManage)},
+	"DeleteSubgroup": {Resource: subgroup, Allow: can(models.PermissionSubgroupsManage)},
+
+	// Goals handlers
+	"CreateGoal":     {Resource: season, Allow: teamAccess},
+	"GetGoal":        {Resource: goal, Allow: teamAccess},
+	"ListGoals":      {Resource: season, Allow: teamAccess},
+	"UpdateGoal":     {Resource: goal, Allow: ownerOr(models.PermissionGoalsManage)},
+	"DeleteGoal":     {Resource: goal, Allow: ownerOr(models.PermissionGoalsManage)},
+	"UploadGoalFile": {Resource: goal, Allow: ownerOr(models.PermissionGoalsManage), Writes: true},
+
+	// Progress Report handlers
+	"CreateProgressReport":        {Resource: season, Allow: teamAccess},
+	"GetProgressReport":           {Resource: progressReport, Allow: teamAccess},
+	"ListProgressReports":         {Resource: season, Allow: teamAccess},
+	"UpdateProgressReport":        {Resource: progressReport, Allow: ownerOr(models.PermissionReportsReview)},
+	"DeleteProgressReport":        {Resource: progressReport, Allow: ownerOr(models.PermissionReportsReview)},
+	"ExportProgressReport":        {Resource: season, Allow: teamAccess},
+	"ExportMemberProgressReports": {Resource: season, Allow: teamAccess},
+
+	// Comments handlers
+	"CreateComment":     {Resource: commentTargetInBody, Allow: teamAccess},
+	"GetComment":        {Resource: comment, Allow: teamAccess},
+	"ListComments":      {Resource: commentTargetInQuery, Allow: teamAccess},
+	"UpdateComment":     {Resource: comment, Allow: ownerOr(models.PermissionCommentsModerate)},
+	"DeleteComment":     {Resource: comment, Allow: ownerOr(models.PermissionCommentsModerate)},
+	"UploadCommentFile": {Resource: comment, Allow: ownerOr(models.PermissionCommentsModerate), Writes: true},
+
+	// Media reference handlers
+	"CreateMediaReference": {Resource: mediaParent, Allow: ownerOr(models.PermissionMediaManage)},
+	"UpdateMediaReference": {Resource: mediaReference, Allow: ownerOr(models.PermissionMediaManage)},
+	"DeleteMediaReference": {Resource: mediaReference, Allow: ownerOr(models.PermissionMediaManage)},
+
+	// Search handlers
+	"GlobalSearch": {Resource: teamInQuery, Allow: teamAccess},
+
+	// Activity handlers
+	"GetTeamActivity": {Resource: team, Allow: teamAccess},
+}
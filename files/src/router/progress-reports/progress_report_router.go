@@ -3,14 +3,21 @@ package progress_reports
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
+	"sort"
+	"strconv"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/fpgschiba/volleygoals/db"
+	"github.com/fpgschiba/volleygoals/export"
 	"github.com/fpgschiba/volleygoals/models"
 	"github.com/fpgschiba/volleygoals/router/activity"
+	"github.com/fpgschiba/volleygoals/router/calendar"
+	"github.com/fpgschiba/volleygoals/router/questionnaires"
+	"github.com/fpgschiba/volleygoals/storage"
 	"github.com/fpgschiba/volleygoals/users"
 	"github.com/fpgschiba/volleygoals/utils"
 )
@@ -29,10 +36,6 @@ func CreateProgressReport(ctx context.Context, event events.APIGatewayProxyReque
 		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorNotFound, nil)
 	}
 
-	if !utils.HasTeamAccess(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-
 	var request CreateProgressReportRequest
 	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
@@ -60,15 +63,42 @@ func CreateProgressReport(ctx context.Context, event events.APIGatewayProxyReque
 		authorPicture = user.Picture
 	}
 
+	questionnaire, err := db.GetQuestionnaireBySeasonId(ctx, seasonId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if err := questionnaires.ValidateAnswers(questionnaire, request.Answers, true); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgErrorInvalidAnswers, err)
+	}
+
+	fieldErrors, err := validateProgressEntries(ctx, seasonId, authorId, request.Progress)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidProgressEntries, fieldErrors)
+	}
+	linkErrors, err := calendar.ValidateEventLink(ctx, seasonId, request.EventId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if len(linkErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidEventLink, linkErrors)
+	}
+	if request.EventId != nil && *request.EventId == "" {
+		request.EventId = nil
+	}
+
 	var entries []db.ProgressEntry
 	for _, p := range request.Progress {
 		entries = append(entries, db.ProgressEntry{GoalId: p.GoalId, Rating: p.Rating, Details: p.Details})
 	}
 
-	report, err := db.CreateProgressReport(ctx, seasonId, authorId, request.Summary, request.Details, request.OverallDetails, entries, authorName, authorPicture)
+	report, err := db.CreateProgressReport(ctx, seasonId, authorId, request.Summary, request.Details, request.OverallDetails, entries, request.Answers, authorName, authorPicture, request.EventId)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
 	}
+	db.InvalidateSeasonStats(ctx, seasonId)
 
 	activity.EmitProgressReportCreated(ctx, teamId, authorId, report.Id)
 
@@ -84,18 +114,6 @@ func GetProgressReport(ctx context.Context, event events.APIGatewayProxyRequest)
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
 	}
 
-	teamId, err := db.GetTeamIdBySeasonId(ctx, seasonId)
-	if err != nil {
-		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
-	}
-	if teamId == "" {
-		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorNotFound, nil)
-	}
-
-	if !utils.HasTeamAccess(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-
 	report, err := db.GetProgressReportById(ctx, reportId)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
@@ -126,17 +144,21 @@ func GetProgressReport(ctx context.Context, event events.APIGatewayProxyRequest)
 		}
 	}
 
-	entries := make([]*models.Progress, 0)
 	fetchedEntries, err := db.ListProgressEntriesByReportId(ctx, reportId)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
 	}
-	if fetchedEntries != nil {
-		entries = fetchedEntries
+	entries := make([]ProgressWithMedia, 0, len(fetchedEntries))
+	for _, e := range fetchedEntries {
+		media, err := db.ListMediaReferencesByTargetId(ctx, e.Id)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		entries = append(entries, ProgressWithMedia{e, media})
 	}
 
 	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
-		"progressReport": ProgressReportWithProgress{report, entries},
+		"progressReport": ProgressReportWithMedia{report, entries},
 	})
 }
 
@@ -146,18 +168,6 @@ func ListProgressReports(ctx context.Context, event events.APIGatewayProxyReques
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
 	}
 
-	teamId, err := db.GetTeamIdBySeasonId(ctx, seasonId)
-	if err != nil {
-		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
-	}
-	if teamId == "" {
-		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorNotFound, nil)
-	}
-
-	if !utils.HasTeamAccess(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-
 	filter, err := db.ProgressReportFilterFromQuery(event.QueryStringParameters)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
@@ -250,18 +260,6 @@ func UpdateProgressReport(ctx context.Context, event events.APIGatewayProxyReque
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
 	}
 
-	teamId, err := db.GetTeamIdBySeasonId(ctx, seasonId)
-	if err != nil {
-		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
-	}
-	if teamId == "" {
-		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorNotFound, nil)
-	}
-
-	if !utils.HasTeamAccess(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-
 	report, err := db.GetProgressReportById(ctx, reportId)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
@@ -270,25 +268,59 @@ func UpdateProgressReport(ctx context.Context, event events.APIGatewayProxyReque
 		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorProgressReportNotFound, nil)
 	}
 
-	userId := utils.GetCognitoUsername(event.RequestContext.Authorizer)
-	if report.AuthorId != userId && !utils.IsTeamAdminOrTrainer(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-
 	var request UpdateProgressReportRequest
 	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
 	}
 
+	if request.Answers != nil {
+		questionnaire, err := db.GetQuestionnaireBySeasonId(ctx, seasonId)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		if err := questionnaires.ValidateAnswers(questionnaire, request.Answers, true); err != nil {
+			return utils.ErrorResponse(http.StatusBadRequest, utils.MsgErrorInvalidAnswers, err)
+		}
+	}
+
+	// Entries are validated against the report author, not the caller, since trainers may edit members' reports.
+	fieldErrors, err := validateProgressEntries(ctx, seasonId, report.AuthorId, request.Progress)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidProgressEntries, fieldErrors)
+	}
+	linkErrors, err := calendar.ValidateEventLink(ctx, seasonId, request.EventId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if len(linkErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidEventLink, linkErrors)
+	}
+
 	var entries []db.ProgressEntry
 	for _, p := range request.Progress {
 		entries = append(entries, db.ProgressEntry{GoalId: p.GoalId, Rating: p.Rating, Details: p.Details})
 	}
+	// Replacing the entries gives them new IDs, so their media references have to be moved along
+	var replacedEntries []*models.Progress
+	if entries != nil {
+		if replacedEntries, err = db.ListProgressEntriesByReportId(ctx, reportId); err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+	}
 
-	updatedReport, err := db.UpdateProgressReport(ctx, reportId, request.Summary, request.Details, request.OverallDetails, entries)
+	updatedReport, err := db.UpdateProgressReport(ctx, reportId, request.Summary, request.Details, request.OverallDetails, entries, request.Answers, request.EventId)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
 	}
+	if len(replacedEntries) > 0 {
+		if err := moveEntryMedia(ctx, reportId, replacedEntries); err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+	}
+	db.InvalidateSeasonStats(ctx, seasonId)
 
 	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
 		"progressReport": updatedReport,
@@ -302,34 +334,351 @@ func DeleteProgressReport(ctx context.Context, event events.APIGatewayProxyReque
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
 	}
 
-	teamId, err := db.GetTeamIdBySeasonId(ctx, seasonId)
+	report, err := db.GetProgressReportById(ctx, reportId)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
 	}
-	if teamId == "" {
-		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorNotFound, nil)
+	if report == nil || report.SeasonId != seasonId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorProgressReportNotFound, nil)
 	}
 
-	if !utils.HasTeamAccess(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	reportEntries, err := db.ListProgressEntriesByReportId(ctx, reportId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	for _, e := range reportEntries {
+		if err := db.DeleteMediaReferencesForTarget(ctx, e.Id); err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+	}
+	if err := db.DeleteProgressReport(ctx, reportId); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
+	}
+	db.InvalidateSeasonStats(ctx, seasonId)
+
+	return utils.SuccessResponse(http.StatusNoContent, utils.MsgSuccess, nil)
+}
+
+// moveEntryMedia attaches the media references of replaced progress entries to the report's new entry for the
+// same goal. References of entries whose goal is no longer rated are deleted.
+func moveEntryMedia(ctx context.Context, reportId string, replaced []*models.Progress) error {
+	current, err := db.ListProgressEntriesByReportId(ctx, reportId)
+	if err != nil {
+		return err
+	}
+	entryByGoal := make(map[string]string, len(current))
+	for _, e := range current {
+		entryByGoal[e.GoalId] = e.Id
+	}
+	for _, old := range replaced {
+		media, err := db.ListMediaReferencesByTargetId(ctx, old.Id)
+		if err != nil {
+			return err
+		}
+		for _, m := range media {
+			entryId, ok := entryByGoal[old.GoalId]
+			if !ok {
+				if err := db.DeleteMediaReference(ctx, m.Id); err != nil {
+					return err
+				}
+				continue
+			}
+			m.TargetId = entryId
+			if err := db.UpdateMediaReference(ctx, m); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateProgressEntries checks that every entry rates a distinct, non-archived goal of the report's season
+// that is either a team goal or an individual goal owned by the report author.
+func validateProgressEntries(ctx context.Context, seasonId, authorId string, entries []ProgressEntry) ([]utils.FieldError, error) {
+	fieldErrors := make([]utils.FieldError, 0)
+	seen := make(map[string]int, len(entries))
+	for i, e := range entries {
+		field := fmt.Sprintf("progress[%d].goalId", i)
+		if e.GoalId == "" {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field, Message: "goalId is required"})
+			continue
+		}
+		if first, dup := seen[e.GoalId]; dup {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field, Message: fmt.Sprintf("goal is already rated in progress[%d]", first)})
+			continue
+		}
+		seen[e.GoalId] = i
+
+		goal, err := db.GetGoalById(ctx, e.GoalId)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case goal == nil:
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field, Message: "goal not found"})
+		case goal.SeasonId != seasonId:
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field, Message: "goal belongs to a different season"})
+		case goal.Status == models.GoalStatusArchived:
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field, Message: "goal is archived"})
+		case goal.GoalType == models.GoalTypeIndividual && goal.OwnerId != authorId:
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field, Message: "goal is another member's individual goal"})
+		}
+	}
+	return fieldErrors, nil
+}
+
+// ExportProgressReport renders a single progress report as Markdown or PDF.
+func ExportProgressReport(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	reportId := event.PathParameters["reportId"]
+	if seasonId == "" || reportId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	format, delivery, err := parseExportOptions(event.QueryStringParameters)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+
+	season, err := db.GetSeasonById(ctx, seasonId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if season == nil {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorSeasonNotFound, nil)
 	}
 
 	report, err := db.GetProgressReportById(ctx, reportId)
 	if err != nil {
-		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
 	}
 	if report == nil || report.SeasonId != seasonId {
 		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorProgressReportNotFound, nil)
 	}
 
-	userId := utils.GetCognitoUsername(event.RequestContext.Authorizer)
-	if report.AuthorId != userId && !utils.IsTeamAdminOrTrainer(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	reports, err := buildExportReports(ctx, season, []*models.ProgressReport{report})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
 	}
 
-	if err := db.DeleteProgressReport(ctx, reportId); err != nil {
-		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, nil)
+	doc := &export.Document{
+		Title:    fmt.Sprintf("Progress report - %s", authorDisplayName(report)),
+		Subtitle: fmt.Sprintf("%s, %s", season.Name, report.CreatedAt.Format("2006-01-02")),
+		Reports:  reports,
 	}
+	filename := fmt.Sprintf("progress-report-%s%s", report.CreatedAt.Format("2006-01-02"), format.Extension())
+	return deliverExport(ctx, season.TeamId, filename, format, delivery, doc)
+}
 
-	return utils.SuccessResponse(http.StatusNoContent, utils.MsgSuccess, nil)
+// ExportMemberProgressReports renders all progress reports of one member in a season as a single Markdown or PDF document.
+// The member defaults to the caller when no authorId is given.
+func ExportMemberProgressReports(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	if seasonId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	format, delivery, err := parseExportOptions(event.QueryStringParameters)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+
+	season, err := db.GetSeasonById(ctx, seasonId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if season == nil {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorSeasonNotFound, nil)
+	}
+
+	authorId := event.QueryStringParameters["authorId"]
+	if authorId == "" {
+		authorId = utils.GetCognitoUsername(event.RequestContext.Authorizer)
+	}
+
+	seasonReports, err := db.ListAllProgressReportsBySeasonId(ctx, seasonId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	memberReports := make([]*models.ProgressReport, 0)
+	for _, r := range seasonReports {
+		if r.AuthorId == authorId {
+			memberReports = append(memberReports, r)
+		}
+	}
+	sort.Slice(memberReports, func(i, j int) bool {
+		return memberReports[i].CreatedAt.Before(memberReports[j].CreatedAt)
+	})
+
+	reports, err := buildExportReports(ctx, season, memberReports)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	authorName := authorId
+	if len(memberReports) > 0 {
+		authorName = authorDisplayName(memberReports[0])
+	} else if u, uerr := users.GetUserBySub(ctx, authorId); uerr == nil && u != nil {
+		authorName, _ = activity.ResolveActorInfo(u)
+	}
+
+	doc := &export.Document{
+		Title:    fmt.Sprintf("Season report - %s", authorName),
+		Subtitle: fmt.Sprintf("%s (%s to %s)", season.Name, season.StartDate.Format("2006-01-02"), season.EndDate.Format("2006-01-02")),
+		Reports:  reports,
+	}
+	filename := fmt.Sprintf("season-report-%s%s", season.StartDate.Format("2006"), format.Extension())
+	return deliverExport(ctx, season.TeamId, filename, format, delivery, doc)
+}
+
+const (
+	exportDeliveryDirect = "direct"
+	exportDeliveryURL    = "url"
+)
+
+func parseExportOptions(q map[string]string) (export.Format, string, error) {
+	format, err := export.ParseFormat(q["format"])
+	if err != nil {
+		return "", "", err
+	}
+	delivery := q["delivery"]
+	switch delivery {
+	case "":
+		delivery = exportDeliveryDirect
+	case exportDeliveryDirect, exportDeliveryURL:
+	default:
+		return "", "", fmt.Errorf("unsupported delivery %q", delivery)
+	}
+	return format, delivery, nil
+}
+
+// buildExportReports loads the goals, questionnaire answers and trainer comments of the given reports.
+// Only comments written by members whose role grants reports.review are included.
+func buildExportReports(ctx context.Context, season *models.Season, reports []*models.ProgressReport) ([]export.Report, error) {
+	questionnaire, err := db.GetQuestionnaireBySeasonId(ctx, season.Id)
+	if err != nil {
+		return nil, err
+	}
+	questionsById := make(map[string]models.Question)
+	if questionnaire != nil {
+		for _, q := range questionnaire.Questions {
+			questionsById[q.Id] = q
+		}
+	}
+
+	reportIds := make([]string, 0, len(reports))
+	for _, r := range reports {
+		reportIds = append(reportIds, r.Id)
+	}
+	progressByReport, err := db.ListProgressEntriesByReportIds(ctx, reportIds)
+	if err != nil {
+		return nil, err
+	}
+
+	goalTitles := make(map[string]string)
+	reviewerByUser := make(map[string]bool)
+	trainerComments := func(targetId string) ([]*models.Comment, error) {
+		comments, err := db.ListCommentsByTargetId(ctx, targetId)
+		if err != nil {
+			return nil, err
+		}
+		out := make([]*models.Comment, 0, len(comments))
+		for _, c := range comments {
+			isReviewer, ok := reviewerByUser[c.AuthorId]
+			if !ok {
+				var err error
+				isReviewer, err = utils.MemberHasPermission(ctx, c.AuthorId, season.TeamId, models.PermissionReportsReview)
+				if err != nil {
+					return nil, err
+				}
+				reviewerByUser[c.AuthorId] = isReviewer
+			}
+			if isReviewer {
+				out = append(out, c)
+			}
+		}
+		sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+		return out, nil
+	}
+
+	result := make([]export.Report, 0, len(reports))
+	for _, r := range reports {
+		item := export.Report{Report: r}
+
+		for _, p := range progressByReport[r.Id] {
+			title, ok := goalTitles[p.GoalId]
+			if !ok {
+				goal, err := db.GetGoalById(ctx, p.GoalId)
+				if err != nil {
+					return nil, err
+				}
+				title = "Deleted goal"
+				if goal != nil {
+					title = goal.Title
+				}
+				goalTitles[p.GoalId] = title
+			}
+			comments, err := trainerComments(p.Id)
+			if err != nil {
+				return nil, err
+			}
+			item.Entries = append(item.Entries, export.Entry{Progress: p, GoalTitle: title, Comments: comments})
+		}
+
+		for _, a := range r.Answers {
+			q, ok := questionsById[a.QuestionId]
+			if !ok {
+				continue
+			}
+			var value string
+			switch {
+			case a.Text != nil:
+				value = *a.Text
+			case a.Scale != nil:
+				value = strconv.Itoa(*a.Scale)
+				if q.Max != nil {
+					value = fmt.Sprintf("%d / %d", *a.Scale, *q.Max)
+				}
+			case a.Choice != nil:
+				value = *a.Choice
+			}
+			item.Answers = append(item.Answers, export.Answer{Prompt: q.Prompt, Value: value})
+		}
+
+		item.Comments, err = trainerComments(r.Id)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// deliverExport renders the document and either returns it directly or uploads it and returns a presigned download URL.
+func deliverExport(ctx context.Context, teamId, filename string, format export.Format, delivery string, doc *export.Document) (*events.APIGatewayProxyResponse, error) {
+	body, err := export.Render(doc, format)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	if delivery == exportDeliveryDirect {
+		return utils.FileResponse(http.StatusOK, format.ContentType(), filename, body, format == export.FormatPDF)
+	}
+
+	downloadUrl, key, err := storage.UploadExport(ctx, teamId, filename, format.ContentType(), body, utils.PresignedURLTimeout)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"downloadUrl": downloadUrl,
+		"key":         key,
+		"filename":    filename,
+	})
+}
+
+func authorDisplayName(report *models.ProgressReport) string {
+	if report.AuthorName != nil && *report.AuthorName != "" {
+		return *report.AuthorName
+	}
+	return report.AuthorId
 }
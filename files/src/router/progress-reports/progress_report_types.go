@@ -7,6 +7,18 @@ type ProgressReportWithProgress struct {
 	Progress []*models.Progress `json:"progress"`
 }
 
+// ProgressWithMedia is a progress entry with the media references attached to it.
+type ProgressWithMedia struct {
+	*models.Progress
+	Media []*models.MediaReference `json:"media"`
+}
+
+// ProgressReportWithMedia is returned for a single report: its progress entries carry their media references.
+type ProgressReportWithMedia struct {
+	*models.ProgressReport
+	Progress []ProgressWithMedia `json:"progress"`
+}
+
 type ProgressEntry struct {
 	GoalId  string `json:"goalId"`
 	Rating  int8   `json:"rating"`
@@ -14,15 +26,19 @@ type ProgressEntry struct {
 }
 
 type CreateProgressReportRequest struct {
-	Summary        string          `json:"summary"`
-	Details        string          `json:"details"`
-	OverallDetails string          `json:"overallDetails"`
-	Progress       []ProgressEntry `json:"progress,omitempty"`
+	Summary        string                  `json:"summary"`
+	Details        string                  `json:"details"`
+	OverallDetails string                  `json:"overallDetails"`
+	Progress       []ProgressEntry         `json:"progress,omitempty"`
+	Answers        []models.QuestionAnswer `json:"answers,omitempty"`
+	EventId        *string                 `json:"eventId,omitempty"`
 }
 
 type UpdateProgressReportRequest struct {
-	Summary        *string         `json:"summary,omitempty"`
-	Details        *string         `json:"details,omitempty"`
-	OverallDetails *string         `json:"overallDetails,omitempty"`
-	Progress       []ProgressEntry `json:"progress,omitempty"`
+	Summary        *string                 `json:"summary,omitempty"`
+	Details        *string                 `json:"details,omitempty"`
+	OverallDetails *string                 `json:"overallDetails,omitempty"`
+	Progress       []ProgressEntry         `json:"progress,omitempty"`
+	Answers        []models.QuestionAnswer `json:"answers,omitempty"`
+	EventId        *string                 `json:"eventId,omitempty"`
 }
@@ -0,0 +1,296 @@
+package questionnaires
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpgschiba/volleygoals/db"
+	"github.com/fpgschiba/volleygoals/models"
+	"github.com/fpgschiba/volleygoals/utils"
+)
+
+func GetSeasonQuestionnaire(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	if seasonId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	questionnaire, err := db.GetQuestionnaireBySeasonId(ctx, seasonId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if questionnaire == nil {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorQuestionnaireNotFound, nil)
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"questionnaire": questionnaire,
+	})
+}
+
+// UpdateSeasonQuestionnaire creates or replaces the questionnaire of a season.
+func UpdateSeasonQuestionnaire(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	if seasonId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	teamId, err := db.GetTeamIdBySeasonId(ctx, seasonId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if teamId == "" {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorSeasonNotFound, nil)
+	}
+
+	var request UpdateQuestionnaireRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+
+	questions, err := buildQuestions(request.Questions)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgErrorInvalidQuestionnaire, err)
+	}
+
+	questionnaire, err := db.GetQuestionnaireBySeasonId(ctx, seasonId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if questionnaire == nil {
+		questionnaire, err = db.CreateQuestionnaire(ctx, teamId, seasonId, questions)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+	} else {
+		questionnaire.Questions = questions
+		if err := db.UpdateQuestionnaire(ctx, questionnaire); err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+	}
+	db.InvalidateSeasonStats(ctx, seasonId)
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"questionnaire": questionnaire,
+	})
+}
+
+func DeleteSeasonQuestionnaire(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	if seasonId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	questionnaire, err := db.GetQuestionnaireBySeasonId(ctx, seasonId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if questionnaire == nil {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorQuestionnaireNotFound, nil)
+	}
+
+	if err := db.DeleteQuestionnaire(ctx, questionnaire.Id); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	db.InvalidateSeasonStats(ctx, seasonId)
+
+	return utils.SuccessResponse(http.StatusNoContent, utils.MsgSuccess, nil)
+}
+
+const (
+	defaultScaleMin = 1
+	defaultScaleMax = 5
+)
+
+// buildQuestions validates the requested questions and assigns IDs to new ones.
+// Existing IDs are kept so answers given before an edit stay linked to their question.
+func buildQuestions(requested []QuestionRequest) ([]models.Question, error) {
+	questions := make([]models.Question, 0, len(requested))
+	seen := make(map[string]struct{}, len(requested))
+	for i, r := range requested {
+		if strings.TrimSpace(r.Prompt) == "" {
+			return nil, fmt.Errorf("question %d: prompt is required", i)
+		}
+		q := models.Question{
+			Id:       r.Id,
+			Prompt:   r.Prompt,
+			Type:     r.Type,
+			Required: r.Required,
+		}
+		if q.Id == "" {
+			q.Id = models.GenerateID()
+		}
+		if _, dup := seen[q.Id]; dup {
+			return nil, fmt.Errorf("question %d: duplicate id %s", i, q.Id)
+		}
+		seen[q.Id] = struct{}{}
+
+		switch r.Type {
+		case models.QuestionTypeText:
+		case models.QuestionTypeScale:
+			min, max := defaultScaleMin, defaultScaleMax
+			if r.Min != nil {
+				min = *r.Min
+			}
+			if r.Max != nil {
+				max = *r.Max
+			}
+			if min >= max {
+				return nil, fmt.Errorf("question %d: min must be lower than max", i)
+			}
+			q.Min, q.Max = &min, &max
+		case models.QuestionTypeChoice:
+			if len(r.Options) < 2 {
+				return nil, fmt.Errorf("question %d: choice questions need at least two options", i)
+			}
+			options := make(map[string]struct{}, len(r.Options))
+			for _, o := range r.Options {
+				if strings.TrimSpace(o) == "" {
+					return nil, fmt.Errorf("question %d: options must not be empty", i)
+				}
+				if _, dup := options[o]; dup {
+					return nil, fmt.Errorf("question %d: duplicate option %q", i, o)
+				}
+				options[o] = struct{}{}
+			}
+			q.Options = r.Options
+		default:
+			return nil, fmt.Errorf("question %d: unknown type %q", i, r.Type)
+		}
+		questions = append(questions, q)
+	}
+	return questions, nil
+}
+
+// ValidateAnswers checks answers against a season questionnaire. A nil questionnaire accepts no answers.
+// When requireAll is set, every required question must be answered.
+func ValidateAnswers(questionnaire *models.Questionnaire, answers []models.QuestionAnswer, requireAll bool) error {
+	if questionnaire == nil {
+		if len(answers) > 0 {
+			return fmt.Errorf("season has no questionnaire")
+		}
+		return nil
+	}
+
+	questionsById := make(map[string]models.Question, len(questionnaire.Questions))
+	for _, q := range questionnaire.Questions {
+		questionsById[q.Id] = q
+	}
+
+	answered := make(map[string]struct{}, len(answers))
+	for _, a := range answers {
+		q, ok := questionsById[a.QuestionId]
+		if !ok {
+			return fmt.Errorf("unknown question %s", a.QuestionId)
+		}
+		if _, dup := answered[a.QuestionId]; dup {
+			return fmt.Errorf("question %s answered more than once", a.QuestionId)
+		}
+		answered[a.QuestionId] = struct{}{}
+
+		switch q.Type {
+		case models.QuestionTypeText:
+			if a.Text == nil || a.Scale != nil || a.Choice != nil {
+				return fmt.Errorf("question %s expects a text answer", q.Id)
+			}
+			if q.Required && strings.TrimSpace(*a.Text) == "" {
+				return fmt.Errorf("question %s requires a non-empty answer", q.Id)
+			}
+		case models.QuestionTypeScale:
+			if a.Scale == nil || a.Text != nil || a.Choice != nil {
+				return fmt.Errorf("question %s expects a scale answer", q.Id)
+			}
+			if (q.Min != nil && *a.Scale < *q.Min) || (q.Max != nil && *a.Scale > *q.Max) {
+				return fmt.Errorf("question %s answer is out of range", q.Id)
+			}
+		case models.QuestionTypeChoice:
+			if a.Choice == nil || a.Text != nil || a.Scale != nil {
+				return fmt.Errorf("question %s expects a choice answer", q.Id)
+			}
+			if !containsOption(q.Options, *a.Choice) {
+				return fmt.Errorf("question %s answer is not one of the options", q.Id)
+			}
+		}
+	}
+
+	if requireAll {
+		for _, q := range questionnaire.Questions {
+			if !q.Required {
+				continue
+			}
+			if _, ok := answered[q.Id]; !ok {
+				return fmt.Errorf("question %s is required", q.Id)
+			}
+		}
+	}
+	return nil
+}
+
+// AggregateAnswers summarises the answers of all given reports per questionnaire question.
+func AggregateAnswers(questionnaire *models.Questionnaire, reports []*models.ProgressReport) []models.QuestionAggregate {
+	if questionnaire == nil {
+		return []models.QuestionAggregate{}
+	}
+
+	aggregates := make([]models.QuestionAggregate, 0, len(questionnaire.Questions))
+	indexById := make(map[string]int, len(questionnaire.Questions))
+	sums := make([]int, len(questionnaire.Questions))
+	for i, q := range questionnaire.Questions {
+		agg := models.QuestionAggregate{QuestionId: q.Id, Prompt: q.Prompt, Type: q.Type}
+		if q.Type != models.QuestionTypeText {
+			agg.Distribution = make(map[string]int)
+		}
+		aggregates = append(aggregates, agg)
+		indexById[q.Id] = i
+	}
+
+	for _, r := range reports {
+		for _, a := range r.Answers {
+			i, ok := indexById[a.QuestionId]
+			if !ok {
+				continue
+			}
+			agg := &aggregates[i]
+			switch agg.Type {
+			case models.QuestionTypeText:
+				if a.Text == nil {
+					continue
+				}
+			case models.QuestionTypeScale:
+				if a.Scale == nil {
+					continue
+				}
+				sums[i] += *a.Scale
+				agg.Distribution[strconv.Itoa(*a.Scale)]++
+			case models.QuestionTypeChoice:
+				if a.Choice == nil {
+					continue
+				}
+				agg.Distribution[*a.Choice]++
+			}
+			agg.AnswerCount++
+		}
+	}
+
+	for i := range aggregates {
+		if aggregates[i].Type == models.QuestionTypeScale && aggregates[i].AnswerCount > 0 {
+			avg := float64(sums[i]) / float64(aggregates[i].AnswerCount)
+			aggregates[i].Average = &avg
+		}
+	}
+	return aggregates
+}
+
+func containsOption(options []string, value string) bool {
+	for _, o := range options {
+		if o == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,17 @@
+package questionnaires
+
+import "github.com/fpgschiba/volleygoals/models"
+
+type QuestionRequest struct {
+	Id       string              `json:"id,omitempty"`
+	Prompt   string              `json:"prompt"`
+	Type     models.QuestionType `json:"type"`
+	Required bool                `json:"required"`
+	Min      *int                `json:"min,omitempty"`
+	Max      *int                `json:"max,omitempty"`
+	Options  []string            `json:"options,omitempty"`
+}
+
+type UpdateQuestionnaireRequest struct {
+	Questions []QuestionRequest `json:"questions"`
+}
@@ -8,15 +8,30 @@ import (
 	"runtime/debug"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpgschiba/volleygoals/db"
 	"github.com/fpgschiba/volleygoals/router/activity"
+	"github.com/fpgschiba/volleygoals/router/calendar"
 	"github.com/fpgschiba/volleygoals/router/comments"
+	"github.com/fpgschiba/volleygoals/router/drills"
+	"github.com/fpgschiba/volleygoals/router/fitness"
 	"github.com/fpgschiba/volleygoals/router/goals"
+	"github.com/fpgschiba/volleygoals/router/guardians"
+	"github.com/fpgschiba/volleygoals/router/handovers"
 	"github.com/fpgschiba/volleygoals/router/invites"
+	"github.com/fpgschiba/volleygoals/router/lineups"
+	match_stats "github.com/fpgschiba/volleygoals/router/match-stats"
+	"github.com/fpgschiba/volleygoals/router/media"
+	"github.com/fpgschiba/volleygoals/router/organizations"
+	"github.com/fpgschiba/volleygoals/router/policy"
 	progress_reports "github.com/fpgschiba/volleygoals/router/progress-reports"
+	"github.com/fpgschiba/volleygoals/router/questionnaires"
 	"github.com/fpgschiba/volleygoals/router/search"
 	"github.com/fpgschiba/volleygoals/router/seasons"
 	"github.com/fpgschiba/volleygoals/router/self"
+	"github.com/fpgschiba/volleygoals/router/skills"
+	"github.com/fpgschiba/volleygoals/router/subgroups"
 	teammembers "github.com/fpgschiba/volleygoals/router/team-members"
+	teamroles "github.com/fpgschiba/volleygoals/router/team-roles"
 	teamsettings "github.com/fpgschiba/volleygoals/router/team-settings"
 	"github.com/fpgschiba/volleygoals/router/teams"
 	"github.com/fpgschiba/volleygoals/router/users"
@@ -63,6 +78,9 @@ func HandleRequest(ctx context.Context, event events.APIGatewayProxyRequest) (re
 	ctx, span := tracer.Start(ctx, spanName)
 	defer span.End()
 
+	// Memberships, roles and season teams are looked up once per request, however many checks ask for them
+	ctx = db.WithRequestCache(ctx)
+
 	span.SetAttributes(
 		attribute.String("http.method", event.HTTPMethod),
 		attribute.String("http.path", event.Path),
@@ -70,6 +88,12 @@ func HandleRequest(ctx context.Context, event events.APIGatewayProxyRequest) (re
 		attribute.String("handler", h),
 	)
 
+	// Authorization: every handler's access rule is declared in the policy package and checked here
+	if resp, e := policy.Authorize(ctx, h, event); resp != nil {
+		span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+		return resp, e
+	}
+
 	switch h {
 	// Utilities
 	case "HealthCheck":
@@ -89,10 +113,40 @@ func HandleRequest(ctx context.Context, event events.APIGatewayProxyRequest) (re
 	case "UploadTeamPicture":
 		response, err = teams.UploadTeamPicture(ctx, event)
 
+	// Organization handlers
+	case "CreateOrganization":
+		response, err = organizations.CreateOrganization(ctx, event)
+	case "ListOrganizations":
+		response, err = organizations.ListOrganizations(ctx, event)
+	case "GetOrganization":
+		response, err = organizations.GetOrganization(ctx, event)
+	case "UpdateOrganization":
+		response, err = organizations.UpdateOrganization(ctx, event)
+	case "DeleteOrganization":
+		response, err = organizations.DeleteOrganization(ctx, event)
+	case "ListOrganizationMembers":
+		response, err = organizations.ListOrganizationMembers(ctx, event)
+	case "AddOrganizationMember":
+		response, err = organizations.AddOrganizationMember(ctx, event)
+	case "UpdateOrganizationMember":
+		response, err = organizations.UpdateOrganizationMember(ctx, event)
+	case "RemoveOrganizationMember":
+		response, err = organizations.RemoveOrganizationMember(ctx, event)
+
 	// Team settings handlers
 	case "UpdateTeamSettings":
 		response, err = teamsettings.UpdateTeamSettings(ctx, event)
 
+	// Team role handlers
+	case "ListTeamRoles":
+		response, err = teamroles.ListTeamRoles(ctx, event)
+	case "CreateTeamRole":
+		response, err = teamroles.CreateTeamRole(ctx, event)
+	case "UpdateTeamRole":
+		response, err = teamroles.UpdateTeamRole(ctx, event)
+	case "DeleteTeamRole":
+		response, err = teamroles.DeleteTeamRole(ctx, event)
+
 	// Self handlers
 	case "GetSelf":
 		response, err = self.GetSelf(ctx, event)
@@ -110,8 +164,22 @@ func HandleRequest(ctx context.Context, event events.APIGatewayProxyRequest) (re
 		response, err = teammembers.UpdateTeamMember(ctx, event)
 	case "RemoveTeamMember":
 		response, err = teammembers.RemoveTeamMember(ctx, event)
+	case "TransferTeamMember":
+		response, err = teammembers.TransferTeamMember(ctx, event)
 	case "LeaveTeam":
 		response, err = teammembers.LeaveTeam(ctx, event)
+	case "GetMemberDevelopment":
+		response, err = teammembers.GetMemberDevelopment(ctx, event)
+
+	// Handover handlers
+	case "CreateHandover":
+		response, err = handovers.CreateHandover(ctx, event)
+	case "ListHandovers":
+		response, err = handovers.ListHandovers(ctx, event)
+	case "RespondToHandover":
+		response, err = handovers.RespondToHandover(ctx, event)
+	case "CancelHandover":
+		response, err = handovers.CancelHandover(ctx, event)
 
 	// Invites handlers
 	case "CreateInvite":
@@ -136,6 +204,22 @@ func HandleRequest(ctx context.Context, event events.APIGatewayProxyRequest) (re
 		response, err = users.DeleteUser(ctx, event)
 	case "UpdateUser":
 		response, err = users.UpdateUser(ctx, event)
+	case "ListUserMemberships":
+		response, err = users.ListUserMemberships(ctx, event)
+
+	// Guardian handlers
+	case "CreateGuardianship":
+		response, err = guardians.CreateGuardianship(ctx, event)
+	case "ListGuardians":
+		response, err = guardians.ListGuardians(ctx, event)
+	case "DeleteGuardianship":
+		response, err = guardians.DeleteGuardianship(ctx, event)
+	case "ListWards":
+		response, err = guardians.ListWards(ctx, event)
+	case "GetWardOverview":
+		response, err = guardians.GetWardOverview(ctx, event)
+	case "GiveGuardianConsent":
+		response, err = guardians.GiveGuardianConsent(ctx, event)
 
 	// Seasons handlers
 	case "CreateSeason":
@@ -150,6 +234,123 @@ func HandleRequest(ctx context.Context, event events.APIGatewayProxyRequest) (re
 		response, err = seasons.DeleteSeason(ctx, event)
 	case "GetSeasonStats":
 		response, err = seasons.GetSeasonStats(ctx, event)
+	case "CloneSeason":
+		response, err = seasons.CloneSeason(ctx, event)
+	case "TransitionSeasonStatuses":
+		response, err = seasons.TransitionSeasonStatuses(ctx, event)
+
+	// Questionnaire handlers
+	case "GetSeasonQuestionnaire":
+		response, err = questionnaires.GetSeasonQuestionnaire(ctx, event)
+	case "UpdateSeasonQuestionnaire":
+		response, err = questionnaires.UpdateSeasonQuestionnaire(ctx, event)
+	case "DeleteSeasonQuestionnaire":
+		response, err = questionnaires.DeleteSeasonQuestionnaire(ctx, event)
+
+	// Calendar handlers
+	case "CreateEvent":
+		response, err = calendar.CreateEvent(ctx, event)
+	case "ListEvents":
+		response, err = calendar.ListEvents(ctx, event)
+	case "GetEvent":
+		response, err = calendar.GetEvent(ctx, event)
+	case "UpdateEvent":
+		response, err = calendar.UpdateEvent(ctx, event)
+	case "DeleteEvent":
+		response, err = calendar.DeleteEvent(ctx, event)
+	case "GetEventAttendance":
+		response, err = calendar.GetEventAttendance(ctx, event)
+	case "UpdateEventAttendance":
+		response, err = calendar.UpdateEventAttendance(ctx, event)
+	case "ListSeasonAttendance":
+		response, err = calendar.ListSeasonAttendance(ctx, event)
+
+	// Match statistics handlers
+	case "GetMatchStats":
+		response, err = match_stats.GetMatchStats(ctx, event)
+	case "UpdateMatchStats":
+		response, err = match_stats.UpdateMatchStats(ctx, event)
+	case "DeleteMatchStats":
+		response, err = match_stats.DeleteMatchStats(ctx, event)
+	case "GetSeasonMatchStats":
+		response, err = match_stats.GetSeasonMatchStats(ctx, event)
+	case "GetPlayerMatchStats":
+		response, err = match_stats.GetPlayerMatchStats(ctx, event)
+
+	// Lineup handlers
+	case "GetLineup":
+		response, err = lineups.GetLineup(ctx, event)
+	case "UpdateLineup":
+		response, err = lineups.UpdateLineup(ctx, event)
+	case "DeleteLineup":
+		response, err = lineups.DeleteLineup(ctx, event)
+	case "GetSeasonLineupHistory":
+		response, err = lineups.GetSeasonLineupHistory(ctx, event)
+	case "GetPlayerLineupHistory":
+		response, err = lineups.GetPlayerLineupHistory(ctx, event)
+
+	// Fitness handlers
+	case "CreateFitnessTest":
+		response, err = fitness.CreateFitnessTest(ctx, event)
+	case "ListFitnessTests":
+		response, err = fitness.ListFitnessTests(ctx, event)
+	case "UpdateFitnessTest":
+		response, err = fitness.UpdateFitnessTest(ctx, event)
+	case "DeleteFitnessTest":
+		response, err = fitness.DeleteFitnessTest(ctx, event)
+	case "CreateFitnessResult":
+		response, err = fitness.CreateFitnessResult(ctx, event)
+	case "ListFitnessResults":
+		response, err = fitness.ListFitnessResults(ctx, event)
+	case "DeleteFitnessResult":
+		response, err = fitness.DeleteFitnessResult(ctx, event)
+	case "GetFitnessLeaderboard":
+		response, err = fitness.GetFitnessLeaderboard(ctx, event)
+	case "GetMemberPersonalBests":
+		response, err = fitness.GetMemberPersonalBests(ctx, event)
+
+	// Skill handlers
+	case "GetSkillCatalogue":
+		response, err = skills.GetSkillCatalogue(ctx, event)
+	case "UpdateSkillCatalogue":
+		response, err = skills.UpdateSkillCatalogue(ctx, event)
+	case "CreateSkillAssessment":
+		response, err = skills.CreateSkillAssessment(ctx, event)
+	case "ListSkillAssessments":
+		response, err = skills.ListSkillAssessments(ctx, event)
+	case "DeleteSkillAssessment":
+		response, err = skills.DeleteSkillAssessment(ctx, event)
+	case "GetSkillMatrix":
+		response, err = skills.GetSkillMatrix(ctx, event)
+	case "GetMemberSkillRadar":
+		response, err = skills.GetMemberSkillRadar(ctx, event)
+
+	// Drill handlers
+	case "CreateDrill":
+		response, err = drills.CreateDrill(ctx, event)
+	case "ListDrills":
+		response, err = drills.ListDrills(ctx, event)
+	case "GetDrill":
+		response, err = drills.GetDrill(ctx, event)
+	case "UpdateDrill":
+		response, err = drills.UpdateDrill(ctx, event)
+	case "DeleteDrill":
+		response, err = drills.DeleteDrill(ctx, event)
+	case "UploadDrillMedia":
+		response, err = drills.UploadDrillMedia(ctx, event)
+	case "SuggestDrillsForGoal":
+		response, err = drills.SuggestDrillsForGoal(ctx, event)
+	// Subgroup handlers
+	case "CreateSubgroup":
+		response, err = subgroups.CreateSubgroup(ctx, event)
+	case "ListSubgroups":
+		response, err = subgroups.ListSubgroups(ctx, event)
+	case "GetSubgroup":
+		response, err = subgroups.GetSubgroup(ctx, event)
+	case "UpdateSubgroup":
+		response, err = subgroups.UpdateSubgroup(ctx, event)
+	case "DeleteSubgroup":
+		response, err = subgroups.DeleteSubgroup(ctx, event)
 
 	// Goals handlers
 	case "CreateGoal":
@@ -176,6 +377,10 @@ func HandleRequest(ctx context.Context, event events.APIGatewayProxyRequest) (re
 		response, err = progress_reports.UpdateProgressReport(ctx, event)
 	case "DeleteProgressReport":
 		response, err = progress_reports.DeleteProgressReport(ctx, event)
+	case "ExportProgressReport":
+		response, err = progress_reports.ExportProgressReport(ctx, event)
+	case "ExportMemberProgressReports":
+		response, err = progress_reports.ExportMemberProgressReports(ctx, event)
 
 	// Comments handlers
 	case "CreateComment":
@@ -191,6 +396,14 @@ func HandleRequest(ctx context.Context, event events.APIGatewayProxyRequest) (re
 	case "UploadCommentFile":
 		response, err = comments.UploadCommentFile(ctx, event)
 
+	// Media reference handlers
+	case "CreateMediaReference":
+		response, err = media.CreateMediaReference(ctx, event)
+	case "UpdateMediaReference":
+		response, err = media.UpdateMediaReference(ctx, event)
+	case "DeleteMediaReference":
+		response, err = media.DeleteMediaReference(ctx, event)
+
 	// Search handlers
 	case "GlobalSearch":
 		response, err = search.GlobalSearch(ctx, event)
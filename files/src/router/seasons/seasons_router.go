@@ -3,12 +3,23 @@ package seasons
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"math"
 	"net/http"
+	"sort"
+	"strconv"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/fpgschiba/volleygoals/db"
+	"github.com/fpgschiba/volleygoals/mail"
 	"github.com/fpgschiba/volleygoals/models"
+	"github.com/fpgschiba/volleygoals/router/activity"
+	"github.com/fpgschiba/volleygoals/router/questionnaires"
+	"github.com/fpgschiba/volleygoals/router/subgroups"
+	"github.com/fpgschiba/volleygoals/users"
 	"github.com/fpgschiba/volleygoals/utils"
+	log "github.com/sirupsen/logrus"
 )
 
 func CreateSeason(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
@@ -17,8 +28,15 @@ func CreateSeason(ctx context.Context, event events.APIGatewayProxyRequest) (*ev
 	if err != nil {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
 	}
-	if !utils.IsTeamAdminOrTrainer(ctx, event.RequestContext.Authorizer, body.TeamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	if fieldErrors := validateSeasonDates(body.StartDate, body.EndDate); len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidSeasonDates, fieldErrors)
+	}
+	overlapping, err := findOverlappingSeason(ctx, body.TeamId, "", body.StartDate, body.EndDate)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if overlapping != nil {
+		return utils.ErrorResponse(http.StatusConflict, utils.MsgErrorSeasonOverlap, nil)
 	}
 	season, err := db.CreateSeason(ctx, body.TeamId, body.Name, body.StartDate, body.EndDate)
 	if err != nil {
@@ -31,20 +49,13 @@ func CreateSeason(ctx context.Context, event events.APIGatewayProxyRequest) (*ev
 
 func GetSeason(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
 	seasonId := event.PathParameters["seasonId"]
-	authorized, exists, err := isAuthorizedForSeason(ctx, event.RequestContext.Authorizer, seasonId, true)
+	season, err := db.GetSeasonById(ctx, seasonId)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
 	}
-	if !exists {
+	if season == nil {
 		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorSeasonNotFound, nil)
 	}
-	if !authorized {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-	season, err := db.GetSeasonById(ctx, seasonId)
-	if err != nil {
-		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
-	}
 	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
 		"season": season,
 	})
@@ -56,15 +67,6 @@ func ListSeasons(ctx context.Context, event events.APIGatewayProxyRequest) (*eve
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
 	}
 
-	if filter.TeamId != "" {
-		// Authorization: all team users can list seasons
-		if !utils.HasTeamAccess(ctx, event.RequestContext.Authorizer, filter.TeamId) {
-			return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-		}
-	} else {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-
 	items, count, nextCursor, hasMore, err := db.ListSeasons(ctx, filter)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
@@ -95,20 +97,42 @@ func ListSeasons(ctx context.Context, event events.APIGatewayProxyRequest) (*eve
 
 func UpdateSeason(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
 	seasonId := event.PathParameters["seasonId"]
-	authorized, exists, err := isAuthorizedForSeason(ctx, event.RequestContext.Authorizer, seasonId, false)
+	var body UpdateSeasonRequest
+	err := json.Unmarshal([]byte(event.Body), &body)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+	existing, err := db.GetSeasonById(ctx, seasonId)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
 	}
-	if !exists {
+	if existing == nil {
 		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorSeasonNotFound, nil)
 	}
-	if !authorized {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	// Validate the season as it will look after the update
+	start, end, status := existing.StartDate, existing.EndDate, existing.Status
+	if body.StartDate != nil {
+		start = *body.StartDate
 	}
-	var body UpdateSeasonRequest
-	err = json.Unmarshal([]byte(event.Body), &body)
-	if err != nil {
-		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	if body.EndDate != nil {
+		end = *body.EndDate
+	}
+	if body.Status != nil {
+		status = *body.Status
+	}
+	if body.StartDate != nil || body.EndDate != nil {
+		if fieldErrors := validateSeasonDates(start, end); len(fieldErrors) > 0 {
+			return utils.ValidationErrorResponse(utils.MsgErrorInvalidSeasonDates, fieldErrors)
+		}
+	}
+	if (body.StartDate != nil || body.EndDate != nil || body.Status != nil) && isOpenSeasonStatus(status) {
+		overlapping, err := findOverlappingSeason(ctx, existing.TeamId, existing.Id, start, end)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		if overlapping != nil {
+			return utils.ErrorResponse(http.StatusConflict, utils.MsgErrorSeasonOverlap, nil)
+		}
 	}
 	season, err := db.UpdateSeason(ctx, seasonId, body.Name, body.StartDate, body.EndDate, body.Status)
 	if err != nil {
@@ -121,85 +145,629 @@ func UpdateSeason(ctx context.Context, event events.APIGatewayProxyRequest) (*ev
 
 func DeleteSeason(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
 	seasonId := event.PathParameters["seasonId"]
-	authorized, exists, err := isAuthorizedForSeason(ctx, event.RequestContext.Authorizer, seasonId, false)
-	if err != nil {
-		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
-	}
-	if !exists {
-		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorSeasonNotFound, nil)
-	}
-	if !authorized {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-	err = db.DeleteSeason(ctx, seasonId)
+	err := db.DeleteSeason(ctx, seasonId)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
 	}
 	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, nil)
 }
 
+// GetSeasonStats returns the analytics rollup of a season. The rollup is served from the season stats table
+// and only recomputed when it has expired or was invalidated by a change to the season's goals, reports or
+// questionnaire. Members who can manage seasons can force a recomputation with refresh=true. With subgroupId the
+// stats only cover the subgroup's members and goals; they are always computed fresh.
 func GetSeasonStats(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
 	seasonId := event.PathParameters["seasonId"]
 	if seasonId == "" {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
 	}
 
-	teamId, err := db.GetTeamIdBySeasonId(ctx, seasonId)
+	season, err := db.GetSeasonById(ctx, seasonId)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
 	}
-	if teamId == "" {
+	if season == nil {
 		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorNotFound, nil)
 	}
 
-	if !utils.HasTeamAccess(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	subgroup, resp, err := subgroups.LoadForTeam(ctx, season.TeamId, event.QueryStringParameters)
+	if resp != nil {
+		return resp, err
 	}
 
-	goalCount, completedGoalCount, openGoalCount, inProgressGoalCount, err := db.CountGoalsBySeasonId(ctx, seasonId)
+	refresh := event.QueryStringParameters["refresh"] == "true" &&
+		utils.HasPermission(ctx, event.RequestContext.Authorizer, season.TeamId, models.PermissionSeasonsManage)
+
+	var stats *models.SeasonStats
+	if subgroup != nil {
+		stats, err = computeSeasonStats(ctx, season, subgroup)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+	} else {
+		stats, err = db.GetSeasonStats(ctx, seasonId)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		if stats == nil || refresh || time.Now().Unix() >= stats.ExpiresAt {
+			stats, err = computeSeasonStats(ctx, season, nil)
+			if err != nil {
+				return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+			}
+			if err := db.PutSeasonStats(ctx, stats); err != nil {
+				log.WithError(err).WithField("seasonId", seasonId).Warn("failed to store season stats")
+			}
+		}
+	}
+
+	// Members only see their own attendance
+	if !utils.HasPermission(ctx, event.RequestContext.Authorizer, season.TeamId, models.PermissionEventsManage) {
+		callerId := utils.GetCognitoUsername(event.RequestContext.Authorizer)
+		for i := range stats.Members {
+			if stats.Members[i].UserId != callerId {
+				stats.Members[i].Attendance = nil
+			}
+		}
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"stats": stats,
+	})
+}
+
+// seasonStatsTTL bounds how stale a stored rollup can get through changes that do not invalidate it,
+// such as members joining or leaving the team.
+const seasonStatsTTL = 15 * time.Minute
+
+// computeSeasonStats builds the full analytics rollup of a season from its goals, reports and progress entries.
+// Weeks are ISO weeks in the team's time zone. With a subgroup only its goals and its members' reports and
+// attendance are counted.
+func computeSeasonStats(ctx context.Context, season *models.Season, subgroup *models.Subgroup) (*models.SeasonStats, error) {
+	team, err := db.GetTeamById(ctx, season.TeamId)
+	if err != nil {
+		return nil, err
+	}
+	loc := time.UTC
+	if team != nil {
+		loc = team.Location()
+	}
+	goals, err := db.ListAllGoalsBySeasonId(ctx, season.Id)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := db.ListAllProgressReportsBySeasonId(ctx, season.Id)
+	if err != nil {
+		return nil, err
+	}
+	members, err := db.GetMembershipsByTeamID(ctx, season.TeamId)
+	if err != nil {
+		return nil, err
+	}
+	if subgroup != nil {
+		subgroupGoals := goals[:0]
+		for _, g := range goals {
+			if g.SubgroupId == subgroup.Id {
+				subgroupGoals = append(subgroupGoals, g)
+			}
+		}
+		goals = subgroupGoals
+		subgroupReports := reports[:0]
+		for _, r := range reports {
+			if subgroup.HasMember(r.AuthorId) {
+				subgroupReports = append(subgroupReports, r)
+			}
+		}
+		reports = subgroupReports
+		subgroupMembers := members[:0]
+		for _, m := range members {
+			if subgroup.HasMember(m.UserId) {
+				subgroupMembers = append(subgroupMembers, m)
+			}
+		}
+		members = subgroupMembers
+	}
+	reportIds := make([]string, 0, len(reports))
+	for _, r := range reports {
+		reportIds = append(reportIds, r.Id)
+	}
+	entriesByReport, err := db.ListProgressEntriesByReportIds(ctx, reportIds)
+	if err != nil {
+		return nil, err
+	}
+	questionnaire, err := db.GetQuestionnaireBySeasonId(ctx, season.Id)
+	if err != nil {
+		return nil, err
+	}
+	attendance, err := db.ListAttendanceBySeasonId(ctx, season.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	stats := &models.SeasonStats{
+		SeasonId:           season.Id,
+		ReportCount:        len(reports),
+		MemberCount:        len(members),
+		RatingDistribution: make(map[string]int),
+		CompletionByType: map[string]models.GoalCompletion{
+			string(models.GoalTypeTeam):       {},
+			string(models.GoalTypeIndividual): {},
+		},
+		CompletionByTag: make(map[string]models.GoalCompletion),
+		Members:         make([]models.MemberSeasonStats, 0, len(members)),
+		Answers:         questionnaires.AggregateAnswers(questionnaire, reports),
+		ComputedAt:      now,
+		ExpiresAt:       now.Add(seasonStatsTTL).Unix(),
+	}
+	if subgroup != nil {
+		stats.SubgroupId = subgroup.Id
+	}
+
+	memberStats := make(map[string]*models.MemberSeasonStats, len(members))
+	memberRatingSums := make(map[string]int, len(members))
+	memberWeeks := make(map[string]map[time.Time]struct{}, len(members))
+	for _, m := range members {
+		memberStats[m.UserId] = &models.MemberSeasonStats{
+			UserId:             m.UserId,
+			Role:               m.Role,
+			RatingDistribution: make(map[string]int),
+			Attendance:         &models.MemberAttendance{},
+		}
+		memberWeeks[m.UserId] = make(map[time.Time]struct{})
+	}
+
+	// Goals: archived goals are excluded from all counts
+	for _, g := range goals {
+		if g.Status == models.GoalStatusArchived {
+			continue
+		}
+		completed := g.Status == models.GoalStatusCompleted
+		stats.GoalCount++
+		switch g.Status {
+		case models.GoalStatusCompleted:
+			stats.CompletedGoalCount++
+		case models.GoalStatusOpen:
+			stats.OpenGoalCount++
+		case models.GoalStatusInProgress:
+			stats.InProgressGoalCount++
+		}
+
+		byType := stats.CompletionByType[string(g.GoalType)]
+		byType.GoalCount++
+		if completed {
+			byType.CompletedGoalCount++
+		}
+		stats.CompletionByType[string(g.GoalType)] = byType
+		for _, tag := range g.Tags {
+			byTag := stats.CompletionByTag[tag]
+			byTag.GoalCount++
+			if completed {
+				byTag.CompletedGoalCount++
+			}
+			stats.CompletionByTag[tag] = byTag
+		}
+
+		if ms, ok := memberStats[g.OwnerId]; ok && g.GoalType == models.GoalTypeIndividual {
+			ms.GoalCount++
+			if completed {
+				ms.CompletedGoalCount++
+			}
+		}
+	}
+	for goalType, c := range stats.CompletionByType {
+		c.CompletionRate = ratio(c.CompletedGoalCount, c.GoalCount)
+		stats.CompletionByType[goalType] = c
+	}
+	for tag, c := range stats.CompletionByTag {
+		c.CompletionRate = ratio(c.CompletedGoalCount, c.GoalCount)
+		stats.CompletionByTag[tag] = c
+	}
+
+	// Reports and their ratings
+	reportsPerWeek := make(map[time.Time]int)
+	ratingSum, ratingCount := 0, 0
+	for _, r := range reports {
+		week := isoWeekStart(r.CreatedAt, loc)
+		reportsPerWeek[week]++
+
+		ms, isMember := memberStats[r.AuthorId]
+		if isMember {
+			ms.ReportCount++
+			memberWeeks[r.AuthorId][week] = struct{}{}
+			if ms.LastReportAt == nil || r.CreatedAt.After(*ms.LastReportAt) {
+				createdAt := r.CreatedAt
+				ms.LastReportAt = &createdAt
+			}
+		}
+		for _, e := range entriesByReport[r.Id] {
+			rating := strconv.Itoa(int(e.Rating))
+			stats.RatingDistribution[rating]++
+			ratingSum += int(e.Rating)
+			ratingCount++
+			if isMember {
+				ms.RatingDistribution[rating]++
+				ms.RatingCount++
+				memberRatingSums[r.AuthorId] += int(e.Rating)
+			}
+		}
+	}
+	stats.AverageRating = average(ratingSum, ratingCount)
+	stats.ReportsPerWeek = weeklyReportCounts(season, reportsPerWeek, now, loc)
+
+	for _, a := range attendance {
+		ms, ok := memberStats[a.UserId]
+		if !ok {
+			continue
+		}
+		ms.Attendance.Recorded++
+		switch a.Status {
+		case models.AttendanceStatusPresent:
+			ms.Attendance.Present++
+		case models.AttendanceStatusLate:
+			ms.Attendance.Late++
+		case models.AttendanceStatusExcused:
+			ms.Attendance.Excused++
+		case models.AttendanceStatusAbsent:
+			ms.Attendance.Absent++
+		}
+	}
+
+	// Engagement: share of the season's elapsed weeks in which the member reported at least once
+	elapsedWeeks := elapsedSeasonWeeks(season, now, loc)
+	for _, m := range members {
+		ms := memberStats[m.UserId]
+		ms.CompletionRate = ratio(ms.CompletedGoalCount, ms.GoalCount)
+		ms.AverageRating = average(memberRatingSums[m.UserId], ms.RatingCount)
+		ms.ActiveWeeks = len(memberWeeks[m.UserId])
+		if expected := ms.Attendance.Recorded - ms.Attendance.Excused; expected > 0 {
+			rate := ratio(ms.Attendance.Present+ms.Attendance.Late, expected)
+			ms.Attendance.Rate = &rate
+		}
+		if elapsedWeeks > 0 {
+			ms.EngagementScore = ratio(ms.ActiveWeeks, elapsedWeeks)
+		}
+		stats.Members = append(stats.Members, *ms)
+	}
+	rankMembersByEngagement(stats.Members)
+
+	return stats, nil
+}
+
+// rankMembersByEngagement sorts members by engagement score, then report count, and assigns competition
+// ranks (1, 2, 2, 4, ...) so members with equal score and report count share a rank.
+func rankMembersByEngagement(members []models.MemberSeasonStats) {
+	sort.SliceStable(members, func(i, j int) bool {
+		if members[i].EngagementScore != members[j].EngagementScore {
+			return members[i].EngagementScore > members[j].EngagementScore
+		}
+		if members[i].ReportCount != members[j].ReportCount {
+			return members[i].ReportCount > members[j].ReportCount
+		}
+		return members[i].UserId < members[j].UserId
+	})
+	for i := range members {
+		if i > 0 && members[i].EngagementScore == members[i-1].EngagementScore && members[i].ReportCount == members[i-1].ReportCount {
+			members[i].EngagementRank = members[i-1].EngagementRank
+		} else {
+			members[i].EngagementRank = i + 1
+		}
+	}
+}
+
+// weeklyReportCounts lists every ISO week from the season start up to today (or the season end, if earlier),
+// extended to cover reports created outside the season dates, with the number of reports created in each.
+func weeklyReportCounts(season *models.Season, counts map[time.Time]int, now time.Time, loc *time.Location) []models.WeeklyReportCount {
+	first := isoWeekStart(season.StartDate, loc)
+	last := isoWeekStart(now, loc)
+	if season.EndDate.Before(now) {
+		last = isoWeekStart(season.EndDate, loc)
+	}
+	for week := range counts {
+		if week.Before(first) {
+			first = week
+		}
+		if week.After(last) {
+			last = week
+		}
+	}
+
+	weeks := make([]models.WeeklyReportCount, 0)
+	for week := first; !week.After(last); week = week.AddDate(0, 0, 7) {
+		year, number := week.ISOWeek()
+		weeks = append(weeks, models.WeeklyReportCount{
+			Week:      fmt.Sprintf("%d-W%02d", year, number),
+			WeekStart: week,
+			Count:     counts[week],
+		})
+	}
+	return weeks
+}
+
+// elapsedSeasonWeeks counts the ISO weeks of the season up to today, or all of them once the season has ended.
+func elapsedSeasonWeeks(season *models.Season, now time.Time, loc *time.Location) int {
+	first := isoWeekStart(season.StartDate, loc)
+	last := isoWeekStart(now, loc)
+	if season.EndDate.Before(now) {
+		last = isoWeekStart(season.EndDate, loc)
+	}
+	if last.Before(first) {
+		return 0
+	}
+	return int(last.Sub(first).Hours()/24/7+0.5) + 1
+}
+
+// isoWeekStart returns midnight of the Monday starting t's ISO week in loc.
+func isoWeekStart(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	offset := (int(t.Weekday()) + 6) % 7
+	y, m, d := t.Date()
+	return time.Date(y, m, d-offset, 0, 0, 0, 0, loc)
+}
+
+func ratio(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(total)*100) / 100
+}
+
+func average(sum, count int) *float64 {
+	if count == 0 {
+		return nil
+	}
+	avg := math.Round(float64(sum)/float64(count)*100) / 100
+	return &avg
+}
+
+// CloneSeason creates a new season for the same team from an existing one. Depending on the request it
+// copies the non-archived team goals, the individual goals of members that are still active on the team and
+// the questionnaire. Copied goals start over as open.
+func CloneSeason(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	seasonId := event.PathParameters["seasonId"]
+	source, err := db.GetSeasonById(ctx, seasonId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if source == nil {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorSeasonNotFound, nil)
+	}
+
+	var body CloneSeasonRequest
+	if err := json.Unmarshal([]byte(event.Body), &body); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+	if body.Name == "" {
+		body.Name = source.Name
+	}
+	if fieldErrors := validateSeasonDates(body.StartDate, body.EndDate); len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidSeasonDates, fieldErrors)
+	}
+	overlapping, err := findOverlappingSeason(ctx, source.TeamId, "", body.StartDate, body.EndDate)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
 	}
+	if overlapping != nil {
+		return utils.ErrorResponse(http.StatusConflict, utils.MsgErrorSeasonOverlap, nil)
+	}
 
-	reportCount, err := db.CountProgressReportsBySeasonId(ctx, seasonId)
+	season, err := db.CreateSeason(ctx, source.TeamId, body.Name, body.StartDate, body.EndDate)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
 	}
 
-	members, err := db.GetMembershipsByTeamID(ctx, teamId)
+	summary := CloneSeasonSummary{}
+	if body.CopyTeamGoals || body.CopyIndividualGoals {
+		callerId := utils.GetCognitoUsername(event.RequestContext.Authorizer)
+		if err := cloneGoals(ctx, source, season, callerId, body.CopyTeamGoals, body.CopyIndividualGoals, &summary); err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+	}
+	if body.CopyQuestionnaire {
+		questionnaire, err := db.GetQuestionnaireBySeasonId(ctx, source.Id)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		if questionnaire != nil {
+			if _, err := db.CreateQuestionnaire(ctx, season.TeamId, season.Id, questionnaire.Questions); err != nil {
+				return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+			}
+			summary.Questionnaire = true
+		}
+	}
+
+	return utils.SuccessResponse(http.StatusCreated, utils.MsgSuccess, map[string]interface{}{
+		"season": season,
+		"copied": summary,
+	})
+}
+
+// cloneGoals copies the goals of source into target. Team goals whose owner left the team are handed to the caller;
+// individual goals of members that left are skipped.
+func cloneGoals(ctx context.Context, source, target *models.Season, callerId string, teamGoals, individualGoals bool, summary *CloneSeasonSummary) error {
+	goals, err := db.ListAllGoalsBySeasonId(ctx, source.Id)
+	if err != nil {
+		return err
+	}
+	members, err := db.GetMembershipsByTeamID(ctx, source.TeamId)
+	if err != nil {
+		return err
+	}
+	activeMembers := make(map[string]struct{}, len(members))
+	for _, m := range members {
+		activeMembers[m.UserId] = struct{}{}
+	}
+
+	for _, g := range goals {
+		if g.Status == models.GoalStatusArchived {
+			continue
+		}
+		_, ownerActive := activeMembers[g.OwnerId]
+		ownerId := g.OwnerId
+		switch g.GoalType {
+		case models.GoalTypeTeam:
+			if !teamGoals {
+				continue
+			}
+			if !ownerActive {
+				ownerId = callerId
+			}
+		case models.GoalTypeIndividual:
+			if !individualGoals {
+				continue
+			}
+			if !ownerActive {
+				summary.SkippedIndividualGoals++
+				continue
+			}
+		default:
+			continue
+		}
+
+		var metric *models.GoalMetric
+		if g.Metric != nil {
+			// The target carries over, the measured value starts over in the new season
+			metric = &models.GoalMetric{Source: g.Metric.Source, Stat: g.Metric.Stat, Comparison: g.Metric.Comparison, Target: g.Metric.Target}
+		}
+		goal, err := db.CreateGoal(ctx, target.Id, ownerId, g.GoalType, g.Title, g.Description, nil, metric, g.Tags, g.SubgroupId)
+		if err != nil {
+			return err
+		}
+		if g.Picture != "" {
+			if err := db.UpdateGoalPicture(ctx, goal.Id, g.Picture); err != nil {
+				return err
+			}
+		}
+		if g.GoalType == models.GoalTypeTeam {
+			summary.TeamGoals++
+		} else {
+			summary.IndividualGoals++
+		}
+	}
+	return nil
+}
+
+// TransitionSeasonStatuses is run on a schedule rather than through the API. It moves planned seasons to active
+// once their start date is reached and active seasons to completed once their end date has passed, both judged
+// by the current date in the team's time zone. Every transition is recorded as activity and mailed to the team's
+// members whose role can manage seasons.
+func TransitionSeasonStatuses(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	seasons, err := db.ListSeasonsByStatus(ctx, models.SeasonStatusPlanned, models.SeasonStatusActive)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
 	}
 
+	now := time.Now()
+	teams := make(map[string]*models.Team)
+	transitioned := make([]*models.Season, 0)
+	for _, season := range seasons {
+		team, ok := teams[season.TeamId]
+		if !ok {
+			team, err = db.GetTeamById(ctx, season.TeamId)
+			if err != nil {
+				log.WithError(err).WithField("teamId", season.TeamId).Warn("failed to load team for season transition")
+				continue
+			}
+			teams[season.TeamId] = team
+		}
+		if team == nil {
+			continue
+		}
+
+		status := nextSeasonStatus(season, now.In(team.Location()))
+		if status == season.Status {
+			continue
+		}
+		updated, err := db.UpdateSeason(ctx, season.Id, nil, nil, nil, &status)
+		if err != nil {
+			log.WithError(err).WithField("seasonId", season.Id).Error("failed to transition season")
+			continue
+		}
+		activity.EmitSeasonStatusChanged(ctx, team.Id, updated.Name, updated.Status, updated.Id)
+		notifySeasonStatusChanged(ctx, team, updated)
+		transitioned = append(transitioned, updated)
+	}
+
 	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
-		"stats": map[string]interface{}{
-			"goalCount":           goalCount,
-			"completedGoalCount":  completedGoalCount,
-			"openGoalCount":       openGoalCount,
-			"inProgressGoalCount": inProgressGoalCount,
-			"reportCount":         reportCount,
-			"memberCount":         len(members),
-		},
+		"items": transitioned,
+		"count": len(transitioned),
 	})
 }
 
-func isAuthorizedForSeason(ctx context.Context, authorizer map[string]interface{}, seasonId string, teamUser bool) (bool, bool, error) {
-	season, err := db.GetSeasonById(ctx, seasonId)
+// nextSeasonStatus returns the status a season should have on the given day. Seasons only move forward:
+// planned -> active -> completed. Start and end dates are inclusive calendar days.
+func nextSeasonStatus(season *models.Season, now time.Time) models.SeasonStatus {
+	today := calendarDay(now)
+	switch {
+	case today.After(calendarDay(season.EndDate)):
+		return models.SeasonStatusCompleted
+	case season.Status == models.SeasonStatusPlanned && !today.Before(calendarDay(season.StartDate)):
+		return models.SeasonStatusActive
+	}
+	return season.Status
+}
+
+// calendarDay strips the time of day, keeping the date as it reads in t's own location.
+func calendarDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func notifySeasonStatusChanged(ctx context.Context, team *models.Team, season *models.Season) {
+	members, err := db.GetMembershipsByTeamID(ctx, team.Id)
 	if err != nil {
-		return false, false, err
+		log.WithError(err).WithField("teamId", team.Id).Warn("failed to list members for season notification")
+		return
 	}
-	if season == nil {
-		return false, false, nil
+	for _, m := range members {
+		manages, err := utils.RoleHasPermission(ctx, team.Id, m.Role, models.PermissionSeasonsManage)
+		if err != nil || !manages {
+			continue
+		}
+		u, err := users.GetUserBySub(ctx, m.UserId)
+		if err != nil || u == nil {
+			log.WithError(err).WithField("userId", m.UserId).Warn("failed to load trainer for season notification")
+			continue
+		}
+		name, _ := activity.ResolveActorInfo(u)
+		if err := mail.SendSeasonStatusEmail(ctx, u.Email, name, team, season); err != nil {
+			log.WithError(err).WithField("userId", m.UserId).Warn("failed to send season status email")
+		}
+	}
+}
+
+func validateSeasonDates(start, end time.Time) []utils.FieldError {
+	fieldErrors := make([]utils.FieldError, 0)
+	if start.IsZero() {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "startDate", Message: "startDate is required"})
 	}
-	// Authorization: only admins or team admins/trainers can access season details
-	if teamUser {
-		if !utils.HasTeamAccess(ctx, authorizer, season.TeamId) {
-			return false, true, nil
+	if end.IsZero() {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "endDate", Message: "endDate is required"})
+	}
+	if len(fieldErrors) == 0 && !end.After(start) {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "endDate", Message: "endDate must be after startDate"})
+	}
+	return fieldErrors
+}
+
+// isOpenSeasonStatus reports whether a season with this status is or will become active.
+func isOpenSeasonStatus(status models.SeasonStatus) bool {
+	return status == models.SeasonStatusPlanned || status == models.SeasonStatusActive
+}
+
+// findOverlappingSeason returns a planned or active season of the team, other than excludeId, whose dates
+// overlap the given range. A team can only run one season at a time.
+func findOverlappingSeason(ctx context.Context, teamId, excludeId string, start, end time.Time) (*models.Season, error) {
+	seasons, err := db.GetAllSeasonsByTeamId(ctx, teamId)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range seasons {
+		if s.Id == excludeId || !isOpenSeasonStatus(s.Status) {
+			continue
 		}
-	} else {
-		if !utils.IsTeamAdminOrTrainer(ctx, authorizer, season.TeamId) {
-			return false, true, nil
+		if !s.EndDate.Before(start) && !end.Before(s.StartDate) {
+			return s, nil
 		}
 	}
-	return true, true, nil
+	return nil, nil
 }
@@ -19,3 +19,20 @@ type UpdateSeasonRequest struct {
 	EndDate   *time.Time           `json:"endDate,omitempty"`
 	Status    *models.SeasonStatus `json:"status,omitempty"`
 }
+
+type CloneSeasonRequest struct {
+	Name                string    `json:"name"`
+	StartDate           time.Time `json:"startDate"`
+	EndDate             time.Time `json:"endDate"`
+	CopyTeamGoals       bool      `json:"copyTeamGoals"`
+	CopyIndividualGoals bool      `json:"copyIndividualGoals"`
+	CopyQuestionnaire   bool      `json:"copyQuestionnaire"`
+}
+
+// CloneSeasonSummary tells the caller what was copied into the new season.
+type CloneSeasonSummary struct {
+	TeamGoals              int  `json:"teamGoals"`
+	IndividualGoals        int  `json:"individualGoals"`
+	SkippedIndividualGoals int  `json:"skippedIndividualGoals"`
+	Questionnaire          bool `json:"questionnaire"`
+}
@@ -24,7 +24,8 @@ func GetSelf(ctx context.Context, event events.APIGatewayProxyRequest) (*events.
 	if user == nil {
 		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorNotFound, nil)
 	}
-	assignments, err := db.GetTeamAssignmentsByUserID(ctx, username)
+	includeArchived := event.QueryStringParameters["includeArchived"] == "true"
+	assignments, err := db.GetTeamAssignmentsByUserID(ctx, username, includeArchived)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
 	}
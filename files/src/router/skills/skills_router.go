@@ -0,0 +1,426 @@
+package skills
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpgschiba/volleygoals/db"
+	"github.com/fpgschiba/volleygoals/models"
+	"github.com/fpgschiba/volleygoals/utils"
+)
+
+const (
+	ratingMin = 1
+	ratingMax = 5
+
+	assessmentPageSize    = 25
+	assessmentMaxPageSize = 100
+)
+
+func GetSkillCatalogue(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	if teamId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	catalogue, err := db.GetSkillCatalogue(ctx, teamId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if catalogue == nil {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorSkillCatalogueNotFound, nil)
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"catalogue": catalogue,
+	})
+}
+
+// UpdateSkillCatalogue creates or replaces the skill catalogue of a team. Ratings of removed skills stay on past
+// assessments but no longer show up in the matrix or radar.
+func UpdateSkillCatalogue(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	if teamId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	var request UpdateSkillCatalogueRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+	skills, fieldErrors := buildSkills(request.Skills)
+	if len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidSkillCatalogue, fieldErrors)
+	}
+
+	catalogue, err := db.GetSkillCatalogue(ctx, teamId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if catalogue == nil {
+		catalogue = &models.SkillCatalogue{TeamId: teamId}
+	}
+	catalogue.Skills = skills
+	if err := db.PutSkillCatalogue(ctx, catalogue); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"catalogue": catalogue,
+	})
+}
+
+func CreateSkillAssessment(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	if teamId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	var request CreateSkillAssessmentRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+
+	catalogue, err := db.GetSkillCatalogue(ctx, teamId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if catalogue == nil {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorSkillCatalogueNotFound, nil)
+	}
+	members, err := db.GetMembershipsByTeamID(ctx, teamId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	now := time.Now()
+	assessedAt := now
+	if request.AssessedAt != nil {
+		assessedAt = *request.AssessedAt
+	}
+	fieldErrors := validateAssessment(catalogue, members, request.UserId, assessedAt, now, request.Ratings)
+	if len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidSkillAssessment, fieldErrors)
+	}
+
+	assessment, err := db.CreateSkillAssessment(ctx, &models.SkillAssessment{
+		TeamId:     teamId,
+		UserId:     request.UserId,
+		AssessedAt: assessedAt,
+		AssessedBy: utils.GetCognitoUsername(event.RequestContext.Authorizer),
+		Ratings:    request.Ratings,
+		Notes:      strings.TrimSpace(request.Notes),
+	})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusCreated, utils.MsgSuccess, map[string]interface{}{
+		"assessment": assessment,
+	})
+}
+
+// ListSkillAssessments returns the skill assessments of a team, newest first. Team admins and trainers can narrow
+// the list down to one player with userId; other members always get only their own assessments.
+func ListSkillAssessments(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	if teamId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+	options, err := db.FilterOptionsFromQuery(event.QueryStringParameters, assessmentPageSize, assessmentMaxPageSize)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+
+	userId := strings.TrimSpace(event.QueryStringParameters["userId"])
+	if !canSeeAllAssessments(ctx, event.RequestContext.Authorizer, teamId) {
+		userId = utils.GetCognitoUsername(event.RequestContext.Authorizer)
+	}
+
+	all, err := db.ListSkillAssessmentsByTeamId(ctx, teamId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	matching := make([]*models.SkillAssessment, 0, len(all))
+	for _, a := range all {
+		if userId == "" || a.UserId == userId {
+			matching = append(matching, a)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		if !matching[i].AssessedAt.Equal(matching[j].AssessedAt) {
+			return matching[i].AssessedAt.After(matching[j].AssessedAt)
+		}
+		return matching[i].Id < matching[j].Id
+	})
+
+	// The cursor holds the ID of the last assessment of the previous page.
+	start := 0
+	if options.Cursor != nil && options.Cursor.LastID != "" {
+		start = len(matching)
+		for i, a := range matching {
+			if a.Id == options.Cursor.LastID {
+				start = i + 1
+				break
+			}
+		}
+	}
+	end := start + options.Limit
+	if end > len(matching) {
+		end = len(matching)
+	}
+	items := matching[start:end]
+	hasMore := end < len(matching)
+	nextToken := ""
+	if hasMore {
+		nextToken, err = models.EncodeCursor(&models.Cursor{LastID: items[len(items)-1].Id})
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"items":     items,
+		"count":     len(items),
+		"nextToken": nextToken,
+		"hasMore":   hasMore,
+	})
+}
+
+func DeleteSkillAssessment(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	assessmentId := event.PathParameters["assessmentId"]
+	if teamId == "" || assessmentId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	assessment, err := db.GetSkillAssessmentById(ctx, assessmentId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if assessment == nil || assessment.TeamId != teamId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorSkillAssessmentNotFound, nil)
+	}
+	if err := db.DeleteSkillAssessment(ctx, assessmentId); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusNoContent, utils.MsgSuccess, nil)
+}
+
+// GetSkillMatrix returns the latest rating of every catalogue skill for every active team member. Members without
+// the skills.assess permission only get their own row.
+func GetSkillMatrix(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	if teamId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	catalogue, err := db.GetSkillCatalogue(ctx, teamId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if catalogue == nil {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorSkillCatalogueNotFound, nil)
+	}
+	members, err := db.GetMembershipsByTeamID(ctx, teamId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if !canSeeAllAssessments(ctx, event.RequestContext.Authorizer, teamId) {
+		callerId := utils.GetCognitoUsername(event.RequestContext.Authorizer)
+		own := make([]*models.TeamMember, 0, 1)
+		for _, m := range members {
+			if m.UserId == callerId {
+				own = append(own, m)
+			}
+		}
+		members = own
+	}
+	assessments, err := db.ListSkillAssessmentsByTeamId(ctx, teamId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"skills": catalogue.Skills,
+		"rows":   skillMatrix(catalogue, members, assessments),
+	})
+}
+
+// GetMemberSkillRadar returns the assessments of one team member in chronological order, with the ratings of each
+// assessment lined up with the catalogue skills so they can be drawn as radar charts over time.
+func GetMemberSkillRadar(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	teamMemberId := event.PathParameters["memberId"]
+	if teamId == "" || teamMemberId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	member, err := db.GetTeamMemberById(ctx, teamMemberId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if member == nil || member.TeamId != teamId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorUserNotFound, nil)
+	}
+
+	catalogue, err := db.GetSkillCatalogue(ctx, teamId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if catalogue == nil {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorSkillCatalogueNotFound, nil)
+	}
+	assessments, err := db.ListSkillAssessmentsByTeamId(ctx, teamId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"userId": member.UserId,
+		"skills": catalogue.Skills,
+		"points": radarPoints(catalogue, member.UserId, assessments),
+	})
+}
+
+// canSeeAllAssessments reports whether the caller may see the assessments of every player of the team.
+func canSeeAllAssessments(ctx context.Context, authorizer map[string]interface{}, teamId string) bool {
+	return utils.HasPermission(ctx, authorizer, teamId, models.PermissionSkillsAssess)
+}
+
+// skillMatrix picks the latest rating per member and catalogue skill. Skills a member was never rated on have
+// no cell.
+func skillMatrix(catalogue *models.SkillCatalogue, members []*models.TeamMember, assessments []*models.SkillAssessment) []MatrixRow {
+	sort.Slice(assessments, func(i, j int) bool { return assessments[i].AssessedAt.Before(assessments[j].AssessedAt) })
+	latest := make(map[string]map[string]MatrixCell)
+	for _, a := range assessments {
+		cells, ok := latest[a.UserId]
+		if !ok {
+			cells = make(map[string]MatrixCell)
+			latest[a.UserId] = cells
+		}
+		for _, r := range a.Ratings {
+			cells[r.SkillId] = MatrixCell{SkillId: r.SkillId, Rating: r.Rating, AssessedAt: a.AssessedAt, AssessmentId: a.Id}
+		}
+	}
+
+	rows := make([]MatrixRow, 0, len(members))
+	for _, m := range members {
+		row := MatrixRow{UserId: m.UserId, MemberId: m.Id, Role: m.Role, Cells: make([]MatrixCell, 0, len(catalogue.Skills))}
+		for _, s := range catalogue.Skills {
+			if cell, ok := latest[m.UserId][s.Id]; ok {
+				row.Cells = append(row.Cells, cell)
+			}
+		}
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].UserId < rows[j].UserId })
+	return rows
+}
+
+func radarPoints(catalogue *models.SkillCatalogue, userId string, assessments []*models.SkillAssessment) []RadarPoint {
+	points := make([]RadarPoint, 0)
+	for _, a := range assessments {
+		if a.UserId != userId {
+			continue
+		}
+		ratings := make(map[string]int, len(a.Ratings))
+		for _, r := range a.Ratings {
+			ratings[r.SkillId] = r.Rating
+		}
+		point := RadarPoint{AssessmentId: a.Id, AssessedAt: a.AssessedAt, Ratings: make([]*int, len(catalogue.Skills))}
+		for i, s := range catalogue.Skills {
+			if rating, ok := ratings[s.Id]; ok {
+				point.Ratings[i] = &rating
+			}
+		}
+		points = append(points, point)
+	}
+	sort.Slice(points, func(i, j int) bool {
+		if !points[i].AssessedAt.Equal(points[j].AssessedAt) {
+			return points[i].AssessedAt.Before(points[j].AssessedAt)
+		}
+		return points[i].AssessmentId < points[j].AssessmentId
+	})
+	return points
+}
+
+// buildSkills validates the requested catalogue and assigns IDs to new skills.
+// Existing IDs are kept so past ratings stay linked to their skill.
+func buildSkills(requested []SkillRequest) ([]models.Skill, []utils.FieldError) {
+	fieldErrors := make([]utils.FieldError, 0)
+	skills := make([]models.Skill, 0, len(requested))
+	ids := make(map[string]struct{}, len(requested))
+	names := make(map[string]struct{}, len(requested))
+	for i, r := range requested {
+		field := fmt.Sprintf("skills[%d]", i)
+		s := models.Skill{
+			Id:          r.Id,
+			Name:        strings.TrimSpace(r.Name),
+			Description: strings.TrimSpace(r.Description),
+		}
+		if s.Id == "" {
+			s.Id = models.GenerateID()
+		}
+		if _, dup := ids[s.Id]; dup {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field + ".id", Message: "duplicate id"})
+		}
+		ids[s.Id] = struct{}{}
+		if s.Name == "" {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field + ".name", Message: "is required"})
+		} else if _, dup := names[strings.ToLower(s.Name)]; dup {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field + ".name", Message: "duplicate name"})
+		}
+		names[strings.ToLower(s.Name)] = struct{}{}
+		skills = append(skills, s)
+	}
+	return skills, fieldErrors
+}
+
+// validateAssessment checks that an assessment rates an active member on distinct catalogue skills within the
+// rating scale and is not dated in the future.
+func validateAssessment(catalogue *models.SkillCatalogue, members []*models.TeamMember, userId string, assessedAt, now time.Time, ratings []models.SkillRating) []utils.FieldError {
+	fieldErrors := make([]utils.FieldError, 0)
+	isMember := false
+	for _, m := range members {
+		if m.UserId == userId {
+			isMember = true
+			break
+		}
+	}
+	if !isMember {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "userId", Message: "not an active member of the team"})
+	}
+	if assessedAt.After(now) {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "assessedAt", Message: "must not be in the future"})
+	}
+	if len(ratings) == 0 {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "ratings", Message: "at least one rating is required"})
+	}
+
+	skillIds := make(map[string]struct{}, len(catalogue.Skills))
+	for _, s := range catalogue.Skills {
+		skillIds[s.Id] = struct{}{}
+	}
+	rated := make(map[string]struct{}, len(ratings))
+	for i, r := range ratings {
+		field := fmt.Sprintf("ratings[%d]", i)
+		if _, ok := skillIds[r.SkillId]; !ok {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field + ".skillId", Message: "not a skill of the team's catalogue"})
+		} else if _, dup := rated[r.SkillId]; dup {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field + ".skillId", Message: "skill is rated more than once"})
+		}
+		rated[r.SkillId] = struct{}{}
+		if r.Rating < ratingMin || r.Rating > ratingMax {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: field + ".rating", Message: fmt.Sprintf("must be between %d and %d", ratingMin, ratingMax)})
+		}
+	}
+	return fieldErrors
+}
@@ -0,0 +1,48 @@
+package skills
+
+import (
+	"time"
+
+	"github.com/fpgschiba/volleygoals/models"
+)
+
+type SkillRequest struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type UpdateSkillCatalogueRequest struct {
+	Skills []SkillRequest `json:"skills"`
+}
+
+type CreateSkillAssessmentRequest struct {
+	UserId     string               `json:"userId"`
+	AssessedAt *time.Time           `json:"assessedAt"`
+	Ratings    []models.SkillRating `json:"ratings"`
+	Notes      string               `json:"notes"`
+}
+
+// MatrixCell is the latest rating of one skill of one player.
+type MatrixCell struct {
+	SkillId      string    `json:"skillId"`
+	Rating       int       `json:"rating"`
+	AssessedAt   time.Time `json:"assessedAt"`
+	AssessmentId string    `json:"assessmentId"`
+}
+
+// MatrixRow holds the latest ratings of one player, one cell per rated catalogue skill.
+type MatrixRow struct {
+	UserId   string                `json:"userId"`
+	MemberId string                `json:"memberId"`
+	Role     models.TeamMemberRole `json:"role"`
+	Cells    []MatrixCell          `json:"cells"`
+}
+
+// RadarPoint is one assessment of a player. Ratings line up with the skills of the radar response and are nil
+// for skills the assessment did not rate.
+type RadarPoint struct {
+	AssessmentId string    `json:"assessmentId"`
+	AssessedAt   time.Time `json:"assessedAt"`
+	Ratings      []*int    `json:"ratings"`
+}
@@ -0,0 +1,236 @@
+package subgroups
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpgschiba/volleygoals/db"
+	"github.com/fpgschiba/volleygoals/models"
+	"github.com/fpgschiba/volleygoals/utils"
+)
+
+func CreateSubgroup(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	if teamId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	var request CreateSubgroupRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+
+	subgroup := &models.Subgroup{
+		TeamId:      teamId,
+		Name:        strings.TrimSpace(request.Name),
+		Description: request.Description,
+		MemberIds:   request.MemberIds,
+		TrainerIds:  request.TrainerIds,
+		CreatedBy:   utils.GetCognitoUsername(event.RequestContext.Authorizer),
+	}
+	if subgroup.MemberIds == nil {
+		subgroup.MemberIds = []string{}
+	}
+	if subgroup.TrainerIds == nil {
+		subgroup.TrainerIds = []string{}
+	}
+	fieldErrors, err := validateSubgroup(ctx, subgroup, nil)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidSubgroup, fieldErrors)
+	}
+
+	subgroup, err = db.CreateSubgroup(ctx, subgroup)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusCreated, utils.MsgSuccess, map[string]interface{}{
+		"subgroup": subgroup,
+	})
+}
+
+// ListSubgroups returns the subgroups of a team ordered by name. With memberId only the subgroups the user is
+// assigned to or trains are returned.
+func ListSubgroups(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	if teamId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+	memberId := strings.TrimSpace(event.QueryStringParameters["memberId"])
+
+	all, err := db.ListSubgroupsByTeamId(ctx, teamId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	items := make([]*models.Subgroup, 0, len(all))
+	for _, s := range all {
+		if memberId != "" && !s.HasMember(memberId) && !s.HasTrainer(memberId) {
+			continue
+		}
+		items = append(items, s)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		a, b := strings.ToLower(items[i].Name), strings.ToLower(items[j].Name)
+		if a != b {
+			return a < b
+		}
+		return items[i].Id < items[j].Id
+	})
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"items": items,
+		"count": len(items),
+	})
+}
+
+func GetSubgroup(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	subgroup, resp, err := loadSubgroup(ctx, event)
+	if resp != nil {
+		return resp, err
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"subgroup": subgroup,
+	})
+}
+
+// UpdateSubgroup updates the given fields of a subgroup. Members and trainers are replaced as a whole; only
+// callers allowed to manage subgroups may change the trainers, so assistant trainers cannot hand their subgroup on.
+func UpdateSubgroup(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	var request UpdateSubgroupRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+
+	subgroup, resp, err := loadSubgroup(ctx, event)
+	if resp != nil {
+		return resp, err
+	}
+	if request.TrainerIds != nil && !utils.HasPermission(ctx, event.RequestContext.Authorizer, subgroup.TeamId, models.PermissionSubgroupsManage) {
+		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	}
+
+	previous := *subgroup
+	if request.Name != nil {
+		subgroup.Name = strings.TrimSpace(*request.Name)
+	}
+	if request.Description != nil {
+		subgroup.Description = *request.Description
+	}
+	if request.MemberIds != nil {
+		subgroup.MemberIds = append([]string{}, *request.MemberIds...)
+	}
+	if request.TrainerIds != nil {
+		subgroup.TrainerIds = append([]string{}, *request.TrainerIds...)
+	}
+	fieldErrors, err := validateSubgroup(ctx, subgroup, &previous)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidSubgroup, fieldErrors)
+	}
+
+	if err := db.UpdateSubgroup(ctx, subgroup); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"subgroup": subgroup,
+	})
+}
+
+// DeleteSubgroup deletes a subgroup. Its goals stay with the team and are no longer assigned to a subgroup.
+func DeleteSubgroup(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	subgroup, resp, err := loadSubgroup(ctx, event)
+	if resp != nil {
+		return resp, err
+	}
+	if err := db.UnassignGoalsFromSubgroup(ctx, subgroup.TeamId, subgroup.Id); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if err := db.DeleteSubgroup(ctx, subgroup.Id); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusNoContent, utils.MsgSuccess, nil)
+}
+
+// LoadForTeam returns the subgroup of the team named by the subgroupId query parameter, or nil if the request
+// does not name one. It returns an error response if the subgroup does not exist in the team.
+func LoadForTeam(ctx context.Context, teamId string, query map[string]string) (*models.Subgroup, *events.APIGatewayProxyResponse, error) {
+	subgroupId := strings.TrimSpace(query["subgroupId"])
+	if subgroupId == "" {
+		return nil, nil, nil
+	}
+	return getSubgroup(ctx, teamId, subgroupId)
+}
+
+func loadSubgroup(ctx context.Context, event events.APIGatewayProxyRequest) (*models.Subgroup, *events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	subgroupId := event.PathParameters["subgroupId"]
+	if teamId == "" || subgroupId == "" {
+		resp, e := utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+		return nil, resp, e
+	}
+	return getSubgroup(ctx, teamId, subgroupId)
+}
+
+func getSubgroup(ctx context.Context, teamId, subgroupId string) (*models.Subgroup, *events.APIGatewayProxyResponse, error) {
+	subgroup, err := db.GetSubgroupById(ctx, subgroupId)
+	if err != nil {
+		resp, e := utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		return nil, resp, e
+	}
+	if subgroup == nil || subgroup.TeamId != teamId {
+		resp, e := utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorSubgroupNotFound, nil)
+		return nil, resp, e
+	}
+	return subgroup, nil, nil
+}
+
+// validateSubgroup checks the subgroup's fields and that its members and trainers are distinct active members of
+// the team. Users already assigned before the update are kept even if they have left the team since.
+func validateSubgroup(ctx context.Context, subgroup *models.Subgroup, previous *models.Subgroup) ([]utils.FieldError, error) {
+	fieldErrors := make([]utils.FieldError, 0)
+	if subgroup.Name == "" {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "name", Message: "is required"})
+	}
+
+	members, err := db.GetMembershipsByTeamID(ctx, subgroup.TeamId)
+	if err != nil {
+		return nil, err
+	}
+	active := make(map[string]struct{}, len(members))
+	for _, m := range members {
+		if m.Status == models.TeamMemberStatusActive {
+			active[m.UserId] = struct{}{}
+		}
+	}
+
+	check := func(field string, ids []string, kept func(userId string) bool) {
+		seen := make(map[string]int, len(ids))
+		for i, userId := range ids {
+			name := fmt.Sprintf("%s[%d]", field, i)
+			if first, dup := seen[userId]; dup {
+				fieldErrors = append(fieldErrors, utils.FieldError{Field: name, Message: fmt.Sprintf("user is already listed in %s[%d]", field, first)})
+				continue
+			}
+			seen[userId] = i
+			if _, ok := active[userId]; !ok && !kept(userId) {
+				fieldErrors = append(fieldErrors, utils.FieldError{Field: name, Message: "user is not an active member of this team"})
+			}
+		}
+	}
+	check("memberIds", subgroup.MemberIds, func(userId string) bool { return previous != nil && previous.HasMember(userId) })
+	check("trainerIds", subgroup.TrainerIds, func(userId string) bool { return previous != nil && previous.HasTrainer(userId) })
+	return fieldErrors, nil
+}
@@ -0,0 +1,15 @@
+package subgroups
+
+type CreateSubgroupRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	MemberIds   []string `json:"memberIds"`
+	TrainerIds  []string `json:"trainerIds"`
+}
+
+type UpdateSubgroupRequest struct {
+	Name        *string   `json:"name,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	MemberIds   *[]string `json:"memberIds,omitempty"`
+	TrainerIds  *[]string `json:"trainerIds,omitempty"`
+}
@@ -3,7 +3,11 @@ package team_members
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/aws/aws-lambda-go/events"
@@ -19,14 +23,7 @@ func ListTeamMembers(ctx context.Context, event events.APIGatewayProxyRequest) (
 	if !ok || teamId == "" {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
 	}
-	if !utils.IsAdmin(event.RequestContext.Authorizer) && !utils.HasTeamAccess(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-	callerRole, err := utils.GetUserRoleOnTeam(ctx, event.RequestContext.Authorizer, teamId)
-	if err != nil {
-		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
-	}
-	isMember := callerRole != nil && *callerRole == models.TeamMemberRoleMember
+	isMember := !utils.HasPermission(ctx, event.RequestContext.Authorizer, teamId, models.PermissionMembersView)
 	filter, err := db.TeamMemberFilterFromQuery(event.QueryStringParameters)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
@@ -88,6 +85,9 @@ func ListTeamMembers(ctx context.Context, event events.APIGatewayProxyRequest) (
 				PreferredUsername: user.PreferredUsername,
 				Picture:           user.Picture,
 				Email:             user.Email,
+				Positions:         item.Positions,
+				JerseyNumber:      item.JerseyNumber,
+				DominantHand:      item.DominantHand,
 			})
 		}
 		return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
@@ -112,6 +112,10 @@ func ListTeamMembers(ctx context.Context, event events.APIGatewayProxyRequest) (
 			PreferredUsername: user.PreferredUsername,
 			Birthdate:         user.Birthdate,
 			JoinedAt:          item.JoinedAt,
+			Positions:         item.Positions,
+			JerseyNumber:      item.JerseyNumber,
+			DominantHand:      item.DominantHand,
+			TrainerNotes:      item.TrainerNotes,
 		})
 	}
 	resp := models.PaginationResponse{
@@ -133,14 +137,14 @@ func AddTeamMember(ctx context.Context, event events.APIGatewayProxyRequest) (*e
 	if !ok || teamId == "" {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
 	}
-	if !utils.IsAdmin(event.RequestContext.Authorizer) && !utils.IsTeamAdminOrTrainer(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
 	var request AddTeamMemberRequest
 	err := json.Unmarshal([]byte(event.Body), &request)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
 	}
+	if resp, err := authorizeRole(ctx, event.RequestContext.Authorizer, teamId, request.Role); resp != nil {
+		return resp, err
+	}
 	user, err := users.GetUserBySub(ctx, request.UserId)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
@@ -169,20 +173,45 @@ func UpdateTeamMember(ctx context.Context, event events.APIGatewayProxyRequest)
 	if !ok || teamMemberId == "" {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
 	}
-	if !utils.IsAdmin(event.RequestContext.Authorizer) && !utils.IsTeamAdminOrTrainer(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
 	var request UpdateTeamMemberRequest
 	err := json.Unmarshal([]byte(event.Body), &request)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
 	}
-	if request.Role != nil && *request.Role == models.TeamMemberRoleAdmin {
-		if !utils.IsAdmin(event.RequestContext.Authorizer) && !utils.IsTeamAdmin(ctx, event.RequestContext.Authorizer, teamId) {
-			return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	if request.Role != nil {
+		if resp, err := authorizeRole(ctx, event.RequestContext.Authorizer, teamId, *request.Role); resp != nil {
+			return resp, err
+		}
+	}
+	if fieldErrors := validateProfile(request); len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidProfile, fieldErrors)
+	}
+	member, resp, err := loadTeamMember(ctx, teamId, teamMemberId)
+	if resp != nil {
+		return resp, err
+	}
+	demoted := request.Role != nil && !request.Role.IsAdminOrTrainer()
+	deactivated := request.Status != nil && *request.Status != models.TeamMemberStatusActive
+	keeperId := ""
+	if demoted || deactivated {
+		keeperId, resp, err = keepAdminOrTrainer(ctx, member)
+		if resp != nil {
+			return resp, err
+		}
+	}
+	if request.JerseyNumber != nil && *request.JerseyNumber > 0 {
+		taken, err := jerseyNumberTaken(ctx, teamId, teamMemberId, *request.JerseyNumber)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		if taken {
+			return utils.ErrorResponse(http.StatusConflict, utils.MsgErrorJerseyNumberTaken, nil)
 		}
 	}
-	teamMember, err := db.UpdateTeamMember(ctx, teamMemberId, request.Role, request.Status)
+	teamMember, err := db.UpdateTeamMember(ctx, teamMemberId, keeperId, request.Role, request.Status, request.Positions, request.JerseyNumber, request.DominantHand, request.TrainerNotes)
+	if errors.Is(err, db.ErrLastAdminOrTrainer) {
+		return utils.ErrorResponse(http.StatusNotAcceptable, utils.MsgErrorLastAdminOrTrainer, nil)
+	}
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
 	}
@@ -206,10 +235,18 @@ func RemoveTeamMember(ctx context.Context, event events.APIGatewayProxyRequest)
 	if !ok || teamMemberId == "" {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
 	}
-	if !utils.IsAdmin(event.RequestContext.Authorizer) && !utils.IsTeamAdminOrTrainer(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	member, resp, err := loadTeamMember(ctx, teamId, teamMemberId)
+	if resp != nil {
+		return resp, err
+	}
+	keeperId, resp, err := keepAdminOrTrainer(ctx, member)
+	if resp != nil {
+		return resp, err
+	}
+	err = db.RemoveTeamMember(ctx, teamMemberId, keeperId)
+	if errors.Is(err, db.ErrLastAdminOrTrainer) {
+		return utils.ErrorResponse(http.StatusNotAcceptable, utils.MsgErrorLastAdminOrTrainer, nil)
 	}
-	err := db.RemoveTeamMember(ctx, teamMemberId)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
 	}
@@ -236,19 +273,476 @@ func LeaveTeam(ctx context.Context, event events.APIGatewayProxyRequest) (*event
 	if userRole == nil {
 		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
 	}
+	keeperId := ""
 	if *userRole == models.TeamMemberRoleAdmin || *userRole == models.TeamMemberRoleTrainer {
-		// Only able to leave if another Trainer or Admin exists
-		hasOther, err := db.HasOtherAdminOrTrainer(ctx, teamId, userId)
+		// Only able to leave if another Trainer or Admin exists, and only while they still do
+		keeper, err := db.OtherAdminOrTrainer(ctx, teamId, userId)
 		if err != nil {
 			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
 		}
-		if !hasOther {
+		if keeper == nil {
 			return utils.ErrorResponse(http.StatusNotAcceptable, utils.MsgErrorMemberCannotLeave, nil)
 		}
+		keeperId = keeper.Id
+	}
+	err = db.LeaveTeam(ctx, teamId, userId, keeperId)
+	if errors.Is(err, db.ErrLastAdminOrTrainer) {
+		return utils.ErrorResponse(http.StatusNotAcceptable, utils.MsgErrorMemberCannotLeave, nil)
 	}
-	err = db.LeaveTeam(ctx, teamId, userId)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
 	}
 	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, nil)
 }
+
+// TransferTeamMember moves a member to another team without losing their history: the old membership ends with
+// status left and the new one records where the member came from. The caller needs members.manage on both teams.
+// With moveGoals the member's individual goals in progress in the old team's seasons move to the target season.
+func TransferTeamMember(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	teamMemberId := event.PathParameters["memberId"]
+	if teamId == "" || teamMemberId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+	var request TransferTeamMemberRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+	if request.Role == "" {
+		request.Role = models.TeamMemberRoleMember
+	}
+
+	fieldErrors := make([]utils.FieldError, 0)
+	var targetTeam *models.Team
+	if request.TargetTeamId == "" {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "targetTeamId", Message: "is required"})
+	} else if request.TargetTeamId == teamId {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "targetTeamId", Message: "must be another team"})
+	} else {
+		team, err := db.GetTeamById(ctx, request.TargetTeamId)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		if team == nil {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: "targetTeamId", Message: "team does not exist"})
+		}
+		targetTeam = team
+	}
+	if request.MoveGoals {
+		if request.TargetSeasonId == "" {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: "targetSeasonId", Message: "is required to move goals"})
+		} else if targetTeam != nil {
+			seasonTeamId, err := db.GetTeamIdBySeasonId(ctx, request.TargetSeasonId)
+			if err != nil {
+				return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+			}
+			if seasonTeamId != targetTeam.Id {
+				fieldErrors = append(fieldErrors, utils.FieldError{Field: "targetSeasonId", Message: "season does not exist in the target team"})
+			}
+		}
+	}
+	if len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidTransfer, fieldErrors)
+	}
+	if targetTeam.Status == models.TeamStatusInactive {
+		return utils.ErrorResponse(http.StatusConflict, utils.MsgErrorTeamArchived, nil)
+	}
+
+	if !utils.HasPermission(ctx, event.RequestContext.Authorizer, targetTeam.Id, models.PermissionMembersManage) {
+		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	}
+	if resp, err := authorizeRole(ctx, event.RequestContext.Authorizer, targetTeam.Id, request.Role); resp != nil {
+		return resp, err
+	}
+
+	member, err := db.GetTeamMemberById(ctx, teamMemberId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if member == nil || member.TeamId != teamId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorUserNotFound, nil)
+	}
+	if member.Status != models.TeamMemberStatusActive {
+		return utils.ErrorResponse(http.StatusConflict, utils.MsgErrorMemberNotActive, nil)
+	}
+	existing, err := db.GetTeamMemberByUserIDAndTeamID(ctx, member.UserId, targetTeam.Id)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if existing != nil {
+		return utils.ErrorResponse(http.StatusConflict, utils.MsgErrorAlreadyTeamMember, nil)
+	}
+	// Like leaving, a transfer must not take the last admin or trainer away from the team
+	last, err := db.IsLastAdminOrTrainer(ctx, member)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if last {
+		return utils.ErrorResponse(http.StatusNotAcceptable, utils.MsgErrorMemberCannotLeave, nil)
+	}
+
+	transferred, err := db.TransferTeamMember(ctx, member, targetTeam.Id, request.Role)
+	if errors.Is(err, db.ErrMembershipNotActive) {
+		return utils.ErrorResponse(http.StatusConflict, utils.MsgErrorMemberNotActive, nil)
+	}
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	movedGoals := 0
+	if request.MoveGoals {
+		if movedGoals, err = moveGoalsInProgress(ctx, teamId, targetTeam.Id, member.UserId, request.TargetSeasonId); err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+	}
+
+	callerId := utils.GetCognitoUsername(event.RequestContext.Authorizer)
+	activity.EmitMemberTransferred(ctx, teamId, callerId, member.Id, targetTeam.Name)
+	activity.EmitMemberJoined(ctx, targetTeam.Id, member.UserId)
+
+	return utils.SuccessResponse(http.StatusCreated, utils.MsgSuccess, map[string]interface{}{
+		"teamMember": transferred,
+		"movedGoals": movedGoals,
+	})
+}
+
+// moveGoalsInProgress moves the user's individual goals in progress from every season of the team to the target
+// season of the new team, together with their video clips. Drills of the old team no longer link to them.
+func moveGoalsInProgress(ctx context.Context, teamId, targetTeamId, userId, targetSeasonId string) (int, error) {
+	seasons, err := db.GetAllSeasonsByTeamId(ctx, teamId)
+	if err != nil {
+		return 0, err
+	}
+	moved := 0
+	for _, season := range seasons {
+		goals, err := db.ListAllGoalsBySeasonId(ctx, season.Id)
+		if err != nil {
+			return moved, err
+		}
+		seasonChanged := false
+		for _, g := range goals {
+			if g.OwnerId != userId || g.GoalType != models.GoalTypeIndividual || g.Status != models.GoalStatusInProgress {
+				continue
+			}
+			if err := db.UnlinkGoalFromDrills(ctx, teamId, g.Id); err != nil {
+				return moved, err
+			}
+			if err := db.MoveGoalToSeason(ctx, g, targetSeasonId); err != nil {
+				return moved, err
+			}
+			references, err := db.ListMediaReferencesByTargetId(ctx, g.Id)
+			if err != nil {
+				return moved, err
+			}
+			for _, reference := range references {
+				reference.TeamId = targetTeamId
+				if err := db.UpdateMediaReference(ctx, reference); err != nil {
+					return moved, err
+				}
+			}
+			moved++
+			seasonChanged = true
+		}
+		if seasonChanged {
+			db.InvalidateSeasonStats(ctx, season.Id)
+		}
+	}
+	if moved > 0 {
+		db.InvalidateSeasonStats(ctx, targetSeasonId)
+	}
+	return moved, nil
+}
+
+const (
+	developmentPageSize    = 5
+	developmentMaxPageSize = 25
+)
+
+// GetMemberDevelopment compares a member's individual goals and ratings season by season, newest season
+// first. The membership may have been removed or left the team; its history stays available.
+func GetMemberDevelopment(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId, ok := event.PathParameters["teamId"]
+	if !ok || teamId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+	teamMemberId, ok := event.PathParameters["memberId"]
+	if !ok || teamMemberId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+	options, err := db.FilterOptionsFromQuery(event.QueryStringParameters, developmentPageSize, developmentMaxPageSize)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+
+	member, err := db.GetTeamMemberById(ctx, teamMemberId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if member == nil || member.TeamId != teamId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorUserNotFound, nil)
+	}
+
+	seasons, err := db.GetAllSeasonsByTeamId(ctx, teamId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	sort.Slice(seasons, func(i, j int) bool {
+		if !seasons[i].StartDate.Equal(seasons[j].StartDate) {
+			return seasons[i].StartDate.After(seasons[j].StartDate)
+		}
+		return seasons[i].Id < seasons[j].Id
+	})
+
+	// The cursor holds the ID of the last season of the previous page.
+	start := 0
+	if options.Cursor != nil && options.Cursor.LastID != "" {
+		start = len(seasons)
+		for i, s := range seasons {
+			if s.Id == options.Cursor.LastID {
+				start = i + 1
+				break
+			}
+		}
+	}
+	end := start + options.Limit
+	if end > len(seasons) {
+		end = len(seasons)
+	}
+	hasMore := end < len(seasons)
+	// Only the page is summarised, plus the season before its last one for the rating change
+	window := seasons[start:end]
+	if hasMore {
+		window = seasons[start : end+1]
+	}
+	development, err := memberDevelopment(ctx, member.UserId, window)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	items := development[:end-start]
+	nextToken := ""
+	if hasMore {
+		nextToken, err = models.EncodeCursor(&models.Cursor{LastID: items[len(items)-1].SeasonId})
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+	}
+
+	if !utils.HasPermission(ctx, event.RequestContext.Authorizer, teamId, models.PermissionMembersView) {
+		member = member.WithoutTrainerNotes()
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"teamMember": member,
+		"items":      items,
+		"count":      len(items),
+		"nextToken":  nextToken,
+		"hasMore":    hasMore,
+	})
+}
+
+// memberDevelopment builds the development summary for every given season, in the order of seasons.
+// Goals and reports of the user on other teams are ignored.
+func memberDevelopment(ctx context.Context, userId string, seasons []*models.Season) ([]SeasonDevelopment, error) {
+	indexBySeason := make(map[string]int, len(seasons))
+	development := make([]SeasonDevelopment, len(seasons))
+	for i, s := range seasons {
+		indexBySeason[s.Id] = i
+		development[i] = SeasonDevelopment{
+			SeasonId:    s.Id,
+			SeasonName:  s.Name,
+			StartDate:   s.StartDate,
+			EndDate:     s.EndDate,
+			Status:      s.Status,
+			MetricGoals: make([]MetricGoalResult, 0),
+		}
+	}
+
+	goalFilter := db.GoalFilter{OwnerId: userId, GoalType: string(models.GoalTypeIndividual)}
+	for {
+		goals, _, nextCursor, hasMore, err := db.ListGoals(ctx, goalFilter)
+		if err != nil {
+			return nil, err
+		}
+		for _, g := range goals {
+			i, ok := indexBySeason[g.SeasonId]
+			if !ok || g.Status == models.GoalStatusArchived {
+				continue
+			}
+			d := &development[i]
+			d.GoalCount++
+			switch g.Status {
+			case models.GoalStatusCompleted:
+				d.CompletedGoalCount++
+			case models.GoalStatusInProgress:
+				d.InProgressGoalCount++
+			case models.GoalStatusOpen:
+				d.OpenGoalCount++
+			}
+			if g.Metric != nil {
+				d.MetricGoals = append(d.MetricGoals, MetricGoalResult{
+					GoalId:  g.Id,
+					Title:   g.Title,
+					Source:  g.Metric.Source,
+					Stat:    g.Metric.Stat,
+					Target:  g.Metric.Target,
+					Current: g.Metric.Current,
+					Reached: g.Metric.Reached(),
+				})
+			}
+		}
+		if !hasMore {
+			break
+		}
+		goalFilter.Cursor = nextCursor
+	}
+
+	seasonByReport := make(map[string]int)
+	reportIds := make([]string, 0)
+	reportFilter := db.ProgressReportFilter{AuthorId: userId}
+	for {
+		reports, _, nextCursor, hasMore, err := db.ListProgressReports(ctx, reportFilter)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range reports {
+			i, ok := indexBySeason[r.SeasonId]
+			if !ok {
+				continue
+			}
+			development[i].ReportCount++
+			seasonByReport[r.Id] = i
+			reportIds = append(reportIds, r.Id)
+		}
+		if !hasMore {
+			break
+		}
+		reportFilter.Cursor = nextCursor
+	}
+
+	entriesByReport, err := db.ListProgressEntriesByReportIds(ctx, reportIds)
+	if err != nil {
+		return nil, err
+	}
+	ratingSums := make([]int, len(seasons))
+	for reportId, entries := range entriesByReport {
+		i := seasonByReport[reportId]
+		for _, e := range entries {
+			ratingSums[i] += int(e.Rating)
+			development[i].RatingCount++
+		}
+	}
+
+	for i := range development {
+		d := &development[i]
+		if d.GoalCount > 0 {
+			d.CompletionRate = math.Round(float64(d.CompletedGoalCount)/float64(d.GoalCount)*100) / 100
+		}
+		if d.RatingCount > 0 {
+			avg := math.Round(float64(ratingSums[i])/float64(d.RatingCount)*100) / 100
+			d.AverageRating = &avg
+		}
+	}
+	// Seasons are ordered newest first, so the previous season is the next one in the list.
+	for i := 0; i+1 < len(development); i++ {
+		current, previous := development[i].AverageRating, development[i+1].AverageRating
+		if current != nil && previous != nil {
+			change := math.Round((*current-*previous)*100) / 100
+			development[i].RatingChange = &change
+		}
+	}
+	return development, nil
+}
+
+// authorizeRole checks that the role exists on the team and that the caller holds every permission it grants, so
+// nobody can hand out more than they have themselves.
+func authorizeRole(ctx context.Context, authorizer map[string]interface{}, teamId string, name models.TeamMemberRole) (*events.APIGatewayProxyResponse, error) {
+	role, err := utils.GetTeamRole(ctx, teamId, name)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if role == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgErrorTeamRoleNotFound, nil)
+	}
+	if !utils.CanGrantRole(ctx, authorizer, teamId, role) {
+		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	}
+	return nil, nil
+}
+
+// loadTeamMember returns the membership if it belongs to the team and a not found response otherwise.
+func loadTeamMember(ctx context.Context, teamId, teamMemberId string) (*models.TeamMember, *events.APIGatewayProxyResponse, error) {
+	member, err := db.GetTeamMemberById(ctx, teamMemberId)
+	if err != nil {
+		resp, e := utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		return nil, resp, e
+	}
+	if member == nil || member.TeamId != teamId {
+		resp, e := utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorUserNotFound, nil)
+		return nil, resp, e
+	}
+	return member, nil, nil
+}
+
+// keepAdminOrTrainer returns an error response if the membership is the team's last active admin or trainer, which
+// may not be removed, demoted or deactivated. The role has to be handed over first. Otherwise it returns the ID of
+// another active admin or trainer the write has to keep, or an empty ID if the membership holds neither role.
+func keepAdminOrTrainer(ctx context.Context, member *models.TeamMember) (string, *events.APIGatewayProxyResponse, error) {
+	if member.Status != models.TeamMemberStatusActive || !member.Role.IsAdminOrTrainer() {
+		return "", nil, nil
+	}
+	keeper, err := db.OtherAdminOrTrainer(ctx, member.TeamId, member.UserId)
+	if err != nil {
+		resp, e := utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		return "", resp, e
+	}
+	if keeper == nil {
+		resp, e := utils.ErrorResponse(http.StatusNotAcceptable, utils.MsgErrorLastAdminOrTrainer, nil)
+		return "", resp, e
+	}
+	return keeper.Id, nil, nil
+}
+
+// validateProfile checks the profile fields of a membership update. A jersey number of 0 removes the number.
+func validateProfile(request UpdateTeamMemberRequest) []utils.FieldError {
+	fieldErrors := make([]utils.FieldError, 0)
+	if request.Positions != nil {
+		seen := make(map[models.PlayingPosition]int, len(*request.Positions))
+		for i, p := range *request.Positions {
+			field := fmt.Sprintf("positions[%d]", i)
+			if first, dup := seen[p]; dup {
+				fieldErrors = append(fieldErrors, utils.FieldError{Field: field, Message: fmt.Sprintf("position is already listed in positions[%d]", first)})
+				continue
+			}
+			seen[p] = i
+			switch p {
+			case models.PlayingPositionSetter, models.PlayingPositionOutside, models.PlayingPositionOpposite, models.PlayingPositionMiddle, models.PlayingPositionLibero:
+			default:
+				fieldErrors = append(fieldErrors, utils.FieldError{Field: field, Message: "must be one of setter, outside, opposite, middle, libero"})
+			}
+		}
+	}
+	if request.JerseyNumber != nil && (*request.JerseyNumber < 0 || *request.JerseyNumber > models.MaxJerseyNumber) {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "jerseyNumber", Message: fmt.Sprintf("must be between 1 and %d, or 0 to remove it", models.MaxJerseyNumber)})
+	}
+	if request.DominantHand != nil {
+		switch *request.DominantHand {
+		case "", models.DominantHandLeft, models.DominantHandRight, models.DominantHandBoth:
+		default:
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: "dominantHand", Message: "must be one of left, right, both"})
+		}
+	}
+	return fieldErrors
+}
+
+// jerseyNumberTaken reports whether another active member of the team already wears the number.
+func jerseyNumberTaken(ctx context.Context, teamId, teamMemberId string, number int) (bool, error) {
+	members, err := db.GetMembershipsByTeamID(ctx, teamId)
+	if err != nil {
+		return false, err
+	}
+	for _, m := range members {
+		if m.Id != teamMemberId && m.JerseyNumber != nil && *m.JerseyNumber == number {
+			return true, nil
+		}
+	}
+	return false, nil
+}
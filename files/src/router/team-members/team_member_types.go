@@ -7,26 +7,35 @@ import (
 )
 
 type TeamMemberListResult struct {
-	Id                string                  `json:"id"`
-	UserId            string                  `json:"userId"`
-	Name              *string                 `json:"name"`
-	Email             string                  `json:"email"`
-	Picture           *string                 `json:"picture"`
-	PreferredUsername *string                 `json:"preferredUsername"`
-	Role              models.TeamMemberRole   `json:"role"`
-	Status            models.TeamMemberStatus `json:"status"`
-	UserStatus        models.UserStatus       `json:"userStatus"`
-	Birthdate         *time.Time              `json:"birthdate"`
-	JoinedAt          *time.Time              `json:"joinedAt,omitempty"`
+	Id                string                   `json:"id"`
+	UserId            string                   `json:"userId"`
+	Name              *string                  `json:"name"`
+	Email             string                   `json:"email"`
+	Picture           *string                  `json:"picture"`
+	PreferredUsername *string                  `json:"preferredUsername"`
+	Role              models.TeamMemberRole    `json:"role"`
+	Status            models.TeamMemberStatus  `json:"status"`
+	UserStatus        models.UserStatus        `json:"userStatus"`
+	Birthdate         *time.Time               `json:"birthdate"`
+	JoinedAt          *time.Time               `json:"joinedAt,omitempty"`
+	Positions         []models.PlayingPosition `json:"positions"`
+	JerseyNumber      *int                     `json:"jerseyNumber"`
+	DominantHand      models.DominantHand      `json:"dominantHand,omitempty"`
+	TrainerNotes      string                   `json:"trainerNotes"`
 }
 
+// TeamMemberPublicResult is what members without members.view see of their teammates: no role or status and no
+// trainer notes.
 type TeamMemberPublicResult struct {
-	Id                string  `json:"id"`
-	UserId            string  `json:"userId"`
-	Name              *string `json:"name"`
-	PreferredUsername *string `json:"preferredUsername"`
-	Picture           *string `json:"picture"`
-	Email             string  `json:"email"`
+	Id                string                   `json:"id"`
+	UserId            string                   `json:"userId"`
+	Name              *string                  `json:"name"`
+	PreferredUsername *string                  `json:"preferredUsername"`
+	Picture           *string                  `json:"picture"`
+	Email             string                   `json:"email"`
+	Positions         []models.PlayingPosition `json:"positions"`
+	JerseyNumber      *int                     `json:"jerseyNumber"`
+	DominantHand      models.DominantHand      `json:"dominantHand,omitempty"`
 }
 
 type AddTeamMemberRequest struct {
@@ -34,7 +43,52 @@ type AddTeamMemberRequest struct {
 	Role   models.TeamMemberRole `json:"role"`
 }
 
+// UpdateTeamMemberRequest changes a membership. Omitted fields stay as they are; empty positions, a jersey number
+// of 0, an empty dominant hand and empty trainer notes clear the field.
 type UpdateTeamMemberRequest struct {
-	Role   *models.TeamMemberRole   `json:"role"`
-	Status *models.TeamMemberStatus `json:"status"`
+	Role         *models.TeamMemberRole    `json:"role"`
+	Status       *models.TeamMemberStatus  `json:"status"`
+	Positions    *[]models.PlayingPosition `json:"positions"`
+	JerseyNumber *int                      `json:"jerseyNumber"`
+	DominantHand *models.DominantHand      `json:"dominantHand"`
+	TrainerNotes *string                   `json:"trainerNotes"`
+}
+
+// TransferTeamMemberRequest moves a member to another team. The role defaults to member. With moveGoals the
+// member's individual goals in progress move to targetSeasonId, a season of the target team.
+type TransferTeamMemberRequest struct {
+	TargetTeamId   string                `json:"targetTeamId"`
+	Role           models.TeamMemberRole `json:"role"`
+	MoveGoals      bool                  `json:"moveGoals"`
+	TargetSeasonId string                `json:"targetSeasonId"`
+}
+
+// SeasonDevelopment summarises a member's individual goals and ratings within one season.
+type SeasonDevelopment struct {
+	SeasonId            string              `json:"seasonId"`
+	SeasonName          string              `json:"seasonName"`
+	StartDate           time.Time           `json:"startDate"`
+	EndDate             time.Time           `json:"endDate"`
+	Status              models.SeasonStatus `json:"status"`
+	GoalCount           int                 `json:"goalCount"`
+	CompletedGoalCount  int                 `json:"completedGoalCount"`
+	InProgressGoalCount int                 `json:"inProgressGoalCount"`
+	OpenGoalCount       int                 `json:"openGoalCount"`
+	CompletionRate      float64             `json:"completionRate"`
+	ReportCount         int                 `json:"reportCount"`
+	RatingCount         int                 `json:"ratingCount"`
+	AverageRating       *float64            `json:"averageRating"`
+	RatingChange        *float64            `json:"ratingChange"`
+	MetricGoals         []MetricGoalResult  `json:"metricGoals"`
+}
+
+// MetricGoalResult is where one of the member's measurable goals stood against its target in the season.
+type MetricGoalResult struct {
+	GoalId  string                  `json:"goalId"`
+	Title   string                  `json:"title"`
+	Source  models.GoalMetricSource `json:"source"`
+	Stat    string                  `json:"stat"`
+	Target  float64                 `json:"target"`
+	Current *float64                `json:"current"`
+	Reached bool                    `json:"reached"`
 }
@@ -0,0 +1,218 @@
+package team_roles
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpgschiba/volleygoals/db"
+	"github.com/fpgschiba/volleygoals/models"
+	"github.com/fpgschiba/volleygoals/utils"
+)
+
+const maxRoleNameLength = 32
+
+// ListTeamRoles returns the built-in roles followed by the team's custom roles, together with every permission a
+// role can grant so clients can render the permission matrix.
+func ListTeamRoles(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	if teamId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	custom, err := db.ListTeamRolesByTeamId(ctx, teamId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	roles := append(models.BuiltInTeamRoles(), custom...)
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"items":       roles,
+		"count":       len(roles),
+		"permissions": models.AllPermissions,
+	})
+}
+
+func CreateTeamRole(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	if teamId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	var request CreateTeamRoleRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+
+	name := models.TeamMemberRole(strings.ToLower(strings.TrimSpace(request.Name)))
+	if !validRoleName(name) {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgErrorInvalidTeamRoleName, nil)
+	}
+	if models.BuiltInTeamRole(name) != nil {
+		return utils.ErrorResponse(http.StatusConflict, utils.MsgErrorTeamRoleBuiltIn, nil)
+	}
+	role := &models.TeamRole{
+		TeamId:      teamId,
+		Name:        name,
+		Description: strings.TrimSpace(request.Description),
+		Permissions: normalizePermissions(request.Permissions),
+		CreatedBy:   utils.GetCognitoUsername(event.RequestContext.Authorizer),
+	}
+	if fieldErrors := validatePermissions(role.Permissions); len(fieldErrors) > 0 {
+		return utils.ValidationErrorResponse(utils.MsgErrorInvalidPermission, fieldErrors)
+	}
+	// Nobody can define a role that grants more than they hold themselves
+	if !utils.CanGrantRole(ctx, event.RequestContext.Authorizer, teamId, role) {
+		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	}
+
+	existing, err := db.GetTeamRoleByName(ctx, teamId, name)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if existing != nil {
+		return utils.ErrorResponse(http.StatusConflict, utils.MsgErrorTeamRoleExists, nil)
+	}
+
+	role, err = db.CreateTeamRole(ctx, role)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusCreated, utils.MsgSuccess, map[string]interface{}{
+		"role": role,
+	})
+}
+
+// UpdateTeamRole changes the description and permissions of a custom role. The new permissions apply to every
+// member holding the role right away.
+func UpdateTeamRole(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	roleId := event.PathParameters["roleId"]
+	if teamId == "" || roleId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	var request UpdateTeamRoleRequest
+	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
+	}
+
+	role, err := db.GetTeamRoleById(ctx, roleId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if role == nil || role.TeamId != teamId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorTeamRoleNotFound, nil)
+	}
+	// The caller has to cover the role both before and after the change, so nobody can widen or strip a role
+	// that outranks them
+	if !utils.CanGrantRole(ctx, event.RequestContext.Authorizer, teamId, role) {
+		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	}
+
+	if request.Description != nil {
+		role.Description = strings.TrimSpace(*request.Description)
+	}
+	if request.Permissions != nil {
+		role.Permissions = normalizePermissions(*request.Permissions)
+		if fieldErrors := validatePermissions(role.Permissions); len(fieldErrors) > 0 {
+			return utils.ValidationErrorResponse(utils.MsgErrorInvalidPermission, fieldErrors)
+		}
+		if !utils.CanGrantRole(ctx, event.RequestContext.Authorizer, teamId, role) {
+			return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+		}
+	}
+
+	if err := db.UpdateTeamRole(ctx, role); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"role": role,
+	})
+}
+
+// DeleteTeamRole removes a custom role. Roles still held by a member or offered by a pending invite cannot be
+// deleted; those members have to be moved to another role first.
+func DeleteTeamRole(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	teamId := event.PathParameters["teamId"]
+	roleId := event.PathParameters["roleId"]
+	if teamId == "" || roleId == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+
+	role, err := db.GetTeamRoleById(ctx, roleId)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if role == nil || role.TeamId != teamId {
+		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorTeamRoleNotFound, nil)
+	}
+	if !utils.CanGrantRole(ctx, event.RequestContext.Authorizer, teamId, role) {
+		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	}
+
+	inUse, err := db.IsTeamRoleInUse(ctx, teamId, role.Name)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	if inUse {
+		return utils.ErrorResponse(http.StatusConflict, utils.MsgErrorTeamRoleInUse, nil)
+	}
+	if err := db.DeleteTeamRole(ctx, roleId); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	return utils.SuccessResponse(http.StatusNoContent, utils.MsgSuccess, nil)
+}
+
+// validRoleName accepts lowercase slugs such as "captain" or "assistant-coach".
+func validRoleName(name models.TeamMemberRole) bool {
+	if len(name) < 2 || len(name) > maxRoleNameLength {
+		return false
+	}
+	for i, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z':
+		case (c >= '0' && c <= '9') || c == '-':
+			if i == 0 {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return !strings.HasSuffix(string(name), "-")
+}
+
+// normalizePermissions drops duplicates while keeping the order the client sent.
+func normalizePermissions(permissions []models.Permission) []models.Permission {
+	seen := make(map[models.Permission]bool, len(permissions))
+	out := make([]models.Permission, 0, len(permissions))
+	for _, p := range permissions {
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		out = append(out, p)
+	}
+	return out
+}
+
+func validatePermissions(permissions []models.Permission) []utils.FieldError {
+	known := make(map[models.Permission]bool, len(models.AllPermissions))
+	for _, p := range models.AllPermissions {
+		known[p] = true
+	}
+	var fieldErrors []utils.FieldError
+	for i, p := range permissions {
+		if !known[p] {
+			fieldErrors = append(fieldErrors, utils.FieldError{Field: fmt.Sprintf("permissions[%d]", i), Message: "is not a known permission"})
+		}
+	}
+	return fieldErrors
+}
@@ -0,0 +1,15 @@
+package team_roles
+
+import "github.com/fpgschiba/volleygoals/models"
+
+type CreateTeamRoleRequest struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Permissions []models.Permission `json:"permissions"`
+}
+
+// UpdateTeamRoleRequest changes a custom role. The name identifies the role on memberships and cannot change.
+type UpdateTeamRoleRequest struct {
+	Description *string              `json:"description,omitempty"`
+	Permissions *[]models.Permission `json:"permissions,omitempty"`
+}
@@ -12,9 +12,6 @@ import (
 )
 
 func UpdateTeamSettings(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
-	if !utils.IsAdmin(event.RequestContext.Authorizer) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
 	teamId := event.PathParameters["teamId"]
 	if teamId == "" {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
@@ -39,6 +36,9 @@ func UpdateTeamSettings(ctx context.Context, event events.APIGatewayProxyRequest
 	if request.AllowIndividualGoalComments != nil {
 		teamSettings.AllowIndividualGoalComments = *request.AllowIndividualGoalComments
 	}
+	if request.RequireGuardianConsent != nil {
+		teamSettings.RequireGuardianConsent = *request.RequireGuardianConsent
+	}
 	if err := db.UpdateTeamSettings(ctx, teamSettings); err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
 	}
@@ -4,4 +4,5 @@ type UpdateTeamSettingsRequest struct {
 	AllowFileUploads            *bool `json:"allowFileUploads"`
 	AllowTeamGoalComments       *bool `json:"allowTeamGoalComments"`
 	AllowIndividualGoalComments *bool `json:"allowIndividualGoalComments"`
+	RequireGuardianConsent      *bool `json:"requireGuardianConsent"`
 }
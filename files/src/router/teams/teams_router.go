@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	log "github.com/sirupsen/logrus"
@@ -15,10 +16,9 @@ import (
 	"github.com/fpgschiba/volleygoals/utils"
 )
 
+// UpdateTeam is open to platform admins and to owners and admins of the organization owning the team. Only
+// platform admins move teams between organizations.
 func UpdateTeam(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
-	if !utils.IsAdmin(event.RequestContext.Authorizer) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
 	teamId := event.PathParameters["teamId"]
 	if teamId == "" {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
@@ -35,12 +35,49 @@ func UpdateTeam(ctx context.Context, event events.APIGatewayProxyRequest) (*even
 	if team == nil {
 		return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorTeamNotFound, nil)
 	}
+	if team.Status == models.TeamStatusInactive {
+		// An archived team only accepts being unarchived, which is up to platform admins
+		if request.Status == nil || *request.Status != models.TeamStatusActive || request.Name != nil || request.Timezone != nil || request.OrganizationId != nil {
+			return utils.ErrorResponse(http.StatusConflict, utils.MsgErrorTeamArchived, nil)
+		}
+		if !utils.IsAdmin(event.RequestContext.Authorizer) {
+			return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+		}
+	}
 	if request.Name != nil {
 		team.Name = *request.Name
 	}
 	if request.Status != nil {
+		if *request.Status != models.TeamStatusActive && *request.Status != models.TeamStatusInactive {
+			return utils.ErrorResponse(http.StatusBadRequest, utils.MsgErrorInvalidTeamStatus, nil)
+		}
 		team.Status = *request.Status
 	}
+	if request.Timezone != nil {
+		// Season lifecycle transitions are evaluated in this zone, so reject names the runtime cannot resolve
+		if *request.Timezone == "" || *request.Timezone == "Local" {
+			return utils.ErrorResponse(http.StatusBadRequest, utils.MsgErrorInvalidTimezone, nil)
+		}
+		if _, err := time.LoadLocation(*request.Timezone); err != nil {
+			return utils.ErrorResponse(http.StatusBadRequest, utils.MsgErrorInvalidTimezone, err)
+		}
+		team.Timezone = *request.Timezone
+	}
+	if request.OrganizationId != nil && *request.OrganizationId != team.OrganizationId {
+		if !utils.IsAdmin(event.RequestContext.Authorizer) {
+			return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+		}
+		if *request.OrganizationId != "" {
+			organization, err := db.GetOrganizationById(ctx, *request.OrganizationId)
+			if err != nil {
+				return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+			}
+			if organization == nil {
+				return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorOrganizationNotFound, nil)
+			}
+		}
+		team.OrganizationId = *request.OrganizationId
+	}
 	err = db.UpdateTeam(ctx, team)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
@@ -53,18 +90,34 @@ func UpdateTeam(ctx context.Context, event events.APIGatewayProxyRequest) (*even
 }
 
 // ListTeams handles HTTP request, parses query params into db.TeamFilter and returns paginated response.
+// Platform admins see all teams, organization members the teams of their organizations.
 func ListTeams(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
-	// authorization example
-	if !utils.IsAdmin(event.RequestContext.Authorizer) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-
 	// build TeamFilter (reuses FilterOptions for sorting)
 	filter, err := db.TeamFilterFromQuery(event.QueryStringParameters)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
 	}
 
+	organizationId := event.QueryStringParameters["organizationId"]
+	if utils.IsAdmin(event.RequestContext.Authorizer) {
+		if organizationId != "" {
+			filter.OrganizationIds = []string{organizationId}
+		}
+	} else {
+		memberships, err := db.GetOrganizationMembershipsByUserId(ctx, utils.GetCognitoUsername(event.RequestContext.Authorizer))
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		for _, m := range memberships {
+			if organizationId == "" || m.OrganizationId == organizationId {
+				filter.OrganizationIds = append(filter.OrganizationIds, m.OrganizationId)
+			}
+		}
+		if len(filter.OrganizationIds) == 0 {
+			return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+		}
+	}
+
 	items, count, nextCursor, hasMore, err := db.ListTeams(ctx, filter)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
@@ -98,9 +151,6 @@ func GetTeam(ctx context.Context, event events.APIGatewayProxyRequest) (*events.
 	if teamId == "" {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
 	}
-	if !utils.IsAdmin(event.RequestContext.Authorizer) && !utils.HasTeamAccess(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
 	team, err := db.GetTeamById(ctx, teamId)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
@@ -124,9 +174,6 @@ func GetTeam(ctx context.Context, event events.APIGatewayProxyRequest) (*events.
 }
 
 func DeleteTeam(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
-	if !utils.IsAdmin(event.RequestContext.Authorizer) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
 	teamId := event.PathParameters["teamId"]
 	if teamId == "" {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
@@ -138,11 +185,8 @@ func DeleteTeam(ctx context.Context, event events.APIGatewayProxyRequest) (*even
 	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccessTeamDeleted, nil)
 }
 
+// CreateTeam is open to platform admins and, for teams of their organization, to organization owners and admins.
 func CreateTeam(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
-	if !utils.IsAdmin(event.RequestContext.Authorizer) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
-
 	bodyPreview := event.Body
 	if len(bodyPreview) > 200 {
 		bodyPreview = bodyPreview[:200]
@@ -167,7 +211,16 @@ func CreateTeam(ctx context.Context, event events.APIGatewayProxyRequest) (*even
 	if err != nil {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
 	}
-	team, err := db.CreateTeam(ctx, request.Name)
+	if request.OrganizationId != "" {
+		organization, err := db.GetOrganizationById(ctx, request.OrganizationId)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		if organization == nil {
+			return utils.ErrorResponse(http.StatusNotFound, utils.MsgErrorOrganizationNotFound, nil)
+		}
+	}
+	team, err := db.CreateTeam(ctx, request.Name, request.OrganizationId)
 	if err != nil {
 		if err.Error() == "team already exists" {
 			return utils.ErrorResponse(http.StatusBadRequest, utils.MsgErrorTeamExists, err)
@@ -187,9 +240,6 @@ func UploadTeamPicture(ctx context.Context, event events.APIGatewayProxyRequest)
 	if !ok || teamId == "" {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
 	}
-	if !utils.IsAdmin(event.RequestContext.Authorizer) && !utils.IsTeamAdminOrTrainer(ctx, event.RequestContext.Authorizer, teamId) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
 	filename, ok := event.QueryStringParameters["filename"]
 	if !ok || filename == "" {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
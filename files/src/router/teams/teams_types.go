@@ -3,10 +3,14 @@ package teams
 import "github.com/fpgschiba/volleygoals/models"
 
 type CreateTeamRequest struct {
-	Name string `json:"name"`
+	Name           string `json:"name"`
+	OrganizationId string `json:"organizationId"`
 }
 
 type UpdateTeamRequest struct {
-	Name   *string            `json:"name"`
-	Status *models.TeamStatus `json:"status"`
+	Name     *string            `json:"name"`
+	Status   *models.TeamStatus `json:"status"`
+	Timezone *string            `json:"timezone"`
+	// OrganizationId moves the team to another organization; an empty string takes it out of its organization.
+	OrganizationId *string `json:"organizationId"`
 }
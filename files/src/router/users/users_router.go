@@ -15,9 +15,6 @@ import (
 )
 
 func ListUsers(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
-	if !utils.IsAdmin(event.RequestContext.Authorizer) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
 	filter, err := users.UserFilterFromQuery(event.QueryStringParameters)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
@@ -43,9 +40,6 @@ func ListUsers(ctx context.Context, event events.APIGatewayProxyRequest) (*event
 }
 
 func GetUser(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
-	if !utils.IsAdmin(event.RequestContext.Authorizer) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
 	userSub, ok := event.PathParameters["userSub"]
 	if !ok || userSub == "" {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
@@ -68,13 +62,13 @@ func GetUser(ctx context.Context, event events.APIGatewayProxyRequest) (*events.
 }
 
 func DeleteUser(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
-	if !utils.IsAdmin(event.RequestContext.Authorizer) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
 	userSub, ok := event.PathParameters["userSub"]
 	if !ok || userSub == "" {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
 	}
+	if resp, err := keepAdminsAndTrainers(ctx, userSub); resp != nil {
+		return resp, err
+	}
 	err := users.DeleteUserBySub(ctx, userSub)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
@@ -87,9 +81,6 @@ func DeleteUser(ctx context.Context, event events.APIGatewayProxyRequest) (*even
 }
 
 func UpdateUser(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
-	if !utils.IsAdmin(event.RequestContext.Authorizer) {
-		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
-	}
 	userSub, ok := event.PathParameters["userSub"]
 	if !ok || userSub == "" {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
@@ -98,6 +89,12 @@ func UpdateUser(ctx context.Context, event events.APIGatewayProxyRequest) (*even
 	if err := json.Unmarshal([]byte(event.Body), &request); err != nil {
 		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, err)
 	}
+	if request.UserType != nil && *request.UserType == models.UserTypeAdmin {
+		// Promoted admins lose their team memberships
+		if resp, err := keepAdminsAndTrainers(ctx, userSub); resp != nil {
+			return resp, err
+		}
+	}
 
 	user, err := applyUserTypeUpdate(ctx, userSub, request.UserType)
 	if err != nil {
@@ -121,6 +118,25 @@ func UpdateUser(ctx context.Context, event events.APIGatewayProxyRequest) (*even
 	})
 }
 
+// keepAdminsAndTrainers returns an error response if ending all of the user's memberships would leave a team
+// without an active admin or trainer.
+func keepAdminsAndTrainers(ctx context.Context, userSub string) (*events.APIGatewayProxyResponse, error) {
+	memberships, err := db.GetMembershipsByUserID(ctx, userSub)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+	for _, membership := range memberships {
+		last, err := db.IsLastAdminOrTrainer(ctx, membership)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		if last {
+			return utils.ErrorResponse(http.StatusNotAcceptable, utils.MsgErrorLastAdminOrTrainer, nil)
+		}
+	}
+	return nil, nil
+}
+
 func applyUserTypeUpdate(ctx context.Context, userSub string, userType *models.UserType) (*models.User, error) {
 	if userType == nil {
 		return nil, nil
@@ -152,3 +168,44 @@ func applyEnabledUpdate(ctx context.Context, userSub string, enabled *bool) erro
 	}
 	return users.DisableUser(ctx, userSub)
 }
+
+// ListUserMemberships returns the membership history of a user across teams, including memberships that ended.
+// Platform admins see every membership; owners and admins of a club see the memberships in their club's teams.
+func ListUserMemberships(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	userSub := event.PathParameters["userSub"]
+	if userSub == "" {
+		return utils.ErrorResponse(http.StatusBadRequest, utils.MsgBadRequest, nil)
+	}
+	memberships, err := db.ListMembershipHistoryByUserID(ctx, userSub)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+	}
+
+	clubRoles := []models.OrganizationRole{models.OrganizationRoleOwner, models.OrganizationRoleAdmin}
+	isAdmin := utils.IsAdmin(event.RequestContext.Authorizer)
+	items := make([]map[string]interface{}, 0, len(memberships))
+	for _, membership := range memberships {
+		if !isAdmin && !utils.HasOrganizationRoleOnTeam(ctx, event.RequestContext.Authorizer, membership.TeamId, clubRoles) {
+			continue
+		}
+		team, err := db.GetTeamById(ctx, membership.TeamId)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, utils.MsgInternalServerError, err)
+		}
+		item := map[string]interface{}{
+			"membership": membership.WithoutTrainerNotes(),
+		}
+		if team != nil {
+			item["teamName"] = team.Name
+		}
+		items = append(items, item)
+	}
+	if !isAdmin && len(items) == 0 && len(memberships) > 0 {
+		return utils.ErrorResponse(http.StatusForbidden, utils.MsgErrorForbidden, nil)
+	}
+
+	return utils.SuccessResponse(http.StatusOK, utils.MsgSuccess, map[string]interface{}{
+		"items": items,
+		"count": len(items),
+	})
+}
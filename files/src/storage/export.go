@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/fpgschiba/volleygoals/models"
+)
+
+// UploadExport stores a generated export under exports/<teamID>/ and returns a presigned download URL and the object key.
+// Exports are private; the bucket lifecycle rule removes them after a day.
+func UploadExport(ctx context.Context, teamID, filename, contentType string, body []byte, expires int) (string, string, error) {
+	client = GetClient()
+	key := fmt.Sprintf("exports/%s/%s/%s", teamID, models.GenerateID(), filename)
+	_, err := client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:             aws.String(bucketName),
+		Key:                aws.String(key),
+		Body:               bytes.NewReader(body),
+		ContentType:        aws.String(contentType),
+		ContentDisposition: aws.String(fmt.Sprintf("attachment; filename=%q", filename)),
+	})
+	if err != nil {
+		return "", key, err
+	}
+	url, err := GeneratePresignedGetURL(ctx, key, expires)
+	if err != nil {
+		return "", key, err
+	}
+	return url, key, nil
+}
@@ -2,8 +2,10 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -73,3 +75,71 @@ func GeneratePresignedUploadURLForCommentFile(ctx context.Context, commentID, fi
 	}
 	return url, key, nil
 }
+
+func GeneratePresignedUploadURLForDrillMedia(ctx context.Context, drillID, filename, contentType string, expires int) (string, string, error) {
+	presignClient = GetPresignClient()
+	fileExtension := filepath.Ext(filename)
+	newFilename := fmt.Sprintf("%s%s", models.GenerateID(), fileExtension)
+	key := fmt.Sprintf("drills/%s/%s", drillID, newFilename)
+	url, err := GeneratePresignedPutURL(ctx, key, contentType, expires)
+	if err != nil {
+		return "", key, err
+	}
+	return url, key, nil
+}
+
+// ErrUnsupportedMediaType is returned when a media upload's content type is not accepted or does not match the
+// file's extension.
+var ErrUnsupportedMediaType = errors.New("unsupported media type")
+
+// mediaContentTypes maps the content types accepted for media reference uploads to their file extensions.
+var mediaContentTypes = map[string][]string{
+	"video/mp4":       {".mp4", ".m4v"},
+	"video/quicktime": {".mov"},
+	"video/webm":      {".webm"},
+}
+
+// IsSupportedMediaFile reports whether a file can be uploaded as a media reference: its content type must be one
+// of the accepted video types and its extension must match it.
+func IsSupportedMediaFile(filename, contentType string) bool {
+	extensions, ok := mediaContentTypes[strings.ToLower(contentType)]
+	if !ok {
+		return false
+	}
+	fileExtension := strings.ToLower(filepath.Ext(filename))
+	for _, ext := range extensions {
+		if ext == fileExtension {
+			return true
+		}
+	}
+	return false
+}
+
+func GeneratePresignedUploadURLForMedia(ctx context.Context, teamID, filename, contentType string, expires int) (string, string, error) {
+	if !IsSupportedMediaFile(filename, contentType) {
+		return "", "", ErrUnsupportedMediaType
+	}
+	presignClient = GetPresignClient()
+	fileExtension := strings.ToLower(filepath.Ext(filename))
+	newFilename := fmt.Sprintf("%s%s", models.GenerateID(), fileExtension)
+	key := fmt.Sprintf("media/%s/%s", teamID, newFilename)
+	url, err := GeneratePresignedPutURL(ctx, key, strings.ToLower(contentType), expires)
+	if err != nil {
+		return "", key, err
+	}
+	return url, key, nil
+}
+
+func GeneratePresignedGetURL(ctx context.Context, key string, expires int) (string, error) {
+	presignClient = GetPresignClient()
+	response, err := presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(key),
+	}, func(po *s3.PresignOptions) {
+		po.Expires = time.Duration(expires) * time.Minute
+	})
+	if err != nil {
+		return "", err
+	}
+	return response.URL, nil
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/fpgschiba/volleygoals/db"
+	"github.com/fpgschiba/volleygoals/models"
+)
+
+// Lookups holds the reads behind every authorization check. Tests replace them to run the checks without DynamoDB.
+var Lookups = struct {
+	TeamMember              func(ctx context.Context, userId, teamId string) (*models.TeamMember, error)
+	TeamRoles               func(ctx context.Context, teamId string) ([]*models.TeamRole, error)
+	Team                    func(ctx context.Context, teamId string) (*models.Team, error)
+	OrganizationMemberships func(ctx context.Context, userId string) ([]*models.OrganizationMember, error)
+}{
+	TeamMember:              db.GetTeamMemberByUserIDAndTeamID,
+	TeamRoles:               db.ListTeamRolesByTeamId,
+	Team:                    db.GetTeamById,
+	OrganizationMemberships: db.GetOrganizationMembershipsByUserId,
+}
+
+// The lookups below back every authorization check. A request usually asks about the same team and user several
+// times, so they are remembered on the request cache and each one reads DynamoDB at most once per request.
+
+// teamMembership returns the user's active membership in the team, or nil if the user is not an active member.
+func teamMembership(ctx context.Context, userId, teamId string) (*models.TeamMember, error) {
+	return db.Remember(ctx, "teamMember#"+teamId+"#"+userId, func() (*models.TeamMember, error) {
+		return Lookups.TeamMember(ctx, userId, teamId)
+	})
+}
+
+// customTeamRoles returns the custom roles the team defines.
+func customTeamRoles(ctx context.Context, teamId string) ([]*models.TeamRole, error) {
+	return db.Remember(ctx, "teamRoles#"+teamId, func() ([]*models.TeamRole, error) {
+		return Lookups.TeamRoles(ctx, teamId)
+	})
+}
+
+// teamOrganizationId returns the organization owning the team, or an empty string for teams outside an
+// organization and teams that do not exist.
+func teamOrganizationId(ctx context.Context, teamId string) (string, error) {
+	return db.Remember(ctx, "teamOrganization#"+teamId, func() (string, error) {
+		team, err := Lookups.Team(ctx, teamId)
+		if err != nil || team == nil {
+			return "", err
+		}
+		return team.OrganizationId, nil
+	})
+}
+
+// organizationMemberships returns the user's memberships in all organizations.
+func organizationMemberships(ctx context.Context, userId string) ([]*models.OrganizationMember, error) {
+	return db.Remember(ctx, "organizationMemberships#"+userId, func() ([]*models.OrganizationMember, error) {
+		return Lookups.OrganizationMemberships(ctx, userId)
+	})
+}
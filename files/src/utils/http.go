@@ -1,7 +1,10 @@
 package utils
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"net/http"
 
 	"github.com/aws/aws-lambda-go/events"
 	log "github.com/sirupsen/logrus"
@@ -9,6 +12,12 @@ import (
 
 type ResponseMessage string
 
+// FieldError describes why a single request field failed validation.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
 const (
 	// General errors
 	MsgGeneralError        ResponseMessage = "error.general"
@@ -20,15 +29,40 @@ const (
 	MsgErrorUnauthorized   ResponseMessage = "error.unauthorized"
 
 	// Team related errors
-	MsgErrorTeamExists   ResponseMessage = "error.team.exists"
-	MsgErrorTeamNotFound ResponseMessage = "error.team.notFound"
+	MsgErrorTeamExists        ResponseMessage = "error.team.exists"
+	MsgErrorTeamNotFound      ResponseMessage = "error.team.notFound"
+	MsgErrorInvalidTimezone   ResponseMessage = "error.team.invalidTimezone"
+	MsgErrorInvalidTeamStatus ResponseMessage = "error.team.invalidStatus"
+	MsgErrorTeamArchived      ResponseMessage = "error.team.archived"
+
+	// Organization related errors
+	MsgErrorOrganizationNotFound       ResponseMessage = "error.organization.notFound"
+	MsgErrorOrganizationMemberNotFound ResponseMessage = "error.organization.memberNotFound"
+	MsgErrorOrganizationMemberExists   ResponseMessage = "error.organization.memberExists"
+	MsgErrorOrganizationHasTeams       ResponseMessage = "error.organization.hasTeams"
+	MsgErrorOrganizationLastOwner      ResponseMessage = "error.organization.lastOwner"
+	MsgErrorInvalidOrganizationRole    ResponseMessage = "error.organization.invalidRole"
+
+	// Team role related errors
+	MsgErrorTeamRoleNotFound    ResponseMessage = "error.teamRole.notFound"
+	MsgErrorTeamRoleExists      ResponseMessage = "error.teamRole.exists"
+	MsgErrorTeamRoleBuiltIn     ResponseMessage = "error.teamRole.builtIn"
+	MsgErrorTeamRoleInUse       ResponseMessage = "error.teamRole.inUse"
+	MsgErrorInvalidTeamRoleName ResponseMessage = "error.teamRole.invalidName"
+	MsgErrorInvalidPermission   ResponseMessage = "error.teamRole.invalidPermission"
 
 	// Team Settings related errors
 	MsgErrorTeamSettingsNotFound ResponseMessage = "error.teamSettings.notFound"
 
 	// Team Member related errors
-	MsgErrorUserNotFound      ResponseMessage = "error.teamMembers.userNotFound"
-	MsgErrorMemberCannotLeave ResponseMessage = "error.teamMembers.cannotLeave"
+	MsgErrorUserNotFound       ResponseMessage = "error.teamMembers.userNotFound"
+	MsgErrorMemberCannotLeave  ResponseMessage = "error.teamMembers.cannotLeave"
+	MsgErrorInvalidProfile     ResponseMessage = "error.teamMembers.invalidProfile"
+	MsgErrorJerseyNumberTaken  ResponseMessage = "error.teamMembers.jerseyNumberTaken"
+	MsgErrorInvalidTransfer    ResponseMessage = "error.teamMembers.invalidTransfer"
+	MsgErrorMemberNotActive    ResponseMessage = "error.teamMembers.notActive"
+	MsgErrorAlreadyTeamMember  ResponseMessage = "error.teamMembers.alreadyMember"
+	MsgErrorLastAdminOrTrainer ResponseMessage = "error.teamMembers.lastAdminOrTrainer"
 
 	// Invite related errors
 	MsgErrorInviteExists           ResponseMessage = "error.invite.exists"
@@ -38,10 +72,71 @@ const (
 	MsgErrorUserAlreadyMember      ResponseMessage = "error.invite.userAlreadyMember"
 
 	// Season related errors
-	MsgErrorSeasonNotFound ResponseMessage = "error.season.notFound"
+	MsgErrorSeasonNotFound     ResponseMessage = "error.season.notFound"
+	MsgErrorInvalidSeasonDates ResponseMessage = "error.season.invalidDates"
+	MsgErrorSeasonOverlap      ResponseMessage = "error.season.overlap"
+
+	// Goal related errors
+	MsgErrorInvalidGoalMetric ResponseMessage = "error.goal.invalidMetric"
 
 	// Progress Report related errors
 	MsgErrorProgressReportNotFound ResponseMessage = "error.progressReport.notFound"
+	MsgErrorInvalidAnswers         ResponseMessage = "error.progressReport.invalidAnswers"
+	MsgErrorInvalidProgressEntries ResponseMessage = "error.progressReport.invalidEntries"
+
+	// Questionnaire related errors
+	MsgErrorQuestionnaireNotFound ResponseMessage = "error.questionnaire.notFound"
+	MsgErrorInvalidQuestionnaire  ResponseMessage = "error.questionnaire.invalid"
+
+	// Event related errors
+	MsgErrorEventNotFound     ResponseMessage = "error.event.notFound"
+	MsgErrorInvalidEvent      ResponseMessage = "error.event.invalid"
+	MsgErrorInvalidEventLink  ResponseMessage = "error.event.invalidLink"
+	MsgErrorInvalidAttendance ResponseMessage = "error.event.invalidAttendance"
+
+	// Match statistics related errors
+	MsgErrorMatchStatsNotFound ResponseMessage = "error.matchStats.notFound"
+	MsgErrorInvalidMatchStats  ResponseMessage = "error.matchStats.invalid"
+
+	// Lineup related errors
+	MsgErrorLineupNotFound ResponseMessage = "error.lineup.notFound"
+	MsgErrorInvalidLineup  ResponseMessage = "error.lineup.invalid"
+
+	// Skill related errors
+	MsgErrorSkillCatalogueNotFound  ResponseMessage = "error.skills.catalogueNotFound"
+	MsgErrorInvalidSkillCatalogue   ResponseMessage = "error.skills.invalidCatalogue"
+	MsgErrorSkillAssessmentNotFound ResponseMessage = "error.skills.assessmentNotFound"
+	MsgErrorInvalidSkillAssessment  ResponseMessage = "error.skills.invalidAssessment"
+
+	// Drill related errors
+	MsgErrorDrillNotFound ResponseMessage = "error.drill.notFound"
+	MsgErrorInvalidDrill  ResponseMessage = "error.drill.invalid"
+
+	// Subgroup related errors
+	MsgErrorSubgroupNotFound ResponseMessage = "error.subgroup.notFound"
+	MsgErrorInvalidSubgroup  ResponseMessage = "error.subgroup.invalid"
+
+	// Handover related errors
+	MsgErrorHandoverNotFound ResponseMessage = "error.handover.notFound"
+	MsgErrorInvalidHandover  ResponseMessage = "error.handover.invalid"
+	MsgErrorHandoverPending  ResponseMessage = "error.handover.pending"
+	MsgErrorHandoverChanged  ResponseMessage = "error.handover.changed"
+
+	// Guardian related errors
+	MsgErrorGuardianshipNotFound    ResponseMessage = "error.guardian.notFound"
+	MsgErrorInvalidGuardianship     ResponseMessage = "error.guardian.invalid"
+	MsgErrorGuardianConsentRequired ResponseMessage = "error.guardian.consentRequired"
+	MsgErrorGuardianConsentGiven    ResponseMessage = "error.guardian.consentGiven"
+
+	// Fitness related errors
+	MsgErrorFitnessTestNotFound   ResponseMessage = "error.fitness.testNotFound"
+	MsgErrorInvalidFitnessTest    ResponseMessage = "error.fitness.invalidTest"
+	MsgErrorFitnessResultNotFound ResponseMessage = "error.fitness.resultNotFound"
+	MsgErrorInvalidFitnessResult  ResponseMessage = "error.fitness.invalidResult"
+
+	// Media reference related errors
+	MsgErrorMediaReferenceNotFound ResponseMessage = "error.media.notFound"
+	MsgErrorInvalidMediaReference  ResponseMessage = "error.media.invalid"
 
 	// Comment related errors
 	MsgErrorCommentNotFound  ResponseMessage = "error.comment.notFound"
@@ -77,6 +172,29 @@ func Response(status int, body interface{}) (*events.APIGatewayProxyResponse, er
 	return &resp, nil
 }
 
+// FileResponse returns a file download. Binary bodies are base64 encoded, as API Gateway expects for binary media types.
+func FileResponse(status int, contentType, filename string, body []byte, binary bool) (*events.APIGatewayProxyResponse, error) {
+	resp := events.APIGatewayProxyResponse{
+		StatusCode: status,
+		Headers: map[string]string{
+			"Content-Type":                     contentType,
+			"Content-Disposition":              fmt.Sprintf("attachment; filename=%q", filename),
+			"Access-Control-Allow-Origin":      "*",
+			"Access-Control-Allow-Headers":     "Content-Type",
+			"Access-Control-Allow-Methods":     "OPTIONS, POST, GET, PUT, DELETE",
+			"Access-Control-Allow-Credentials": "true",
+			"Access-Control-Expose-Headers":    "Content-Disposition",
+		},
+	}
+	if binary {
+		resp.Body = base64.StdEncoding.EncodeToString(body)
+		resp.IsBase64Encoded = true
+	} else {
+		resp.Body = string(body)
+	}
+	return &resp, nil
+}
+
 func SuccessResponse(status int, message ResponseMessage, data interface{}) (*events.APIGatewayProxyResponse, error) {
 	respMap := map[string]interface{}{
 		"message": string(message),
@@ -107,3 +225,8 @@ func ErrorResponse(status int, message ResponseMessage, err error) (*events.APIG
 	}
 	return Response(status, map[string]interface{}{"message": string(message), "error": err.Error()})
 }
+
+// ValidationErrorResponse returns a 400 response listing every field that failed validation.
+func ValidationErrorResponse(message ResponseMessage, fieldErrors []FieldError) (*events.APIGatewayProxyResponse, error) {
+	return Response(http.StatusBadRequest, map[string]interface{}{"message": string(message), "errors": fieldErrors})
+}
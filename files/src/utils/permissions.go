@@ -3,7 +3,6 @@ package utils
 import (
 	"context"
 
-	"github.com/fpgschiba/volleygoals/db"
 	"github.com/fpgschiba/volleygoals/models"
 )
 
@@ -96,47 +95,163 @@ func GetCognitoUsername(authorizer map[string]interface{}) string {
 	return subStr
 }
 
-func HasOneRoleOnTeam(ctx context.Context, authorizer map[string]interface{}, teamId string, requiredRole []models.TeamMemberRole) bool {
+// HasPermission is the central policy check for team-scoped actions. Platform admins and owners and admins of the
+// organization owning the team hold every permission; team members hold the permissions of their team role.
+func HasPermission(ctx context.Context, authorizer map[string]interface{}, teamId string, permission models.Permission) bool {
+	if IsAdmin(authorizer) {
+		return true
+	}
 	if !IsUser(authorizer) {
 		return false
 	}
-	userID := GetCognitoUsername(authorizer)
-	for _, role := range requiredRole {
-		ok, err := db.HasRoleOnTeam(ctx, userID, teamId, role)
-		if err != nil {
+	ok, err := MemberHasPermission(ctx, GetCognitoUsername(authorizer), teamId, permission)
+	if err == nil && ok {
+		return true
+	}
+	return HasOrganizationRoleOnTeam(ctx, authorizer, teamId, []models.OrganizationRole{models.OrganizationRoleOwner, models.OrganizationRoleAdmin})
+}
+
+// MemberHasPermission reports whether the user's active membership in the team grants the permission.
+func MemberHasPermission(ctx context.Context, userId string, teamId string, permission models.Permission) (bool, error) {
+	member, err := teamMembership(ctx, userId, teamId)
+	if err != nil || member == nil {
+		return false, err
+	}
+	return RoleHasPermission(ctx, teamId, member.Role, permission)
+}
+
+// RoleHasPermission reports whether the built-in or custom team role grants the permission. Roles the team does
+// not define grant nothing.
+func RoleHasPermission(ctx context.Context, teamId string, role models.TeamMemberRole, permission models.Permission) (bool, error) {
+	definition, err := GetTeamRole(ctx, teamId, role)
+	if err != nil || definition == nil {
+		return false, err
+	}
+	return definition.Grants(permission), nil
+}
+
+// GetTeamRole resolves a role name to its built-in or custom definition, or nil if the team has no such role.
+func GetTeamRole(ctx context.Context, teamId string, role models.TeamMemberRole) (*models.TeamRole, error) {
+	if builtIn := models.BuiltInTeamRole(role); builtIn != nil {
+		return builtIn, nil
+	}
+	roles, err := customTeamRoles(ctx, teamId)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range roles {
+		if r.Name == role {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+// CanGrantRole reports whether the caller may give a member or invitee the role: the caller has to hold every
+// permission of the role themselves, so nobody can hand out more than they have.
+func CanGrantRole(ctx context.Context, authorizer map[string]interface{}, teamId string, role *models.TeamRole) bool {
+	for _, p := range role.Permissions {
+		if !HasPermission(ctx, authorizer, teamId, p) {
 			return false
 		}
-		if ok {
-			return true
+	}
+	return true
+}
+
+// HasTeamAccess reports whether the caller is an active member of the team or has any role in the organization
+// owning it.
+func HasTeamAccess(ctx context.Context, authorizer map[string]interface{}, teamId string) bool {
+	if !IsUser(authorizer) {
+		return false
+	}
+	member, err := teamMembership(ctx, GetCognitoUsername(authorizer), teamId)
+	if err == nil && member != nil {
+		return true
+	}
+	return HasOrganizationRoleOnTeam(ctx, authorizer, teamId, []models.OrganizationRole{models.OrganizationRoleOwner, models.OrganizationRoleAdmin, models.OrganizationRoleViewer})
+}
+
+func GetUserRoleOnTeam(ctx context.Context, authorizer map[string]interface{}, teamId string) (*models.TeamMemberRole, error) {
+	if !IsUser(authorizer) {
+		return nil, nil
+	}
+	member, err := teamMembership(ctx, GetCognitoUsername(authorizer), teamId)
+	if err != nil || member == nil {
+		return nil, err
+	}
+	return &member.Role, nil
+}
+
+// GetUserRoleOnOrganization returns the caller's role in the organization, or nil if the caller is not a member.
+func GetUserRoleOnOrganization(ctx context.Context, authorizer map[string]interface{}, organizationId string) (*models.OrganizationRole, error) {
+	if !IsUser(authorizer) || organizationId == "" {
+		return nil, nil
+	}
+	memberships, err := organizationMemberships(ctx, GetCognitoUsername(authorizer))
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range memberships {
+		if m.OrganizationId == organizationId {
+			return &m.Role, nil
 		}
 	}
-	return false
+	return nil, nil
 }
 
-func IsTeamAdminOrTrainer(ctx context.Context, authorizer map[string]interface{}, teamId string) bool {
-	return IsTeamTrainer(ctx, authorizer, teamId) || IsTeamAdmin(ctx, authorizer, teamId)
+func HasOrganizationRole(ctx context.Context, authorizer map[string]interface{}, organizationId string, requiredRole []models.OrganizationRole) bool {
+	role, err := GetUserRoleOnOrganization(ctx, authorizer, organizationId)
+	if err != nil || role == nil {
+		return false
+	}
+	for _, r := range requiredRole {
+		if *role == r {
+			return true
+		}
+	}
+	return false
 }
 
-func IsTeamAdmin(ctx context.Context, authorizer map[string]interface{}, teamId string) bool {
-	return HasOneRoleOnTeam(ctx, authorizer, teamId, []models.TeamMemberRole{models.TeamMemberRoleAdmin})
+// HasAnyOrganizationRole reports whether the caller has one of the roles in at least one organization.
+func HasAnyOrganizationRole(ctx context.Context, authorizer map[string]interface{}, requiredRole []models.OrganizationRole) bool {
+	if !IsUser(authorizer) {
+		return false
+	}
+	memberships, err := organizationMemberships(ctx, GetCognitoUsername(authorizer))
+	if err != nil {
+		return false
+	}
+	for _, m := range memberships {
+		for _, r := range requiredRole {
+			if m.Role == r {
+				return true
+			}
+		}
+	}
+	return false
 }
 
-func IsTeamTrainer(ctx context.Context, authorizer map[string]interface{}, teamId string) bool {
-	return HasOneRoleOnTeam(ctx, authorizer, teamId, []models.TeamMemberRole{models.TeamMemberRoleTrainer})
+func IsOrganizationOwner(ctx context.Context, authorizer map[string]interface{}, organizationId string) bool {
+	return HasOrganizationRole(ctx, authorizer, organizationId, []models.OrganizationRole{models.OrganizationRoleOwner})
 }
 
-func HasTeamAccess(ctx context.Context, authorizer map[string]interface{}, teamId string) bool {
-	return HasOneRoleOnTeam(ctx, authorizer, teamId, []models.TeamMemberRole{models.TeamMemberRoleAdmin, models.TeamMemberRoleTrainer, models.TeamMemberRoleMember})
+func IsOrganizationAdmin(ctx context.Context, authorizer map[string]interface{}, organizationId string) bool {
+	return HasOrganizationRole(ctx, authorizer, organizationId, []models.OrganizationRole{models.OrganizationRoleOwner, models.OrganizationRoleAdmin})
 }
 
-func IsTeamUser(ctx context.Context, authorizer map[string]interface{}, teamId string) bool {
-	return HasOneRoleOnTeam(ctx, authorizer, teamId, []models.TeamMemberRole{models.TeamMemberRoleMember})
+func HasOrganizationAccess(ctx context.Context, authorizer map[string]interface{}, organizationId string) bool {
+	return HasOrganizationRole(ctx, authorizer, organizationId, []models.OrganizationRole{models.OrganizationRoleOwner, models.OrganizationRoleAdmin, models.OrganizationRoleViewer})
 }
 
-func GetUserRoleOnTeam(ctx context.Context, authorizer map[string]interface{}, teamId string) (*models.TeamMemberRole, error) {
+// HasOrganizationRoleOnTeam reports whether the caller has one of the roles in the organization owning the team.
+// It is false for teams outside an organization.
+func HasOrganizationRoleOnTeam(ctx context.Context, authorizer map[string]interface{}, teamId string, requiredRole []models.OrganizationRole) bool {
 	if !IsUser(authorizer) {
-		return nil, nil
+		return false
+	}
+	organizationId, err := teamOrganizationId(ctx, teamId)
+	if err != nil || organizationId == "" {
+		return false
 	}
-	userID := GetCognitoUsername(authorizer)
-	return db.GetUserRoleOnTeam(ctx, userID, teamId)
+	return HasOrganizationRole(ctx, authorizer, organizationId, requiredRole)
 }
@@ -6,30 +6,50 @@ locals {
     var.tags,
   )
   lambda_environment_variables = {
-    "TEAMS_TABLE_NAME"            = aws_dynamodb_table.teams.name
-    "TEAM_MEMBERS_TABLE_NAME"     = aws_dynamodb_table.team_members.name
-    "INVITE_TABLE_NAME"           = aws_dynamodb_table.invites.name
-    "TEAM_SETTINGS_TABLE_NAME"    = aws_dynamodb_table.team_settings.name
-    "SEASONS_TABLE_NAME"          = aws_dynamodb_table.seasons.name
-    "GOALS_TABLE_NAME"            = aws_dynamodb_table.goals.name
-    "PROGRESS_REPORTS_TABLE_NAME" = aws_dynamodb_table.progress_reports.name
-    "PROGRESS_TABLE_NAME"         = aws_dynamodb_table.progress.name
-    "COMMENTS_TABLE_NAME"         = aws_dynamodb_table.comments.name
-    "COMMENT_FILES_TABLE_NAME"    = aws_dynamodb_table.comment_files.name
-    "ACTIVITIES_TABLE_NAME"       = aws_dynamodb_table.activities.name
-    "OTEL_PROPAGATORS"            = "xray"
-    "OTEL_SERVICE_NAME"           = "volleygoals"
-    "OTEL_TRACES_SAMPLER"         = "always_on"
-    "OTEL_RESOURCE_ATTRIBUTES"    = "service.name=volleygoals"
-    "EMAIL_SENDER"                = "no-reply@${data.aws_route53_zone.this.name}"
-    "TENANT_NAME"                 = var.ses_tenant_name
-    "CONFIGURATION_SET_NAME"      = aws_sesv2_configuration_set.this.configuration_set_name
-    "FRONTEND_BASE_URL"           = "https://${data.aws_route53_zone.this.name}"
-    "BACKEND_BASE_URL"            = "https://api.${data.aws_route53_zone.this.name}/api/v1"
-    "CDN_BASE_URL"                = "https://cdn.${data.aws_route53_zone.this.name}"
-    "USER_POOL_ID"                = element(split("/", element(split(":", var.cognito_user_pool_arn), -1)), -1)
-    "INVITE_TEMPLATE_ARN"         = aws_ses_template.invitation.arn
-    "S3_BUCKET_NAME"              = aws_s3_bucket.this.bucket
+    "TEAMS_TABLE_NAME"                = aws_dynamodb_table.teams.name
+    "TEAM_MEMBERS_TABLE_NAME"         = aws_dynamodb_table.team_members.name
+    "INVITE_TABLE_NAME"               = aws_dynamodb_table.invites.name
+    "TEAM_SETTINGS_TABLE_NAME"        = aws_dynamodb_table.team_settings.name
+    "SEASONS_TABLE_NAME"              = aws_dynamodb_table.seasons.name
+    "GOALS_TABLE_NAME"                = aws_dynamodb_table.goals.name
+    "PROGRESS_REPORTS_TABLE_NAME"     = aws_dynamodb_table.progress_reports.name
+    "PROGRESS_TABLE_NAME"             = aws_dynamodb_table.progress.name
+    "COMMENTS_TABLE_NAME"             = aws_dynamodb_table.comments.name
+    "COMMENT_FILES_TABLE_NAME"        = aws_dynamodb_table.comment_files.name
+    "ACTIVITIES_TABLE_NAME"           = aws_dynamodb_table.activities.name
+    "QUESTIONNAIRES_TABLE_NAME"       = aws_dynamodb_table.questionnaires.name
+    "SEASON_STATS_TABLE_NAME"         = aws_dynamodb_table.season_stats.name
+    "EVENTS_TABLE_NAME"               = aws_dynamodb_table.events.name
+    "ATTENDANCE_TABLE_NAME"           = aws_dynamodb_table.attendance.name
+    "MATCH_STATS_TABLE_NAME"          = aws_dynamodb_table.match_stats.name
+    "SKILL_CATALOGUES_TABLE_NAME"     = aws_dynamodb_table.skill_catalogues.name
+    "SKILL_ASSESSMENTS_TABLE_NAME"    = aws_dynamodb_table.skill_assessments.name
+    "DRILLS_TABLE_NAME"               = aws_dynamodb_table.drills.name
+    "SUBGROUPS_TABLE_NAME"            = aws_dynamodb_table.subgroups.name
+    "HANDOVERS_TABLE_NAME"            = aws_dynamodb_table.handovers.name
+    "GUARDIANS_TABLE_NAME"            = aws_dynamodb_table.guardians.name
+    "LINEUPS_TABLE_NAME"              = aws_dynamodb_table.lineups.name
+    "FITNESS_TESTS_TABLE_NAME"        = aws_dynamodb_table.fitness_tests.name
+    "FITNESS_RESULTS_TABLE_NAME"      = aws_dynamodb_table.fitness_results.name
+    "MEDIA_REFERENCES_TABLE_NAME"     = aws_dynamodb_table.media_references.name
+    "ORGANIZATIONS_TABLE_NAME"        = aws_dynamodb_table.organizations.name
+    "ORGANIZATION_MEMBERS_TABLE_NAME" = aws_dynamodb_table.organization_members.name
+    "TEAM_ROLES_TABLE_NAME"           = aws_dynamodb_table.team_roles.name
+    "OTEL_PROPAGATORS"                = "xray"
+    "OTEL_SERVICE_NAME"               = "volleygoals"
+    "OTEL_TRACES_SAMPLER"             = "always_on"
+    "OTEL_RESOURCE_ATTRIBUTES"        = "service.name=volleygoals"
+    "EMAIL_SENDER"                    = "no-reply@${data.aws_route53_zone.this.name}"
+    "TENANT_NAME"                     = var.ses_tenant_name
+    "CONFIGURATION_SET_NAME"          = aws_sesv2_configuration_set.this.configuration_set_name
+    "FRONTEND_BASE_URL"               = "https://${data.aws_route53_zone.this.name}"
+    "BACKEND_BASE_URL"                = "https://api.${data.aws_route53_zone.this.name}/api/v1"
+    "CDN_BASE_URL"                    = "https://cdn.${data.aws_route53_zone.this.name}"
+    "USER_POOL_ID"                    = element(split("/", element(split(":", var.cognito_user_pool_arn), -1)), -1)
+    "INVITE_TEMPLATE_ARN"             = aws_ses_template.invitation.arn
+    "SEASON_STATUS_TEMPLATE_ARN"      = aws_ses_template.season_status.arn
+    "GUARDIAN_TEMPLATE_ARN"           = aws_ses_template.guardian_notification.arn
+    "S3_BUCKET_NAME"                  = aws_s3_bucket.this.bucket
   }
   lambda_layer_arns = [
     "arn:aws:lambda:${data.aws_region.current.region}:901920570463:layer:aws-otel-collector-amd64-ver-0-117-0:1" # Me hates it, as it is hardcoded
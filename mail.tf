@@ -117,3 +117,122 @@ resource "aws_ses_template" "invitation" {
     The VolleyGoals team
   TEXT
 }
+
+resource "aws_ses_template" "season_status" {
+  name    = "${var.prefix}-season-status"
+  subject = "{{seasonName}} is now {{status}} — {{teamName}}"
+  html    = <<-HTML
+    <!doctype html>
+    <html>
+    <head>
+      <meta charset="utf-8" />
+      <meta name="viewport" content="width=device-width,initial-scale=1" />
+      <style>
+        body{font-family:Arial,Helvetica,sans-serif;background:#ffffff;color:#000000;margin:0;padding:0}
+        .email-container{max-width:600px;margin:24px auto;background:#f8f8f8;border-radius:8px;overflow:hidden;box-shadow:0 2px 6px rgba(0,0,0,.06)}
+        .header{padding:24px;background:#C41E3A;color:#ffffff;text-align:center}
+        .content{padding:24px;color:#000000}
+        a{color:#C41E3A}
+        .button{display:inline-block;padding:12px 20px;background:#C41E3A;color:#ffffff;text-decoration:none;border-radius:6px}
+        .footer{padding:16px;font-size:12px;color:#666666;text-align:center}
+
+        @media (prefers-color-scheme: dark) {
+          body{background:#0a0a0a;color:#ffffff}
+          .email-container{background:#1a1a1a;box-shadow:none}
+          .content{color:#ffffff}
+          .footer{color:#b0b0b0}
+        }
+      </style>
+    </head>
+    <body>
+      <div class="email-container">
+        <div class="header">
+          <h1 style="margin:0;font-size:20px">{{seasonName}} is now {{status}}</h1>
+        </div>
+        <div class="content">
+          <p>Hello {{recipientName}},</p>
+          <p>The season <strong>{{seasonName}}</strong> of <strong>{{teamName}}</strong> ({{startDate}} to {{endDate}}) is now <strong>{{status}}</strong>.</p>
+          <p style="text-align:center">
+            <a class="button" href="{{appLink}}" target="_blank" rel="noopener">Open VolleyGoals</a>
+          </p>
+        </div>
+        <div class="footer">This message was sent from <strong>no-reply@${data.aws_route53_zone.this.name}</strong>. Please do not reply to this email. For help or support, visit <a href="https://${data.aws_route53_zone.this.name}/support" target="_blank" rel="noopener">VolleyGoals Support</a>.</div>
+      </div>
+    </body>
+    </html>
+  HTML
+  text    = <<-TEXT
+    Hello {{recipientName}},
+
+    The season "{{seasonName}}" of {{teamName}} ({{startDate}} to {{endDate}}) is now {{status}}.
+
+    Open VolleyGoals: {{appLink}}
+
+    This message was sent from no-reply@${data.aws_route53_zone.this.name}. Please do not reply to this email.
+    For support visit: https://${data.aws_route53_zone.this.name}/support
+
+    Thanks,
+    The VolleyGoals team
+  TEXT
+}
+
+resource "aws_ses_template" "guardian_notification" {
+  name    = "${var.prefix}-guardian-notification"
+  subject = "News about {{playerName}} — {{teamName}}"
+  html    = <<-HTML
+    <!doctype html>
+    <html>
+    <head>
+      <meta charset="utf-8" />
+      <meta name="viewport" content="width=device-width,initial-scale=1" />
+      <style>
+        body{font-family:Arial,Helvetica,sans-serif;background:#ffffff;color:#000000;margin:0;padding:0}
+        .email-container{max-width:600px;margin:24px auto;background:#f8f8f8;border-radius:8px;overflow:hidden;box-shadow:0 2px 6px rgba(0,0,0,.06)}
+        .header{padding:24px;background:#C41E3A;color:#ffffff;text-align:center}
+        .content{padding:24px;color:#000000}
+        a{color:#C41E3A}
+        .button{display:inline-block;padding:12px 20px;background:#C41E3A;color:#ffffff;text-decoration:none;border-radius:6px}
+        .footer{padding:16px;font-size:12px;color:#666666;text-align:center}
+
+        @media (prefers-color-scheme: dark) {
+          body{background:#0a0a0a;color:#ffffff}
+          .email-container{background:#1a1a1a;box-shadow:none}
+          .content{color:#ffffff}
+          .footer{color:#b0b0b0}
+        }
+      </style>
+    </head>
+    <body>
+      <div class="email-container">
+        <div class="header">
+          <h1 style="margin:0;font-size:20px">News about {{playerName}}</h1>
+        </div>
+        <div class="content">
+          <p>Hello {{recipientName}},</p>
+          <p>You are receiving this as guardian of <strong>{{playerName}}</strong> on <strong>{{teamName}}</strong>.</p>
+          <p>{{message}}</p>
+          <p style="text-align:center">
+            <a class="button" href="{{appLink}}" target="_blank" rel="noopener">Open VolleyGoals</a>
+          </p>
+        </div>
+        <div class="footer">This message was sent from <strong>no-reply@${data.aws_route53_zone.this.name}</strong>. Please do not reply to this email. For help or support, visit <a href="https://${data.aws_route53_zone.this.name}/support" target="_blank" rel="noopener">VolleyGoals Support</a>.</div>
+      </div>
+    </body>
+    </html>
+  HTML
+  text    = <<-TEXT
+    Hello {{recipientName}},
+
+    You are receiving this as guardian of {{playerName}} on {{teamName}}.
+
+    {{message}}
+
+    Open VolleyGoals: {{appLink}}
+
+    This message was sent from no-reply@${data.aws_route53_zone.this.name}. Please do not reply to this email.
+    For support visit: https://${data.aws_route53_zone.this.name}/support
+
+    Thanks,
+    The VolleyGoals team
+  TEXT
+}
@@ -137,7 +137,11 @@ module "get_teams_ms" {
       resources = [
         aws_dynamodb_table.teams.arn
       ]
-    }
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -184,6 +188,18 @@ module "get_team_ms" {
         "${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex",
       ]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -231,6 +247,14 @@ module "create_team_ms" {
       actions   = ["cognito-idp:AdminGetUser", "cognito-idp:AdminListGroupsForUser"]
       resources = [var.cognito_user_pool_arn]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.organizations.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -297,6 +321,65 @@ module "delete_team_ms" {
         "${aws_dynamodb_table.team_settings.arn}/index/teamIdIndex",
       ]
     },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.media_references.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.media_references.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.team_roles.arn]
+    },
+    {
+      actions = ["dynamodb:Query"]
+      resources = [
+        "${aws_dynamodb_table.events.arn}/index/seasonIdIndex",
+        "${aws_dynamodb_table.attendance.arn}/index/seasonIdIndex",
+        "${aws_dynamodb_table.match_stats.arn}/index/seasonIdIndex",
+        "${aws_dynamodb_table.lineups.arn}/index/seasonIdIndex",
+        "${aws_dynamodb_table.questionnaires.arn}/index/seasonIdIndex",
+        "${aws_dynamodb_table.subgroups.arn}/index/teamIdIndex",
+        "${aws_dynamodb_table.handovers.arn}/index/teamIdIndex",
+        "${aws_dynamodb_table.drills.arn}/index/teamIdIndex",
+        "${aws_dynamodb_table.skill_assessments.arn}/index/teamIdIndex",
+        "${aws_dynamodb_table.fitness_tests.arn}/index/teamIdIndex",
+        "${aws_dynamodb_table.fitness_results.arn}/index/teamIdIndex",
+        "${aws_dynamodb_table.fitness_results.arn}/index/testIdIndex",
+      ]
+    },
+    {
+      actions = ["dynamodb:DeleteItem"]
+      resources = [
+        aws_dynamodb_table.events.arn,
+        aws_dynamodb_table.attendance.arn,
+        aws_dynamodb_table.match_stats.arn,
+        aws_dynamodb_table.lineups.arn,
+        aws_dynamodb_table.questionnaires.arn,
+        aws_dynamodb_table.season_stats.arn,
+        aws_dynamodb_table.subgroups.arn,
+        aws_dynamodb_table.handovers.arn,
+        aws_dynamodb_table.drills.arn,
+        aws_dynamodb_table.skill_catalogues.arn,
+        aws_dynamodb_table.skill_assessments.arn,
+        aws_dynamodb_table.fitness_tests.arn,
+        aws_dynamodb_table.fitness_results.arn,
+      ]
+    },
   ]
 
   depends_on = [
@@ -349,6 +432,22 @@ module "update_team_ms" {
       actions   = ["cognito-idp:AdminGetUser", "cognito-idp:AdminListGroupsForUser"]
       resources = [var.cognito_user_pool_arn]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.organizations.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -399,6 +498,18 @@ module "get_team_invites_ms" {
         "${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex",
       ]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -459,6 +570,18 @@ module "upload_team_picture_ms" {
       actions   = ["dynamodb:Query"]
       resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -509,6 +632,22 @@ module "get_team_activity_ms" {
       actions   = ["dynamodb:Query"]
       resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.subgroups.arn]
+    },
   ]
 
   depends_on = [
@@ -571,6 +710,18 @@ module "update_team_settings_ms" {
       actions   = ["cognito-idp:AdminGetUser", "cognito-idp:AdminListGroupsForUser"]
       resources = [var.cognito_user_pool_arn]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -631,6 +782,18 @@ module "list_team_members_ms" {
       actions   = ["cognito-idp:AdminGetUser", "cognito-idp:AdminListGroupsForUser"]
       resources = [var.cognito_user_pool_arn]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -682,6 +845,18 @@ module "add_team_member_ms" {
       actions   = ["cognito-idp:AdminGetUser", "cognito-idp:AdminListGroupsForUser"]
       resources = [var.cognito_user_pool_arn]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -718,11 +893,14 @@ module "update_team_member_ms" {
 
   additional_iam_statements = [
     {
-      actions   = ["dynamodb:Query"]
-      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+      actions = ["dynamodb:Query"]
+      resources = [
+        "${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex",
+        "${aws_dynamodb_table.team_members.arn}/index/teamIdIndex",
+      ]
     },
     {
-      actions   = ["dynamodb:UpdateItem"]
+      actions   = ["dynamodb:GetItem", "dynamodb:UpdateItem", "dynamodb:ConditionCheckItem"]
       resources = [aws_dynamodb_table.team_members.arn]
     },
     {
@@ -733,6 +911,18 @@ module "update_team_member_ms" {
       actions   = ["cognito-idp:AdminGetUser", "cognito-idp:AdminListGroupsForUser"]
       resources = [var.cognito_user_pool_arn]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -769,11 +959,14 @@ module "delete_team_member_ms" {
 
   additional_iam_statements = [
     {
-      actions   = ["dynamodb:Query"]
-      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+      actions = ["dynamodb:Query"]
+      resources = [
+        "${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex",
+        "${aws_dynamodb_table.team_members.arn}/index/teamIdIndex",
+      ]
     },
     {
-      actions   = ["dynamodb:DeleteItem"]
+      actions   = ["dynamodb:GetItem", "dynamodb:DeleteItem", "dynamodb:ConditionCheckItem"]
       resources = [aws_dynamodb_table.team_members.arn]
     },
     {
@@ -784,6 +977,18 @@ module "delete_team_member_ms" {
       actions   = ["cognito-idp:AdminGetUser", "cognito-idp:AdminListGroupsForUser"]
       resources = [var.cognito_user_pool_arn]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -820,13 +1025,25 @@ module "leave_team_ms" {
 
   additional_iam_statements = [
     {
-      actions = ["dynamodb:Query", "dynamodb:UpdateItem"]
+      actions = ["dynamodb:Query", "dynamodb:UpdateItem", "dynamodb:ConditionCheckItem"]
       resources = [
         aws_dynamodb_table.team_members.arn,
         "${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex",
         "${aws_dynamodb_table.team_members.arn}/index/teamIdIndex",
       ]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -835,3 +1052,173 @@ module "leave_team_ms" {
     data.archive_file.shared_lambda_zip,
   ]
 }
+
+resource "aws_api_gateway_resource" "team_member_development" {
+  rest_api_id = aws_api_gateway_rest_api.api.id
+  parent_id   = aws_api_gateway_resource.team_member_id.id
+  path_part   = "development"
+}
+
+module "get_member_development_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = true
+  http_methods          = ["GET"]
+  name_overwrite        = "get-member-development"
+  path_name             = "development"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.team_member_development.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+
+  handler_name  = "GetMemberDevelopment"
+  pre_built_zip = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions = ["dynamodb:GetItem", "dynamodb:Query"]
+      resources = [
+        aws_dynamodb_table.team_members.arn,
+        "${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex",
+      ]
+    },
+    {
+      actions = ["dynamodb:Scan"]
+      resources = [
+        aws_dynamodb_table.seasons.arn,
+        aws_dynamodb_table.goals.arn,
+        aws_dynamodb_table.progress_reports.arn,
+        aws_dynamodb_table.progress.arn,
+      ]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.team_member_development,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+resource "aws_api_gateway_resource" "team_member_transfer" {
+  rest_api_id = aws_api_gateway_rest_api.api.id
+  parent_id   = aws_api_gateway_resource.team_member_id.id
+  path_part   = "transfer"
+}
+
+module "transfer_team_member_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = true
+  http_methods          = ["POST"]
+  name_overwrite        = "transfer-team-member"
+  path_name             = "transfer"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.team_member_transfer.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+
+  handler_name  = "TransferTeamMember"
+  pre_built_zip = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions = ["dynamodb:GetItem", "dynamodb:Query", "dynamodb:PutItem", "dynamodb:UpdateItem"]
+      resources = [
+        aws_dynamodb_table.team_members.arn,
+        "${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex",
+        "${aws_dynamodb_table.team_members.arn}/index/teamIdIndex",
+      ]
+    },
+    {
+      actions   = ["dynamodb:TransactWriteItems"]
+      resources = [aws_dynamodb_table.team_members.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem", "dynamodb:Scan"]
+      resources = [aws_dynamodb_table.seasons.arn]
+    },
+    {
+      actions   = ["dynamodb:Scan", "dynamodb:UpdateItem"]
+      resources = [aws_dynamodb_table.goals.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.drills.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:PutItem"]
+      resources = [aws_dynamodb_table.drills.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.media_references.arn}/index/targetIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem", "dynamodb:PutItem"]
+      resources = [aws_dynamodb_table.media_references.arn]
+    },
+    {
+      actions   = ["dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.season_stats.arn]
+    },
+    {
+      actions   = ["dynamodb:PutItem"]
+      resources = [aws_dynamodb_table.activities.arn]
+    },
+    {
+      actions   = ["cognito-idp:AdminGetUser", "cognito-idp:AdminListGroupsForUser"]
+      resources = [var.cognito_user_pool_arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.team_member_transfer,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
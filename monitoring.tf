@@ -79,32 +79,67 @@ resource "aws_cloudwatch_metric_alarm" "api_latency" {
 
 locals {
   dynamodb_tables = {
-    teams            = aws_dynamodb_table.teams.name
-    team_members     = aws_dynamodb_table.team_members.name
-    invites          = aws_dynamodb_table.invites.name
-    team_settings    = aws_dynamodb_table.team_settings.name
-    seasons          = aws_dynamodb_table.seasons.name
-    goals            = aws_dynamodb_table.goals.name
-    progress_reports = aws_dynamodb_table.progress_reports.name
-    progress         = aws_dynamodb_table.progress.name
-    comments         = aws_dynamodb_table.comments.name
-    comment_files    = aws_dynamodb_table.comment_files.name
-    activities       = aws_dynamodb_table.activities.name
+    teams                = aws_dynamodb_table.teams.name
+    team_members         = aws_dynamodb_table.team_members.name
+    invites              = aws_dynamodb_table.invites.name
+    team_settings        = aws_dynamodb_table.team_settings.name
+    seasons              = aws_dynamodb_table.seasons.name
+    goals                = aws_dynamodb_table.goals.name
+    progress_reports     = aws_dynamodb_table.progress_reports.name
+    progress             = aws_dynamodb_table.progress.name
+    comments             = aws_dynamodb_table.comments.name
+    comment_files        = aws_dynamodb_table.comment_files.name
+    activities           = aws_dynamodb_table.activities.name
+    season_stats         = aws_dynamodb_table.season_stats.name
+    events               = aws_dynamodb_table.events.name
+    attendance           = aws_dynamodb_table.attendance.name
+    match_stats          = aws_dynamodb_table.match_stats.name
+    skill_catalogues     = aws_dynamodb_table.skill_catalogues.name
+    skill_assessments    = aws_dynamodb_table.skill_assessments.name
+    drills               = aws_dynamodb_table.drills.name
+    lineups              = aws_dynamodb_table.lineups.name
+    fitness_tests        = aws_dynamodb_table.fitness_tests.name
+    fitness_results      = aws_dynamodb_table.fitness_results.name
+    media_references     = aws_dynamodb_table.media_references.name
+    organizations        = aws_dynamodb_table.organizations.name
+    organization_members = aws_dynamodb_table.organization_members.name
+    team_roles           = aws_dynamodb_table.team_roles.name
+    subgroups            = aws_dynamodb_table.subgroups.name
+    handovers            = aws_dynamodb_table.handovers.name
+    guardians            = aws_dynamodb_table.guardians.name
   }
 
   lambda_function_names = [
     "get-self", "update-self", "upload-self-picture",
     "list-teams", "get-team", "create-team", "update-team", "delete-team",
     "update-team-settings",
-    "list-team-members", "add-team-member", "update-team-member", "delete-team-member", "leave-team",
+    "list-team-members", "add-team-member", "update-team-member", "delete-team-member", "leave-team", "get-member-development",
+    "transfer-team-member", "create-handover", "list-handovers", "respond-to-handover", "cancel-handover",
     "upload-team-picture", "get-team-activity", "get-team-invites",
     "create-invite", "complete-invite", "revoke-invite", "resend-invite", "get-invite-by-token",
-    "list-users", "get-user", "delete-user", "update-user",
-    "create-season", "list-seasons", "get-season", "update-season", "delete-season", "get-season-stats",
+    "list-users", "get-user", "delete-user", "update-user", "list-user-memberships",
+    "create-guardianship", "list-guardians", "delete-guardianship", "list-wards", "get-ward-overview", "give-guardian-consent",
+    "create-season", "list-seasons", "get-season", "update-season", "delete-season", "get-season-stats", "clone-season",
+    "create-event", "list-events", "get-event", "update-event", "delete-event",
+    "get-event-attendance", "update-event-attendance", "list-season-attendance",
+    "get-match-stats", "update-match-stats", "delete-match-stats", "get-season-match-stats", "get-player-match-stats",
+    "get-lineup", "update-lineup", "delete-lineup", "get-season-lineup-history", "get-player-lineup-history",
+    "get-skill-catalogue", "update-skill-catalogue", "get-skill-matrix", "create-skill-assessment", "list-skill-assessments",
+    "delete-skill-assessment", "get-member-skill-radar",
+    "create-drill", "list-drills", "get-drill", "update-drill", "delete-drill", "upload-drill-media", "suggest-drills-for-goal",
+    "create-fitness-test", "list-fitness-tests", "update-fitness-test", "delete-fitness-test",
+    "create-fitness-result", "list-fitness-results", "delete-fitness-result", "get-fitness-leaderboard", "get-member-personal-bests",
+    "create-goal-media", "update-goal-media", "delete-goal-media", "create-progress-entry-media", "update-progress-entry-media", "delete-progress-entry-media",
+    "create-comment-media", "update-comment-media", "delete-comment-media",
+    "create-organization", "list-organizations", "get-organization", "update-organization", "delete-organization",
+    "list-organization-members", "add-organization-member", "update-organization-member", "remove-organization-member",
+    "list-team-roles", "create-team-role", "update-team-role", "delete-team-role",
+    "create-subgroup", "list-subgroups", "get-subgroup", "update-subgroup", "delete-subgroup",
     "create-goal", "list-goals", "get-goal", "update-goal", "delete-goal", "upload-goal-file",
     "create-progress-report", "list-progress-reports", "get-progress-report", "update-progress-report", "delete-progress-report",
     "create-comment", "list-comments", "get-comment", "update-comment", "delete-comment", "upload-comment-file",
     "global-search", "health-check",
+    "transition-season-statuses",
   ]
 }
 
@@ -152,12 +187,36 @@ resource "aws_cloudwatch_log_metric_filter" "lambda_errors" {
     module.update_team_ms, module.get_team_invites_ms, module.upload_team_picture_ms,
     module.get_team_activity_ms, module.update_team_settings_ms,
     module.list_team_members_ms, module.add_team_member_ms, module.update_team_member_ms,
-    module.delete_team_member_ms, module.leave_team_ms,
+    module.delete_team_member_ms, module.leave_team_ms, module.get_member_development_ms,
+    module.transfer_team_member_ms, module.create_handover_ms, module.list_handovers_ms, module.respond_to_handover_ms,
+    module.cancel_handover_ms,
     module.create_invite_ms, module.complete_invite_ms, module.revoke_invite_ms,
     module.resend_invite_ms, module.get_invite_by_token_ms,
-    module.list_users_ms, module.get_user_ms, module.delete_user_ms, module.update_user_ms,
+    module.list_users_ms, module.get_user_ms, module.delete_user_ms, module.update_user_ms, module.list_user_memberships_ms,
+    module.create_guardianship_ms, module.list_guardians_ms, module.delete_guardianship_ms, module.list_wards_ms,
+    module.get_ward_overview_ms, module.give_guardian_consent_ms,
     module.create_season_ms, module.list_seasons_ms, module.get_season_ms,
-    module.update_season_ms, module.delete_season_ms, module.get_season_stats_ms,
+    module.update_season_ms, module.delete_season_ms, module.get_season_stats_ms, module.clone_season_ms,
+    module.create_event_ms, module.list_events_ms, module.get_event_ms, module.update_event_ms, module.delete_event_ms,
+    module.get_event_attendance_ms, module.update_event_attendance_ms, module.list_season_attendance_ms,
+    module.get_match_stats_ms, module.update_match_stats_ms, module.delete_match_stats_ms,
+    module.get_season_match_stats_ms, module.get_player_match_stats_ms,
+    module.get_lineup_ms, module.update_lineup_ms, module.delete_lineup_ms,
+    module.get_season_lineup_history_ms, module.get_player_lineup_history_ms,
+    module.get_skill_catalogue_ms, module.update_skill_catalogue_ms, module.get_skill_matrix_ms,
+    module.create_skill_assessment_ms, module.list_skill_assessments_ms, module.delete_skill_assessment_ms,
+    module.get_member_skill_radar_ms,
+    module.create_drill_ms, module.list_drills_ms, module.get_drill_ms, module.update_drill_ms,
+    module.delete_drill_ms, module.upload_drill_media_ms, module.suggest_drills_for_goal_ms,
+    module.create_fitness_test_ms, module.list_fitness_tests_ms, module.update_fitness_test_ms, module.delete_fitness_test_ms,
+    module.create_fitness_result_ms, module.list_fitness_results_ms, module.delete_fitness_result_ms,
+    module.get_fitness_leaderboard_ms, module.get_member_personal_bests_ms,
+    module.create_goal_media_ms, module.update_goal_media_ms, module.delete_goal_media_ms, module.create_progress_entry_media_ms, module.update_progress_entry_media_ms,
+    module.delete_progress_entry_media_ms, module.create_comment_media_ms, module.update_comment_media_ms, module.delete_comment_media_ms,
+    module.create_organization_ms, module.list_organizations_ms, module.get_organization_ms, module.update_organization_ms, module.delete_organization_ms,
+    module.list_organization_members_ms, module.add_organization_member_ms, module.update_organization_member_ms, module.remove_organization_member_ms,
+    module.list_team_roles_ms, module.create_team_role_ms, module.update_team_role_ms, module.delete_team_role_ms,
+    module.create_subgroup_ms, module.list_subgroups_ms, module.get_subgroup_ms, module.update_subgroup_ms, module.delete_subgroup_ms,
     module.create_goal_ms, module.list_goals_ms, module.get_goal_ms,
     module.update_goal_ms, module.delete_goal_ms, module.upload_goal_file_ms,
     module.create_progress_report_ms, module.list_progress_reports_ms,
@@ -165,6 +224,7 @@ resource "aws_cloudwatch_log_metric_filter" "lambda_errors" {
     module.create_comment_ms, module.list_comments_ms, module.get_comment_ms,
     module.update_comment_ms, module.delete_comment_ms, module.upload_comment_file_ms,
     module.global_search_ms, module.health_check_ms,
+    aws_lambda_function.transition_season_statuses,
   ]
 }
 
@@ -188,12 +248,36 @@ resource "aws_cloudwatch_log_metric_filter" "lambda_panics" {
     module.update_team_ms, module.get_team_invites_ms, module.upload_team_picture_ms,
     module.get_team_activity_ms, module.update_team_settings_ms,
     module.list_team_members_ms, module.add_team_member_ms, module.update_team_member_ms,
-    module.delete_team_member_ms, module.leave_team_ms,
+    module.delete_team_member_ms, module.leave_team_ms, module.get_member_development_ms,
+    module.transfer_team_member_ms, module.create_handover_ms, module.list_handovers_ms, module.respond_to_handover_ms,
+    module.cancel_handover_ms,
     module.create_invite_ms, module.complete_invite_ms, module.revoke_invite_ms,
     module.resend_invite_ms, module.get_invite_by_token_ms,
-    module.list_users_ms, module.get_user_ms, module.delete_user_ms, module.update_user_ms,
+    module.list_users_ms, module.get_user_ms, module.delete_user_ms, module.update_user_ms, module.list_user_memberships_ms,
+    module.create_guardianship_ms, module.list_guardians_ms, module.delete_guardianship_ms, module.list_wards_ms,
+    module.get_ward_overview_ms, module.give_guardian_consent_ms,
     module.create_season_ms, module.list_seasons_ms, module.get_season_ms,
-    module.update_season_ms, module.delete_season_ms, module.get_season_stats_ms,
+    module.update_season_ms, module.delete_season_ms, module.get_season_stats_ms, module.clone_season_ms,
+    module.create_event_ms, module.list_events_ms, module.get_event_ms, module.update_event_ms, module.delete_event_ms,
+    module.get_event_attendance_ms, module.update_event_attendance_ms, module.list_season_attendance_ms,
+    module.get_match_stats_ms, module.update_match_stats_ms, module.delete_match_stats_ms,
+    module.get_season_match_stats_ms, module.get_player_match_stats_ms,
+    module.get_lineup_ms, module.update_lineup_ms, module.delete_lineup_ms,
+    module.get_season_lineup_history_ms, module.get_player_lineup_history_ms,
+    module.get_skill_catalogue_ms, module.update_skill_catalogue_ms, module.get_skill_matrix_ms,
+    module.create_skill_assessment_ms, module.list_skill_assessments_ms, module.delete_skill_assessment_ms,
+    module.get_member_skill_radar_ms,
+    module.create_drill_ms, module.list_drills_ms, module.get_drill_ms, module.update_drill_ms,
+    module.delete_drill_ms, module.upload_drill_media_ms, module.suggest_drills_for_goal_ms,
+    module.create_fitness_test_ms, module.list_fitness_tests_ms, module.update_fitness_test_ms, module.delete_fitness_test_ms,
+    module.create_fitness_result_ms, module.list_fitness_results_ms, module.delete_fitness_result_ms,
+    module.get_fitness_leaderboard_ms, module.get_member_personal_bests_ms,
+    module.create_goal_media_ms, module.update_goal_media_ms, module.delete_goal_media_ms, module.create_progress_entry_media_ms, module.update_progress_entry_media_ms,
+    module.delete_progress_entry_media_ms, module.create_comment_media_ms, module.update_comment_media_ms, module.delete_comment_media_ms,
+    module.create_organization_ms, module.list_organizations_ms, module.get_organization_ms, module.update_organization_ms, module.delete_organization_ms,
+    module.list_organization_members_ms, module.add_organization_member_ms, module.update_organization_member_ms, module.remove_organization_member_ms,
+    module.list_team_roles_ms, module.create_team_role_ms, module.update_team_role_ms, module.delete_team_role_ms,
+    module.create_subgroup_ms, module.list_subgroups_ms, module.get_subgroup_ms, module.update_subgroup_ms, module.delete_subgroup_ms,
     module.create_goal_ms, module.list_goals_ms, module.get_goal_ms,
     module.update_goal_ms, module.delete_goal_ms, module.upload_goal_file_ms,
     module.create_progress_report_ms, module.list_progress_reports_ms,
@@ -201,6 +285,7 @@ resource "aws_cloudwatch_log_metric_filter" "lambda_panics" {
     module.create_comment_ms, module.list_comments_ms, module.get_comment_ms,
     module.update_comment_ms, module.delete_comment_ms, module.upload_comment_file_ms,
     module.global_search_ms, module.health_check_ms,
+    aws_lambda_function.transition_season_statuses,
   ]
 }
 
@@ -224,12 +309,36 @@ resource "aws_cloudwatch_log_metric_filter" "lambda_access_denied" {
     module.update_team_ms, module.get_team_invites_ms, module.upload_team_picture_ms,
     module.get_team_activity_ms, module.update_team_settings_ms,
     module.list_team_members_ms, module.add_team_member_ms, module.update_team_member_ms,
-    module.delete_team_member_ms, module.leave_team_ms,
+    module.delete_team_member_ms, module.leave_team_ms, module.get_member_development_ms,
+    module.transfer_team_member_ms, module.create_handover_ms, module.list_handovers_ms, module.respond_to_handover_ms,
+    module.cancel_handover_ms,
     module.create_invite_ms, module.complete_invite_ms, module.revoke_invite_ms,
     module.resend_invite_ms, module.get_invite_by_token_ms,
-    module.list_users_ms, module.get_user_ms, module.delete_user_ms, module.update_user_ms,
+    module.list_users_ms, module.get_user_ms, module.delete_user_ms, module.update_user_ms, module.list_user_memberships_ms,
+    module.create_guardianship_ms, module.list_guardians_ms, module.delete_guardianship_ms, module.list_wards_ms,
+    module.get_ward_overview_ms, module.give_guardian_consent_ms,
     module.create_season_ms, module.list_seasons_ms, module.get_season_ms,
-    module.update_season_ms, module.delete_season_ms, module.get_season_stats_ms,
+    module.update_season_ms, module.delete_season_ms, module.get_season_stats_ms, module.clone_season_ms,
+    module.create_event_ms, module.list_events_ms, module.get_event_ms, module.update_event_ms, module.delete_event_ms,
+    module.get_event_attendance_ms, module.update_event_attendance_ms, module.list_season_attendance_ms,
+    module.get_match_stats_ms, module.update_match_stats_ms, module.delete_match_stats_ms,
+    module.get_season_match_stats_ms, module.get_player_match_stats_ms,
+    module.get_lineup_ms, module.update_lineup_ms, module.delete_lineup_ms,
+    module.get_season_lineup_history_ms, module.get_player_lineup_history_ms,
+    module.get_skill_catalogue_ms, module.update_skill_catalogue_ms, module.get_skill_matrix_ms,
+    module.create_skill_assessment_ms, module.list_skill_assessments_ms, module.delete_skill_assessment_ms,
+    module.get_member_skill_radar_ms,
+    module.create_drill_ms, module.list_drills_ms, module.get_drill_ms, module.update_drill_ms,
+    module.delete_drill_ms, module.upload_drill_media_ms, module.suggest_drills_for_goal_ms,
+    module.create_fitness_test_ms, module.list_fitness_tests_ms, module.update_fitness_test_ms, module.delete_fitness_test_ms,
+    module.create_fitness_result_ms, module.list_fitness_results_ms, module.delete_fitness_result_ms,
+    module.get_fitness_leaderboard_ms, module.get_member_personal_bests_ms,
+    module.create_goal_media_ms, module.update_goal_media_ms, module.delete_goal_media_ms, module.create_progress_entry_media_ms, module.update_progress_entry_media_ms,
+    module.delete_progress_entry_media_ms, module.create_comment_media_ms, module.update_comment_media_ms, module.delete_comment_media_ms,
+    module.create_organization_ms, module.list_organizations_ms, module.get_organization_ms, module.update_organization_ms, module.delete_organization_ms,
+    module.list_organization_members_ms, module.add_organization_member_ms, module.update_organization_member_ms, module.remove_organization_member_ms,
+    module.list_team_roles_ms, module.create_team_role_ms, module.update_team_role_ms, module.delete_team_role_ms,
+    module.create_subgroup_ms, module.list_subgroups_ms, module.get_subgroup_ms, module.update_subgroup_ms, module.delete_subgroup_ms,
     module.create_goal_ms, module.list_goals_ms, module.get_goal_ms,
     module.update_goal_ms, module.delete_goal_ms, module.upload_goal_file_ms,
     module.create_progress_report_ms, module.list_progress_reports_ms,
@@ -237,6 +346,7 @@ resource "aws_cloudwatch_log_metric_filter" "lambda_access_denied" {
     module.create_comment_ms, module.list_comments_ms, module.get_comment_ms,
     module.update_comment_ms, module.delete_comment_ms, module.upload_comment_file_ms,
     module.global_search_ms, module.health_check_ms,
+    aws_lambda_function.transition_season_statuses,
   ]
 }
 
@@ -68,6 +68,18 @@ module "create_comment_ms" {
       actions   = ["cognito-idp:AdminGetUser", "cognito-idp:AdminListGroupsForUser"]
       resources = [var.cognito_user_pool_arn]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -114,6 +126,18 @@ module "list_comments_ms" {
       actions   = ["dynamodb:Query"]
       resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -160,6 +184,22 @@ module "get_comment_ms" {
       actions   = ["dynamodb:Query"]
       resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
     },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.media_references.arn}/index/targetIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -206,6 +246,18 @@ module "update_comment_ms" {
       actions   = ["dynamodb:Query"]
       resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -256,6 +308,26 @@ module "delete_comment_ms" {
       actions   = ["dynamodb:Query"]
       resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
     },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.media_references.arn}/index/targetIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.media_references.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -306,6 +378,18 @@ module "upload_comment_file_ms" {
       actions   = ["dynamodb:Query"]
       resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -0,0 +1,445 @@
+# Drill library
+
+resource "aws_api_gateway_resource" "team_drills" {
+  rest_api_id = aws_api_gateway_rest_api.api.id
+  parent_id   = aws_api_gateway_resource.teams_id.id
+  path_part   = "drills"
+}
+
+resource "aws_api_gateway_resource" "team_drill_id" {
+  rest_api_id = aws_api_gateway_rest_api.api.id
+  parent_id   = aws_api_gateway_resource.team_drills.id
+  path_part   = "{drillId}"
+}
+
+resource "aws_api_gateway_resource" "team_drill_media" {
+  rest_api_id = aws_api_gateway_rest_api.api.id
+  parent_id   = aws_api_gateway_resource.team_drill_id.id
+  path_part   = "media"
+}
+
+resource "aws_api_gateway_resource" "team_drill_media_presign" {
+  rest_api_id = aws_api_gateway_rest_api.api.id
+  parent_id   = aws_api_gateway_resource.team_drill_media.id
+  path_part   = "presign"
+}
+
+resource "aws_api_gateway_resource" "goal_suggested_drills" {
+  rest_api_id = aws_api_gateway_rest_api.api.id
+  parent_id   = aws_api_gateway_resource.goal_id.id
+  path_part   = "suggested-drills"
+}
+
+module "create_drill_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = true
+  http_methods          = ["POST"]
+  name_overwrite        = "create-drill"
+  path_name             = "drills"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.team_drills.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "CreateDrill"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:PutItem"]
+      resources = [aws_dynamodb_table.drills.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.goals.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.seasons.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.events.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.team_drills,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+module "list_drills_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = false
+  http_methods          = ["GET"]
+  name_overwrite        = "list-drills"
+  path_name             = "drills"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.team_drills.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "ListDrills"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.drills.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.team_drills,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+module "get_drill_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = true
+  http_methods          = ["GET"]
+  name_overwrite        = "get-drill"
+  path_name             = "{drillId}"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.team_drill_id.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "GetDrill"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.drills.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.team_drill_id,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+module "update_drill_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = false
+  http_methods          = ["PATCH"]
+  name_overwrite        = "update-drill"
+  path_name             = "{drillId}"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.team_drill_id.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "UpdateDrill"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:GetItem", "dynamodb:PutItem"]
+      resources = [aws_dynamodb_table.drills.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.goals.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.seasons.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.events.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.team_drill_id,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+module "delete_drill_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = false
+  http_methods          = ["DELETE"]
+  name_overwrite        = "delete-drill"
+  path_name             = "{drillId}"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.team_drill_id.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "DeleteDrill"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:GetItem", "dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.drills.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.team_drill_id,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+module "upload_drill_media_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = true
+  http_methods          = ["GET"]
+  name_overwrite        = "upload-drill-media"
+  path_name             = "presign"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.team_drill_media_presign.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "UploadDrillMedia"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["s3:PutObject"]
+      resources = ["${aws_s3_bucket.this.arn}/drills/*"]
+    },
+    {
+      actions   = ["dynamodb:GetItem", "dynamodb:UpdateItem"]
+      resources = [aws_dynamodb_table.drills.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.team_drill_media_presign,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+module "suggest_drills_for_goal_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = true
+  http_methods          = ["GET"]
+  name_overwrite        = "suggest-drills-for-goal"
+  path_name             = "suggested-drills"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.goal_suggested_drills.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "SuggestDrillsForGoal"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.seasons.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.goals.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.drills.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.goal_suggested_drills,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
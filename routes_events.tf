@@ -0,0 +1,554 @@
+# Calendar events
+
+resource "aws_api_gateway_resource" "season_events" {
+  rest_api_id = aws_api_gateway_rest_api.api.id
+  parent_id   = aws_api_gateway_resource.season_id.id
+  path_part   = "events"
+}
+
+resource "aws_api_gateway_resource" "season_event_id" {
+  rest_api_id = aws_api_gateway_rest_api.api.id
+  parent_id   = aws_api_gateway_resource.season_events.id
+  path_part   = "{eventId}"
+}
+
+module "create_event_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = true
+  http_methods          = ["POST"]
+  name_overwrite        = "create-event"
+  path_name             = "events"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.season_events.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "CreateEvent"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:PutItem"]
+      resources = [aws_dynamodb_table.events.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.seasons.arn]
+    },
+    {
+      actions   = ["dynamodb:PutItem"]
+      resources = [aws_dynamodb_table.activities.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["cognito-idp:AdminGetUser", "cognito-idp:AdminListGroupsForUser"]
+      resources = [var.cognito_user_pool_arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.season_events,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+module "list_events_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = false
+  http_methods          = ["GET"]
+  name_overwrite        = "list-events"
+  path_name             = "events"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.season_events.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "ListEvents"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.events.arn}/index/seasonIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.seasons.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.season_events,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+module "get_event_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = true
+  http_methods          = ["GET"]
+  name_overwrite        = "get-event"
+  path_name             = "events"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.season_event_id.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "GetEvent"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.events.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.season_event_id,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+module "update_event_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = false
+  http_methods          = ["PATCH"]
+  name_overwrite        = "update-event"
+  path_name             = "events"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.season_event_id.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "UpdateEvent"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:GetItem", "dynamodb:PutItem"]
+      resources = [aws_dynamodb_table.events.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.seasons.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.season_event_id,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+module "delete_event_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = false
+  http_methods          = ["DELETE"]
+  name_overwrite        = "delete-event"
+  path_name             = "events"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.season_event_id.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "DeleteEvent"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:GetItem", "dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.events.arn]
+    },
+    {
+      actions = ["dynamodb:Scan", "dynamodb:UpdateItem"]
+      resources = [
+        aws_dynamodb_table.goals.arn,
+        aws_dynamodb_table.progress_reports.arn,
+      ]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.attendance.arn}/index/eventIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.attendance.arn]
+    },
+    {
+      actions   = ["dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.season_stats.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem", "dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.match_stats.arn]
+    },
+    {
+      actions   = ["dynamodb:Scan", "dynamodb:UpdateItem"]
+      resources = [aws_dynamodb_table.goals.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.match_stats.arn}/index/seasonIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:PutItem"]
+      resources = [aws_dynamodb_table.activities.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.drills.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:PutItem"]
+      resources = [aws_dynamodb_table.drills.arn]
+    },
+    {
+      actions   = ["dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.lineups.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.subgroups.arn]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.season_event_id,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+resource "aws_api_gateway_resource" "event_attendance" {
+  rest_api_id = aws_api_gateway_rest_api.api.id
+  parent_id   = aws_api_gateway_resource.season_event_id.id
+  path_part   = "attendance"
+}
+
+resource "aws_api_gateway_resource" "season_attendance" {
+  rest_api_id = aws_api_gateway_rest_api.api.id
+  parent_id   = aws_api_gateway_resource.season_id.id
+  path_part   = "attendance"
+}
+
+module "get_event_attendance_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = true
+  http_methods          = ["GET"]
+  name_overwrite        = "get-event-attendance"
+  path_name             = "attendance"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.event_attendance.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "GetEventAttendance"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.events.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.attendance.arn}/index/eventIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.event_attendance,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+module "update_event_attendance_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = false
+  http_methods          = ["PUT"]
+  name_overwrite        = "update-event-attendance"
+  path_name             = "attendance"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.event_attendance.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "UpdateEventAttendance"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.events.arn]
+    },
+    {
+      actions   = ["dynamodb:PutItem"]
+      resources = [aws_dynamodb_table.attendance.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.attendance.arn}/index/eventIdIndex"]
+    },
+    {
+      actions = ["dynamodb:Query"]
+      resources = [
+        "${aws_dynamodb_table.team_members.arn}/index/teamIdIndex",
+        "${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex",
+      ]
+    },
+    {
+      actions   = ["dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.season_stats.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.event_attendance,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+module "list_season_attendance_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = true
+  http_methods          = ["GET"]
+  name_overwrite        = "list-season-attendance"
+  path_name             = "attendance"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.season_attendance.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "ListSeasonAttendance"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.seasons.arn]
+    },
+    {
+      actions = ["dynamodb:Query"]
+      resources = [
+        "${aws_dynamodb_table.attendance.arn}/index/seasonIdIndex",
+        "${aws_dynamodb_table.events.arn}/index/seasonIdIndex",
+      ]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.season_attendance,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
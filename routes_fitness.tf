@@ -0,0 +1,681 @@
+# Fitness tests
+
+resource "aws_api_gateway_resource" "team_fitness_tests" {
+  rest_api_id = aws_api_gateway_rest_api.api.id
+  parent_id   = aws_api_gateway_resource.teams_id.id
+  path_part   = "fitness-tests"
+}
+
+resource "aws_api_gateway_resource" "team_fitness_test_id" {
+  rest_api_id = aws_api_gateway_rest_api.api.id
+  parent_id   = aws_api_gateway_resource.team_fitness_tests.id
+  path_part   = "{testId}"
+}
+
+resource "aws_api_gateway_resource" "team_fitness_test_results" {
+  rest_api_id = aws_api_gateway_rest_api.api.id
+  parent_id   = aws_api_gateway_resource.team_fitness_test_id.id
+  path_part   = "results"
+}
+
+resource "aws_api_gateway_resource" "team_fitness_test_result_id" {
+  rest_api_id = aws_api_gateway_rest_api.api.id
+  parent_id   = aws_api_gateway_resource.team_fitness_test_results.id
+  path_part   = "{resultId}"
+}
+
+resource "aws_api_gateway_resource" "team_fitness_test_leaderboard" {
+  rest_api_id = aws_api_gateway_rest_api.api.id
+  parent_id   = aws_api_gateway_resource.team_fitness_test_id.id
+  path_part   = "leaderboard"
+}
+
+resource "aws_api_gateway_resource" "team_member_fitness" {
+  rest_api_id = aws_api_gateway_rest_api.api.id
+  parent_id   = aws_api_gateway_resource.team_member_id.id
+  path_part   = "fitness"
+}
+
+module "create_fitness_test_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = true
+  http_methods          = ["POST"]
+  name_overwrite        = "create-fitness-test"
+  path_name             = "fitness-tests"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.team_fitness_tests.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "CreateFitnessTest"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:PutItem"]
+      resources = [aws_dynamodb_table.fitness_tests.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.fitness_tests.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.team_fitness_tests,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+module "list_fitness_tests_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = false
+  http_methods          = ["GET"]
+  name_overwrite        = "list-fitness-tests"
+  path_name             = "fitness-tests"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.team_fitness_tests.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "ListFitnessTests"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.fitness_tests.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.team_fitness_tests,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+module "update_fitness_test_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = true
+  http_methods          = ["PATCH"]
+  name_overwrite        = "update-fitness-test"
+  path_name             = "fitness-test"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.team_fitness_test_id.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "UpdateFitnessTest"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:GetItem", "dynamodb:PutItem"]
+      resources = [aws_dynamodb_table.fitness_tests.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.fitness_tests.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem", "dynamodb:Scan"]
+      resources = [aws_dynamodb_table.seasons.arn]
+    },
+    {
+      actions   = ["dynamodb:Scan", "dynamodb:UpdateItem"]
+      resources = [aws_dynamodb_table.goals.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.fitness_results.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:PutItem"]
+      resources = [aws_dynamodb_table.activities.arn]
+    },
+    {
+      actions   = ["dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.season_stats.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.subgroups.arn]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.team_fitness_test_id,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+module "delete_fitness_test_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = false
+  http_methods          = ["DELETE"]
+  name_overwrite        = "delete-fitness-test"
+  path_name             = "fitness-test"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.team_fitness_test_id.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "DeleteFitnessTest"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:GetItem", "dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.fitness_tests.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.fitness_tests.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.fitness_results.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.fitness_results.arn}/index/testIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem", "dynamodb:Scan"]
+      resources = [aws_dynamodb_table.seasons.arn]
+    },
+    {
+      actions   = ["dynamodb:Scan", "dynamodb:UpdateItem"]
+      resources = [aws_dynamodb_table.goals.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.fitness_results.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:PutItem"]
+      resources = [aws_dynamodb_table.activities.arn]
+    },
+    {
+      actions   = ["dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.season_stats.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.subgroups.arn]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.team_fitness_test_id,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+module "create_fitness_result_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = true
+  http_methods          = ["POST"]
+  name_overwrite        = "create-fitness-result"
+  path_name             = "fitness-results"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.team_fitness_test_results.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "CreateFitnessResult"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.fitness_tests.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.fitness_tests.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:PutItem"]
+      resources = [aws_dynamodb_table.fitness_results.arn]
+    },
+    {
+      actions = ["dynamodb:Query"]
+      resources = [
+        "${aws_dynamodb_table.team_members.arn}/index/teamIdIndex",
+        "${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex",
+      ]
+    },
+    {
+      actions   = ["dynamodb:GetItem", "dynamodb:Scan"]
+      resources = [aws_dynamodb_table.seasons.arn]
+    },
+    {
+      actions   = ["dynamodb:Scan", "dynamodb:UpdateItem"]
+      resources = [aws_dynamodb_table.goals.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.fitness_results.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:PutItem"]
+      resources = [aws_dynamodb_table.activities.arn]
+    },
+    {
+      actions   = ["dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.season_stats.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.subgroups.arn]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.team_fitness_test_results,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+module "list_fitness_results_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = false
+  http_methods          = ["GET"]
+  name_overwrite        = "list-fitness-results"
+  path_name             = "fitness-results"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.team_fitness_test_results.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "ListFitnessResults"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.fitness_tests.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.fitness_results.arn}/index/testIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.team_fitness_test_results,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+module "delete_fitness_result_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = true
+  http_methods          = ["DELETE"]
+  name_overwrite        = "delete-fitness-result"
+  path_name             = "fitness-result"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.team_fitness_test_result_id.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "DeleteFitnessResult"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.fitness_tests.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.fitness_tests.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem", "dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.fitness_results.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem", "dynamodb:Scan"]
+      resources = [aws_dynamodb_table.seasons.arn]
+    },
+    {
+      actions   = ["dynamodb:Scan", "dynamodb:UpdateItem"]
+      resources = [aws_dynamodb_table.goals.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.fitness_results.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:PutItem"]
+      resources = [aws_dynamodb_table.activities.arn]
+    },
+    {
+      actions   = ["dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.season_stats.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.subgroups.arn]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.team_fitness_test_result_id,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+module "get_fitness_leaderboard_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = true
+  http_methods          = ["GET"]
+  name_overwrite        = "get-fitness-leaderboard"
+  path_name             = "leaderboard"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.team_fitness_test_leaderboard.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "GetFitnessLeaderboard"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.fitness_tests.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.seasons.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.fitness_results.arn}/index/testIdIndex"]
+    },
+    {
+      actions = ["dynamodb:Query"]
+      resources = [
+        "${aws_dynamodb_table.team_members.arn}/index/teamIdIndex",
+        "${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex",
+      ]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.team_fitness_test_leaderboard,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+module "get_member_personal_bests_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = true
+  http_methods          = ["GET"]
+  name_overwrite        = "get-member-personal-bests"
+  path_name             = "fitness"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.team_member_fitness.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "GetMemberPersonalBests"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.team_members.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.fitness_tests.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.fitness_results.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.team_member_fitness,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
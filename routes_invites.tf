@@ -68,6 +68,18 @@ module "create_invite_ms" {
       actions   = ["ses:SendEmail", "ses:SendTemplatedEmail"]
       resources = ["*"]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -114,6 +126,18 @@ module "complete_invite_ms" {
       actions   = ["cognito-idp:AdminCreateUser", "cognito-idp:AdminAddUserToGroup", "cognito-idp:AdminGetUser", "cognito-idp:AdminListGroupsForUser", "cognito-idp:AdminDeleteUser", "cognito-idp:ListUsers"]
       resources = [var.cognito_user_pool_arn]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_settings.arn}/index/teamIdIndex", "${aws_dynamodb_table.guardians.arn}/index/playerIdIndex"]
+    },
+    {
+      actions   = ["ses:SendEmail", "ses:SendTemplatedEmail"]
+      resources = ["*"]
+    },
   ]
 
   depends_on = [
@@ -156,6 +180,18 @@ module "revoke_invite_ms" {
       actions   = ["dynamodb:Query"]
       resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -210,6 +246,18 @@ module "resend_invite_ms" {
       actions   = ["ses:SendEmail", "ses:SendTemplatedEmail"]
       resources = ["*"]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -250,6 +298,18 @@ module "get_invite_by_token_ms" {
         "${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex",
       ]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
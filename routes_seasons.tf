@@ -18,6 +18,18 @@ resource "aws_api_gateway_resource" "season_stats" {
   path_part   = "stats"
 }
 
+resource "aws_api_gateway_resource" "season_clone" {
+  rest_api_id = aws_api_gateway_rest_api.api.id
+  parent_id   = aws_api_gateway_resource.season_id.id
+  path_part   = "clone"
+}
+
+resource "aws_api_gateway_resource" "season_questionnaire" {
+  rest_api_id = aws_api_gateway_rest_api.api.id
+  parent_id   = aws_api_gateway_resource.season_id.id
+  path_part   = "questionnaire"
+}
+
 # Goals (nested under seasons)
 
 resource "aws_api_gateway_resource" "season_goals" {
@@ -58,6 +70,18 @@ resource "aws_api_gateway_resource" "progress_report_id" {
   path_part   = "{reportId}"
 }
 
+resource "aws_api_gateway_resource" "progress_reports_export" {
+  rest_api_id = aws_api_gateway_rest_api.api.id
+  parent_id   = aws_api_gateway_resource.season_progress_reports.id
+  path_part   = "export"
+}
+
+resource "aws_api_gateway_resource" "progress_report_export" {
+  rest_api_id = aws_api_gateway_rest_api.api.id
+  parent_id   = aws_api_gateway_resource.progress_report_id.id
+  path_part   = "export"
+}
+
 # ─── Season modules ──────────────────────────────────────────────────────────
 
 module "create_season_ms" {
@@ -86,13 +110,25 @@ module "create_season_ms" {
 
   additional_iam_statements = [
     {
-      actions   = ["dynamodb:PutItem"]
+      actions   = ["dynamodb:PutItem", "dynamodb:Scan"]
       resources = [aws_dynamodb_table.seasons.arn]
     },
     {
       actions   = ["dynamodb:Query"]
       resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -135,6 +171,18 @@ module "list_seasons_ms" {
       actions   = ["dynamodb:Query"]
       resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -177,6 +225,18 @@ module "get_season_ms" {
       actions   = ["dynamodb:Query"]
       resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -212,13 +272,25 @@ module "update_season_ms" {
 
   additional_iam_statements = [
     {
-      actions   = ["dynamodb:GetItem", "dynamodb:PutItem"]
+      actions   = ["dynamodb:GetItem", "dynamodb:PutItem", "dynamodb:UpdateItem", "dynamodb:Scan"]
       resources = [aws_dynamodb_table.seasons.arn]
     },
     {
       actions   = ["dynamodb:Query"]
       resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -271,6 +343,18 @@ module "delete_season_ms" {
       actions   = ["dynamodb:Query"]
       resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -309,13 +393,46 @@ module "get_season_stats_ms" {
       actions   = ["dynamodb:GetItem", "dynamodb:Scan", "dynamodb:Query"]
       resources = [aws_dynamodb_table.seasons.arn, aws_dynamodb_table.goals.arn, aws_dynamodb_table.progress_reports.arn, aws_dynamodb_table.progress.arn]
     },
+    {
+      actions   = ["dynamodb:GetItem", "dynamodb:PutItem"]
+      resources = [aws_dynamodb_table.season_stats.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.questionnaires.arn}/index/seasonIdIndex"]
+    },
     {
       actions = ["dynamodb:Query"]
       resources = [
         aws_dynamodb_table.team_members.arn,
+        "${aws_dynamodb_table.team_members.arn}/index/teamIdIndex",
         "${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex",
       ]
     },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.attendance.arn}/index/seasonIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.subgroups.arn]
+    },
   ]
 
   depends_on = [
@@ -325,19 +442,17 @@ module "get_season_stats_ms" {
   ]
 }
 
-# ─── Goal modules ─────────────────────────────────────────────────────────────
-
-module "create_goal_ms" {
+module "clone_season_ms" {
   source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
 
   api_id                = aws_api_gateway_rest_api.api.id
   code_dir              = "${path.module}/files/src"
-  cors_enabled          = false
+  cors_enabled          = true
   http_methods          = ["POST"]
-  name_overwrite        = "create-goal"
-  path_name             = "goals"
+  name_overwrite        = "clone-season"
+  path_name             = "clone"
   create_resource       = false
-  existing_resource_id  = aws_api_gateway_resource.season_goals.id
+  existing_resource_id  = aws_api_gateway_resource.season_clone.id
   prefix                = var.prefix
   authorizer_id         = aws_api_gateway_authorizer.this.id
   authorization_type    = "COGNITO_USER_POOLS"
@@ -348,50 +463,67 @@ module "create_goal_ms" {
   tags                  = local.tags
   layer_arns            = local.lambda_layer_arns
   json_logging          = true
-  handler_name          = "CreateGoal"
+  handler_name          = "CloneSeason"
   pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
 
   additional_iam_statements = [
     {
-      actions   = ["dynamodb:PutItem"]
+      actions   = ["dynamodb:GetItem", "dynamodb:PutItem", "dynamodb:Scan"]
+      resources = [aws_dynamodb_table.seasons.arn]
+    },
+    {
+      actions   = ["dynamodb:Scan", "dynamodb:PutItem", "dynamodb:UpdateItem"]
       resources = [aws_dynamodb_table.goals.arn]
     },
     {
-      actions   = ["dynamodb:GetItem"]
-      resources = [aws_dynamodb_table.seasons.arn]
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.questionnaires.arn}/index/seasonIdIndex"]
     },
     {
       actions   = ["dynamodb:PutItem"]
-      resources = [aws_dynamodb_table.activities.arn]
+      resources = [aws_dynamodb_table.questionnaires.arn]
+    },
+    {
+      actions = ["dynamodb:Query"]
+      resources = [
+        "${aws_dynamodb_table.team_members.arn}/index/teamIdIndex",
+        "${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex",
+      ]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
     },
     {
       actions   = ["dynamodb:Query"]
-      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
     },
     {
-      actions   = ["cognito-idp:AdminGetUser", "cognito-idp:AdminListGroupsForUser"]
-      resources = [var.cognito_user_pool_arn]
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
     },
   ]
 
   depends_on = [
     aws_api_gateway_rest_api.api,
-    aws_api_gateway_resource.season_goals,
+    aws_api_gateway_resource.season_clone,
     data.archive_file.shared_lambda_zip,
   ]
 }
 
-module "list_goals_ms" {
+# ─── Questionnaire modules ───────────────────────────────────────────────────
+
+module "get_season_questionnaire_ms" {
   source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
 
   api_id                = aws_api_gateway_rest_api.api.id
   code_dir              = "${path.module}/files/src"
   cors_enabled          = true
   http_methods          = ["GET"]
-  name_overwrite        = "list-goals"
-  path_name             = "goals"
+  name_overwrite        = "get-season-questionnaire"
+  path_name             = "questionnaire"
   create_resource       = false
-  existing_resource_id  = aws_api_gateway_resource.season_goals.id
+  existing_resource_id  = aws_api_gateway_resource.season_questionnaire.id
   prefix                = var.prefix
   authorizer_id         = aws_api_gateway_authorizer.this.id
   authorization_type    = "COGNITO_USER_POOLS"
@@ -402,17 +534,13 @@ module "list_goals_ms" {
   tags                  = local.tags
   layer_arns            = local.lambda_layer_arns
   json_logging          = true
-  handler_name          = "ListGoals"
+  handler_name          = "GetSeasonQuestionnaire"
   pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
 
   additional_iam_statements = [
     {
-      actions   = ["dynamodb:Scan", "dynamodb:Query"]
-      resources = [aws_dynamodb_table.goals.arn]
-    },
-    {
-      actions   = ["dynamodb:Scan"]
-      resources = [aws_dynamodb_table.progress.arn]
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.questionnaires.arn}/index/seasonIdIndex"]
     },
     {
       actions   = ["dynamodb:GetItem"]
@@ -423,29 +551,37 @@ module "list_goals_ms" {
       resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
     },
     {
-      actions   = ["cognito-idp:AdminGetUser", "cognito-idp:AdminListGroupsForUser"]
-      resources = [var.cognito_user_pool_arn]
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
     },
   ]
 
   depends_on = [
     aws_api_gateway_rest_api.api,
-    aws_api_gateway_resource.season_goals,
+    aws_api_gateway_resource.season_questionnaire,
     data.archive_file.shared_lambda_zip,
   ]
 }
 
-module "get_goal_ms" {
+module "update_season_questionnaire_ms" {
   source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
 
   api_id                = aws_api_gateway_rest_api.api.id
   code_dir              = "${path.module}/files/src"
-  cors_enabled          = true
-  http_methods          = ["GET"]
-  name_overwrite        = "get-goal"
-  path_name             = "{goalId}"
+  cors_enabled          = false
+  http_methods          = ["PUT"]
+  name_overwrite        = "update-season-questionnaire"
+  path_name             = "questionnaire"
   create_resource       = false
-  existing_resource_id  = aws_api_gateway_resource.goal_id.id
+  existing_resource_id  = aws_api_gateway_resource.season_questionnaire.id
   prefix                = var.prefix
   authorizer_id         = aws_api_gateway_authorizer.this.id
   authorization_type    = "COGNITO_USER_POOLS"
@@ -456,38 +592,62 @@ module "get_goal_ms" {
   tags                  = local.tags
   layer_arns            = local.lambda_layer_arns
   json_logging          = true
-  handler_name          = "GetGoal"
+  handler_name          = "UpdateSeasonQuestionnaire"
   pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
 
   additional_iam_statements = [
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.questionnaires.arn}/index/seasonIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:PutItem"]
+      resources = [aws_dynamodb_table.questionnaires.arn]
+    },
     {
       actions   = ["dynamodb:GetItem"]
-      resources = [aws_dynamodb_table.goals.arn, aws_dynamodb_table.seasons.arn]
+      resources = [aws_dynamodb_table.seasons.arn]
     },
     {
       actions   = ["dynamodb:Query"]
       resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
     },
+    {
+      actions   = ["dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.season_stats.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
     aws_api_gateway_rest_api.api,
-    aws_api_gateway_resource.goal_id,
+    aws_api_gateway_resource.season_questionnaire,
     data.archive_file.shared_lambda_zip,
   ]
 }
 
-module "update_goal_ms" {
+module "delete_season_questionnaire_ms" {
   source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
 
   api_id                = aws_api_gateway_rest_api.api.id
   code_dir              = "${path.module}/files/src"
   cors_enabled          = false
-  http_methods          = ["PATCH"]
-  name_overwrite        = "update-goal"
-  path_name             = "{goalId}"
+  http_methods          = ["DELETE"]
+  name_overwrite        = "delete-season-questionnaire"
+  path_name             = "questionnaire"
   create_resource       = false
-  existing_resource_id  = aws_api_gateway_resource.goal_id.id
+  existing_resource_id  = aws_api_gateway_resource.season_questionnaire.id
   prefix                = var.prefix
   authorizer_id         = aws_api_gateway_authorizer.this.id
   authorization_type    = "COGNITO_USER_POOLS"
@@ -498,50 +658,64 @@ module "update_goal_ms" {
   tags                  = local.tags
   layer_arns            = local.lambda_layer_arns
   json_logging          = true
-  handler_name          = "UpdateGoal"
+  handler_name          = "DeleteSeasonQuestionnaire"
   pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
 
   additional_iam_statements = [
     {
-      actions   = ["dynamodb:GetItem", "dynamodb:PutItem"]
-      resources = [aws_dynamodb_table.goals.arn]
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.questionnaires.arn}/index/seasonIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.questionnaires.arn]
     },
     {
       actions   = ["dynamodb:GetItem"]
       resources = [aws_dynamodb_table.seasons.arn]
     },
     {
-      actions   = ["dynamodb:PutItem"]
-      resources = [aws_dynamodb_table.activities.arn]
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.season_stats.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
     },
     {
       actions   = ["dynamodb:Query"]
-      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
     },
     {
-      actions   = ["cognito-idp:AdminGetUser", "cognito-idp:AdminListGroupsForUser"]
-      resources = [var.cognito_user_pool_arn]
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
     },
   ]
 
   depends_on = [
     aws_api_gateway_rest_api.api,
-    aws_api_gateway_resource.goal_id,
+    aws_api_gateway_resource.season_questionnaire,
     data.archive_file.shared_lambda_zip,
   ]
 }
 
-module "delete_goal_ms" {
+# ─── Goal modules ─────────────────────────────────────────────────────────────
+
+module "create_goal_ms" {
   source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
 
   api_id                = aws_api_gateway_rest_api.api.id
   code_dir              = "${path.module}/files/src"
   cors_enabled          = false
-  http_methods          = ["DELETE"]
-  name_overwrite        = "delete-goal"
-  path_name             = "{goalId}"
+  http_methods          = ["POST"]
+  name_overwrite        = "create-goal"
+  path_name             = "goals"
   create_resource       = false
-  existing_resource_id  = aws_api_gateway_resource.goal_id.id
+  existing_resource_id  = aws_api_gateway_resource.season_goals.id
   prefix                = var.prefix
   authorizer_id         = aws_api_gateway_authorizer.this.id
   authorization_type    = "COGNITO_USER_POOLS"
@@ -552,40 +726,408 @@ module "delete_goal_ms" {
   tags                  = local.tags
   layer_arns            = local.lambda_layer_arns
   json_logging          = true
-  handler_name          = "DeleteGoal"
+  handler_name          = "CreateGoal"
   pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
 
   additional_iam_statements = [
     {
-      actions   = ["dynamodb:GetItem", "dynamodb:DeleteItem"]
+      actions   = ["dynamodb:PutItem"]
       resources = [aws_dynamodb_table.goals.arn]
     },
     {
       actions   = ["dynamodb:GetItem"]
       resources = [aws_dynamodb_table.seasons.arn]
     },
+    {
+      actions   = ["dynamodb:PutItem"]
+      resources = [aws_dynamodb_table.activities.arn]
+    },
     {
       actions   = ["dynamodb:Query"]
       resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
     },
+    {
+      actions   = ["cognito-idp:AdminGetUser", "cognito-idp:AdminListGroupsForUser"]
+      resources = [var.cognito_user_pool_arn]
+    },
+    {
+      actions   = ["dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.season_stats.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.events.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem", "dynamodb:Scan", "dynamodb:UpdateItem"]
+      resources = [aws_dynamodb_table.goals.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.match_stats.arn}/index/seasonIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.fitness_tests.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.fitness_tests.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.fitness_results.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.subgroups.arn]
+    },
   ]
 
   depends_on = [
     aws_api_gateway_rest_api.api,
-    aws_api_gateway_resource.goal_id,
+    aws_api_gateway_resource.season_goals,
     data.archive_file.shared_lambda_zip,
   ]
 }
 
-module "upload_goal_file_ms" {
+module "list_goals_ms" {
   source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
 
   api_id                = aws_api_gateway_rest_api.api.id
   code_dir              = "${path.module}/files/src"
   cors_enabled          = true
   http_methods          = ["GET"]
-  name_overwrite        = "upload-goal-file"
-  path_name             = "presign"
+  name_overwrite        = "list-goals"
+  path_name             = "goals"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.season_goals.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "ListGoals"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:Scan", "dynamodb:Query"]
+      resources = [aws_dynamodb_table.goals.arn]
+    },
+    {
+      actions   = ["dynamodb:Scan"]
+      resources = [aws_dynamodb_table.progress.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.seasons.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["cognito-idp:AdminGetUser", "cognito-idp:AdminListGroupsForUser"]
+      resources = [var.cognito_user_pool_arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.season_goals,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+module "get_goal_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = true
+  http_methods          = ["GET"]
+  name_overwrite        = "get-goal"
+  path_name             = "{goalId}"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.goal_id.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "GetGoal"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.goals.arn, aws_dynamodb_table.seasons.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.media_references.arn}/index/targetIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.subgroups.arn]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.goal_id,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+module "update_goal_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = false
+  http_methods          = ["PATCH"]
+  name_overwrite        = "update-goal"
+  path_name             = "{goalId}"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.goal_id.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "UpdateGoal"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:GetItem", "dynamodb:PutItem"]
+      resources = [aws_dynamodb_table.goals.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.seasons.arn]
+    },
+    {
+      actions   = ["dynamodb:PutItem"]
+      resources = [aws_dynamodb_table.activities.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["cognito-idp:AdminGetUser", "cognito-idp:AdminListGroupsForUser"]
+      resources = [var.cognito_user_pool_arn]
+    },
+    {
+      actions   = ["dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.season_stats.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.events.arn]
+    },
+    {
+      actions   = ["dynamodb:Scan", "dynamodb:UpdateItem"]
+      resources = [aws_dynamodb_table.goals.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.match_stats.arn}/index/seasonIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.fitness_tests.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.fitness_tests.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.fitness_results.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.subgroups.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.guardians.arn}/index/playerIdIndex"]
+    },
+    {
+      actions   = ["ses:SendEmail", "ses:SendTemplatedEmail"]
+      resources = ["*"]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.goal_id,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+module "delete_goal_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = false
+  http_methods          = ["DELETE"]
+  name_overwrite        = "delete-goal"
+  path_name             = "{goalId}"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.goal_id.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "DeleteGoal"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:GetItem", "dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.goals.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.seasons.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.season_stats.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.drills.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:PutItem"]
+      resources = [aws_dynamodb_table.drills.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.media_references.arn}/index/targetIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.media_references.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.subgroups.arn]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.goal_id,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+module "upload_goal_file_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = true
+  http_methods          = ["GET"]
+  name_overwrite        = "upload-goal-file"
+  path_name             = "presign"
   create_resource       = false
   existing_resource_id  = aws_api_gateway_resource.goal_picture_presign.id
   prefix                = var.prefix
@@ -618,6 +1160,22 @@ module "upload_goal_file_ms" {
       actions   = ["dynamodb:Query"]
       resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.subgroups.arn]
+    },
   ]
 
   depends_on = [
@@ -660,12 +1218,16 @@ module "create_progress_report_ms" {
     },
     {
       actions   = ["dynamodb:GetItem"]
-      resources = [aws_dynamodb_table.seasons.arn]
+      resources = [aws_dynamodb_table.seasons.arn, aws_dynamodb_table.goals.arn]
     },
     {
       actions   = ["dynamodb:PutItem"]
       resources = [aws_dynamodb_table.activities.arn]
     },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.questionnaires.arn}/index/seasonIdIndex"]
+    },
     {
       actions   = ["dynamodb:Query"]
       resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
@@ -674,6 +1236,26 @@ module "create_progress_report_ms" {
       actions   = ["cognito-idp:AdminGetUser", "cognito-idp:AdminListGroupsForUser"]
       resources = [var.cognito_user_pool_arn]
     },
+    {
+      actions   = ["dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.season_stats.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.events.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -728,6 +1310,18 @@ module "list_progress_reports_ms" {
       actions   = ["cognito-idp:AdminGetUser", "cognito-idp:AdminListGroupsForUser"]
       resources = [var.cognito_user_pool_arn]
     },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -778,6 +1372,22 @@ module "get_progress_report_ms" {
       actions   = ["cognito-idp:AdminGetUser", "cognito-idp:AdminListGroupsForUser"]
       resources = [var.cognito_user_pool_arn]
     },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.media_references.arn}/index/targetIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -822,12 +1432,44 @@ module "update_progress_report_ms" {
     },
     {
       actions   = ["dynamodb:GetItem"]
-      resources = [aws_dynamodb_table.seasons.arn]
+      resources = [aws_dynamodb_table.seasons.arn, aws_dynamodb_table.goals.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.questionnaires.arn}/index/seasonIdIndex"]
     },
     {
       actions   = ["dynamodb:Query"]
       resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
     },
+    {
+      actions   = ["dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.season_stats.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.events.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.media_references.arn}/index/targetIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:PutItem", "dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.media_references.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -878,6 +1520,30 @@ module "delete_progress_report_ms" {
       actions   = ["dynamodb:Query"]
       resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
     },
+    {
+      actions   = ["dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.season_stats.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.media_references.arn}/index/targetIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:DeleteItem"]
+      resources = [aws_dynamodb_table.media_references.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
   ]
 
   depends_on = [
@@ -886,3 +1552,151 @@ module "delete_progress_report_ms" {
     data.archive_file.shared_lambda_zip,
   ]
 }
+
+module "export_member_progress_reports_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = true
+  http_methods          = ["GET"]
+  name_overwrite        = "export-member-progress-reports"
+  path_name             = "export"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.progress_reports_export.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "ExportMemberProgressReports"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:Scan"]
+      resources = [aws_dynamodb_table.progress_reports.arn]
+    },
+    {
+      actions   = ["dynamodb:Scan"]
+      resources = [aws_dynamodb_table.progress.arn, aws_dynamodb_table.comments.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.seasons.arn, aws_dynamodb_table.goals.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.questionnaires.arn}/index/seasonIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["cognito-idp:AdminGetUser", "cognito-idp:AdminListGroupsForUser"]
+      resources = [var.cognito_user_pool_arn]
+    },
+    {
+      actions   = ["s3:PutObject", "s3:GetObject"]
+      resources = ["${aws_s3_bucket.this.arn}/exports/*"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.progress_reports_export,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+module "export_progress_report_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = true
+  http_methods          = ["GET"]
+  name_overwrite        = "export-progress-report"
+  path_name             = "export"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.progress_report_export.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "ExportProgressReport"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.progress_reports.arn]
+    },
+    {
+      actions   = ["dynamodb:Scan"]
+      resources = [aws_dynamodb_table.progress.arn, aws_dynamodb_table.comments.arn]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.seasons.arn, aws_dynamodb_table.goals.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.questionnaires.arn}/index/seasonIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamUserIdIndex"]
+    },
+    {
+      actions   = ["cognito-idp:AdminGetUser", "cognito-idp:AdminListGroupsForUser"]
+      resources = [var.cognito_user_pool_arn]
+    },
+    {
+      actions   = ["s3:PutObject", "s3:GetObject"]
+      resources = ["${aws_s3_bucket.this.arn}/exports/*"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.progress_report_export,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
@@ -124,6 +124,10 @@ module "delete_user_ms" {
     {
       actions   = ["dynamodb:Query", "dynamodb:UpdateItem"]
       resources = [aws_dynamodb_table.team_members.arn, "${aws_dynamodb_table.team_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/teamIdIndex"]
     }
   ]
 
@@ -163,6 +167,14 @@ module "update_user_ms" {
       actions   = ["cognito-idp:AdminUpdateUserAttributes", "cognito-idp:AdminGetUser", "cognito-idp:AdminAddUserToGroup", "cognito-idp:AdminRemoveUserFromGroup", "cognito-idp:AdminEnableUser", "cognito-idp:AdminDisableUser"]
       resources = [var.cognito_user_pool_arn]
     },
+    {
+      actions = ["dynamodb:Query", "dynamodb:DeleteItem"]
+      resources = [
+        aws_dynamodb_table.team_members.arn,
+        "${aws_dynamodb_table.team_members.arn}/index/userIdIndex",
+        "${aws_dynamodb_table.team_members.arn}/index/teamIdIndex",
+      ]
+    },
   ]
 
   depends_on = [
@@ -171,3 +183,55 @@ module "update_user_ms" {
     data.archive_file.shared_lambda_zip,
   ]
 }
+
+resource "aws_api_gateway_resource" "user_memberships" {
+  rest_api_id = aws_api_gateway_rest_api.api.id
+  parent_id   = aws_api_gateway_resource.user_id.id
+  path_part   = "memberships"
+}
+
+module "list_user_memberships_ms" {
+  source = "github.com/FPGSchiba/terraform-aws-microservice?ref=v2.4.0"
+
+  api_id                = aws_api_gateway_rest_api.api.id
+  code_dir              = "${path.module}/files/src"
+  cors_enabled          = true
+  http_methods          = ["GET"]
+  name_overwrite        = "list-user-memberships"
+  path_name             = "memberships"
+  create_resource       = false
+  existing_resource_id  = aws_api_gateway_resource.user_memberships.id
+  prefix                = var.prefix
+  authorizer_id         = aws_api_gateway_authorizer.this.id
+  authorization_type    = "COGNITO_USER_POOLS"
+  enable_tracing        = true
+  timeout               = 29
+  vpc_networked         = false
+  environment_variables = local.lambda_environment_variables
+  tags                  = local.tags
+  layer_arns            = local.lambda_layer_arns
+  json_logging          = true
+  handler_name          = "ListUserMemberships"
+  pre_built_zip         = data.archive_file.shared_lambda_zip.output_path
+
+  additional_iam_statements = [
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.team_members.arn}/index/userIdIndex"]
+    },
+    {
+      actions   = ["dynamodb:GetItem"]
+      resources = [aws_dynamodb_table.teams.arn]
+    },
+    {
+      actions   = ["dynamodb:Query"]
+      resources = ["${aws_dynamodb_table.organization_members.arn}/index/userIdIndex"]
+    },
+  ]
+
+  depends_on = [
+    aws_api_gateway_rest_api.api,
+    aws_api_gateway_resource.user_memberships,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
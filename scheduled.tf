@@ -0,0 +1,141 @@
+# ─── Scheduled handlers ──────────────────────────────────────────────────────
+# These Lambdas are triggered by EventBridge instead of API Gateway, so they use the
+# shared binary directly rather than the microservice module.
+
+data "aws_iam_policy_document" "scheduled_assume_role" {
+  statement {
+    effect = "Allow"
+
+    principals {
+      type        = "Service"
+      identifiers = ["lambda.amazonaws.com"]
+    }
+
+    actions = ["sts:AssumeRole"]
+  }
+}
+
+# Season lifecycle: moves seasons from planned to active to completed by their dates.
+# Runs hourly so that every team time zone is picked up shortly after its midnight.
+resource "aws_iam_role" "transition_season_statuses" {
+  name               = "${var.prefix}-transition-season-statuses"
+  assume_role_policy = data.aws_iam_policy_document.scheduled_assume_role.json
+
+  tags = local.tags
+}
+
+resource "aws_iam_role_policy_attachment" "transition_season_statuses_logs" {
+  role       = aws_iam_role.transition_season_statuses.name
+  policy_arn = "arn:aws:iam::aws:policy/service-role/AWSLambdaBasicExecutionRole"
+}
+
+resource "aws_iam_role_policy_attachment" "transition_season_statuses_tracing" {
+  role       = aws_iam_role.transition_season_statuses.name
+  policy_arn = "arn:aws:iam::aws:policy/AWSXRayDaemonWriteAccess"
+}
+
+data "aws_iam_policy_document" "transition_season_statuses" {
+  statement {
+    actions   = ["dynamodb:Scan", "dynamodb:UpdateItem"]
+    resources = [aws_dynamodb_table.seasons.arn]
+  }
+
+  statement {
+    actions   = ["dynamodb:GetItem"]
+    resources = [aws_dynamodb_table.teams.arn]
+  }
+
+  statement {
+    actions   = ["dynamodb:Query"]
+    resources = ["${aws_dynamodb_table.team_members.arn}/index/teamIdIndex"]
+  }
+
+  statement {
+    actions   = ["dynamodb:Query"]
+    resources = ["${aws_dynamodb_table.team_roles.arn}/index/teamIdIndex"]
+  }
+
+  statement {
+    actions   = ["dynamodb:PutItem"]
+    resources = [aws_dynamodb_table.activities.arn]
+  }
+
+  statement {
+    actions   = ["cognito-idp:AdminGetUser", "cognito-idp:AdminListGroupsForUser"]
+    resources = [var.cognito_user_pool_arn]
+  }
+
+  statement {
+    actions   = ["ses:SendEmail", "ses:SendTemplatedEmail"]
+    resources = ["*"]
+  }
+}
+
+resource "aws_iam_role_policy" "transition_season_statuses" {
+  name   = "default"
+  role   = aws_iam_role.transition_season_statuses.id
+  policy = data.aws_iam_policy_document.transition_season_statuses.json
+}
+
+resource "aws_cloudwatch_log_group" "transition_season_statuses" {
+  name              = "/aws/lambda/${var.prefix}-transition-season-statuses"
+  retention_in_days = 30
+
+  tags = local.tags
+}
+
+resource "aws_lambda_function" "transition_season_statuses" {
+  function_name    = "${var.prefix}-transition-season-statuses"
+  role             = aws_iam_role.transition_season_statuses.arn
+  runtime          = "provided.al2023"
+  handler          = "bootstrap"
+  architectures    = ["x86_64"]
+  filename         = data.archive_file.shared_lambda_zip.output_path
+  source_code_hash = data.archive_file.shared_lambda_zip.output_base64sha256
+  timeout          = 300
+  layers           = local.lambda_layer_arns
+
+  environment {
+    variables = merge(local.lambda_environment_variables, {
+      "HANDLER" = "TransitionSeasonStatuses"
+    })
+  }
+
+  tracing_config {
+    mode = "Active"
+  }
+
+  logging_config {
+    log_format = "JSON"
+    log_group  = aws_cloudwatch_log_group.transition_season_statuses.name
+  }
+
+  tags = local.tags
+
+  depends_on = [
+    aws_iam_role_policy_attachment.transition_season_statuses_logs,
+    aws_cloudwatch_log_group.transition_season_statuses,
+    data.archive_file.shared_lambda_zip,
+  ]
+}
+
+resource "aws_cloudwatch_event_rule" "transition_season_statuses" {
+  name                = "${var.prefix}-transition-season-statuses"
+  description         = "Moves seasons between planned, active and completed based on their dates"
+  schedule_expression = "cron(5 * * * ? *)"
+
+  tags = local.tags
+}
+
+resource "aws_cloudwatch_event_target" "transition_season_statuses" {
+  rule = aws_cloudwatch_event_rule.transition_season_statuses.name
+  arn  = aws_lambda_function.transition_season_statuses.arn
+}
+
+resource "aws_lambda_permission" "transition_season_statuses" {
+  statement_id  = "AllowExecutionFromEventBridge"
+  action        = "lambda:InvokeFunction"
+  function_name = aws_lambda_function.transition_season_statuses.function_name
+  principal     = "events.amazonaws.com"
+  source_arn    = aws_cloudwatch_event_rule.transition_season_statuses.arn
+}